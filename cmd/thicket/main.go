@@ -8,18 +8,36 @@ import (
 
 	"github.com/abarth/thicket/internal/commands"
 	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
 )
 
 func main() {
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "error: %v\n", err)
-		os.Exit(1)
+	os.Exit(runMain(os.Args[1:]))
+}
+
+// runMain runs the command line args, reports any error on stderr unless the
+// command already reported it, and returns the process exit code: 0 on
+// success, or the code for the error's category (see the Exit constants in
+// internal/errors).
+func runMain(args []string) int {
+	if err := run(args); err != nil {
+		if !thickerr.IsShown(err) {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		return thickerr.ExitCode(err)
 	}
+	return 0
 }
 
-func run() error {
+func run(argv []string) error {
 	fs := flag.NewFlagSet("thicket", flag.ExitOnError)
 	dataDir := fs.String("data-dir", "", "Custom .thicket directory")
+	verbose := fs.Bool("verbose", false, "Print timing and cache rebuild details to stderr")
+	projectRoot := fs.String("project-root", "", "Use the Thicket project in this directory")
+	noWalk := fs.Bool("no-walk", false, "Only look for .thicket in the current directory")
+	noHooks := fs.Bool("no-hooks", false, "Don't run hooks from config.json")
+	memoryCache := fs.Bool("memory-cache", false, "Keep the cache in memory instead of in cache.db")
 	fs.Usage = printUsage
 
 	// We want to parse global flags before the command.
@@ -27,18 +45,33 @@ func run() error {
 	// stops at the first non-flag argument if we don't use flag.CommandLine.
 	// Actually, by default it continues. We need to handle this.
 
-	if len(os.Args) < 2 {
+	if len(argv) == 0 {
 		printUsage()
 		return nil
 	}
 
-	if err := fs.Parse(os.Args[1:]); err != nil {
+	if err := fs.Parse(argv); err != nil {
 		return err
 	}
 
 	if *dataDir != "" {
 		config.SetDataDir(*dataDir)
 	}
+	if *verbose {
+		storage.SetVerbose(os.Stderr)
+	}
+	if *projectRoot != "" {
+		config.SetProjectRoot(*projectRoot)
+	}
+	if *noWalk {
+		config.SetNoWalk(true)
+	}
+	if *noHooks {
+		commands.DisableHooks()
+	}
+	if *memoryCache {
+		storage.SetMemoryCache(true)
+	}
 
 	args := fs.Args()
 	if len(args) == 0 {
@@ -61,14 +94,50 @@ func run() error {
 		return commands.Ready(remainingArgs)
 	case "show":
 		return commands.Show(remainingArgs)
+	case "blame":
+		return commands.Blame(remainingArgs)
 	case "update":
 		return commands.Update(remainingArgs)
+	case "rename":
+		return commands.Rename(remainingArgs)
+	case "priority-set":
+		return commands.PrioritySet(remainingArgs)
+	case "move":
+		return commands.Move(remainingArgs)
+	case "tag":
+		return commands.Tag(remainingArgs)
+	case "untag":
+		return commands.Untag(remainingArgs)
+	case "label":
+		return commands.Label(remainingArgs)
 	case "close":
 		return commands.Close(remainingArgs)
+	case "reopen":
+		return commands.Reopen(remainingArgs)
+	case "merge":
+		return commands.Merge(remainingArgs)
+	case "delete":
+		return commands.Delete(remainingArgs)
+	case "restore":
+		return commands.Restore(remainingArgs)
 	case "comment":
 		return commands.Comment(remainingArgs)
 	case "link":
 		return commands.Link(remainingArgs)
+	case "export":
+		return commands.Export(remainingArgs)
+	case "diff":
+		return commands.Diff(remainingArgs)
+	case "stats":
+		return commands.Stats(remainingArgs)
+	case "sync":
+		return commands.Sync(remainingArgs)
+	case "doctor":
+		return commands.Doctor(remainingArgs)
+	case "serve":
+		return commands.Serve(remainingArgs)
+	case "watch":
+		return commands.Watch(remainingArgs)
 	case "quickstart":
 		return commands.Quickstart(remainingArgs)
 	case "tui":
@@ -91,26 +160,51 @@ Usage:
   thicket <command> [arguments]
 
 Global Flags:
-  --data-dir  Custom .thicket directory location
-  --json      Output in JSON format (available for most commands)
+  --data-dir <DIR>      Custom .thicket directory location
+  --json                Output in JSON format (available for most commands)
+  --verbose             Print timing and cache rebuild details to stderr
+  --project-root <DIR>  Use the Thicket project in DIR
+  --no-walk             Only look for .thicket in the current directory
+  --no-hooks            Don't run hooks from config.json
+  --memory-cache        Keep the cache in memory instead of in cache.db
 
 Environment Variables:
-  THICKET_DIR  Custom .thicket directory location (flag takes precedence)
+  THICKET_DIR    Custom .thicket directory location (flag takes precedence)
+  THICKET_USER   Your name, recorded as the comment author (default: git user.name)
+  THICKET_DEBUG  Set to 1 to log storage internals to stderr
 
 Commands:
-  init        Initialize a new Thicket project
-  add         Create a new ticket
-  list        List tickets (alias: ls)
-  ready       Show next actionable ticket
-  show        Display a ticket
-  update      Modify a ticket
-  close       Close a ticket
-  comment     Add a comment to a ticket
-  link        Create dependencies between tickets
-  quickstart  Show guide for coding agents
-  tui         Launch interactive terminal UI
-  help        Show this help message
-  version     Show version information
+  init         Initialize a new Thicket project
+  add          Create a new ticket
+  list         List tickets (alias: ls)
+  ready        Show next actionable ticket
+  show         Display a ticket
+  blame        Show who created and last changed a ticket
+  update       Modify a ticket
+  rename       Change a ticket's title
+  priority-set Set a ticket's priority
+  move         Reorder a ticket within its priority
+  tag          Add labels to a ticket
+  untag        Remove labels from a ticket
+  label        Rename a label on every ticket
+  close        Close a ticket
+  reopen       Reopen a closed ticket
+  merge        Fold a duplicate ticket into another
+  delete       Delete a ticket (can be restored)
+  restore      Restore a deleted ticket
+  comment      Add a comment to a ticket
+  link         Create dependencies between tickets
+  export       Write tickets, comments, and dependencies as JSONL
+  diff         Show how tickets changed between two git revisions
+  stats        Count tickets and total estimates by status
+  sync         Bring the cache up to date with tickets.jsonl
+  doctor       Check the project's data and cache for problems
+  serve        Serve a JSON HTTP API over the tickets
+  watch        Report or run a command when tickets change
+  quickstart   Show guide for coding agents
+  tui          Launch interactive terminal UI
+  help         Show this help message
+  version      Show version information
 
 Run 'thicket <command> --help' for more information on a command.
 
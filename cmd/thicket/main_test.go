@@ -0,0 +1,118 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	thickerr "github.com/abarth/thicket/internal/errors"
+)
+
+func TestRunMain_ExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir() error = %v", err)
+	}
+	defer os.Chdir(oldWd)
+	t.Setenv("THICKET_DIR", "")
+
+	if code := runMain([]string{"--no-walk", "list"}); code != thickerr.ExitNotInitialized {
+		t.Errorf("list before init exit code = %d, want %d", code, thickerr.ExitNotInitialized)
+	}
+
+	if code := runMain([]string{"init", "--project", "TH"}); code != 0 {
+		t.Fatalf("init exit code = %d, want 0", code)
+	}
+
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"show", "TH-ffffff"}, thickerr.ExitNotFound},
+		{[]string{"show", "not-an-id"}, thickerr.ExitValidation},
+		{[]string{"add", "--title", "Bad", "--priority", "99"}, thickerr.ExitValidation},
+		{[]string{"add", "--title", "Twice"}, 0},
+		{[]string{"add", "--strict", "--title", "Twice"}, thickerr.ExitConflict},
+	}
+	for _, tt := range tests {
+		if code := runMain(tt.args); code != tt.want {
+			t.Errorf("runMain(%v) = %d, want %d", tt.args, code, tt.want)
+		}
+	}
+}
+
+// chdirTemp changes into a new temporary directory for the rest of the test.
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Chdir() error = %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	t.Setenv("THICKET_DIR", "")
+}
+
+// runMainOutput runs runMain with args and returns its exit code and stdout.
+func runMainOutput(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+	oldStdout := os.Stdout
+	os.Stdout = w
+	code := runMain(args)
+	os.Stdout = oldStdout
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading stdout: %v", err)
+	}
+	return code, string(out)
+}
+
+func TestRunMain_LsOneline(t *testing.T) {
+	chdirTemp(t)
+
+	if code := runMain([]string{"init", "--project", "TH"}); code != 0 {
+		t.Fatalf("init exit code = %d, want 0", code)
+	}
+	runMainOutput(t, "add", "--title", "Fix the parser", "--priority", "1")
+	runMainOutput(t, "add", "--title", "Write docs", "--priority", "2")
+
+	code, ls := runMainOutput(t, "ls", "--oneline")
+	if code != 0 {
+		t.Fatalf("ls --oneline exit code = %d, want 0", code)
+	}
+	lines := strings.Split(strings.TrimSuffix(ls, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ls --oneline printed %d lines, want 2:\n%s", len(lines), ls)
+	}
+	for i, title := range []string{"Fix the parser", "Write docs"} {
+		id, rest, ok := strings.Cut(lines[i], " ")
+		if !ok || !strings.HasPrefix(id, "TH-") || rest != title {
+			t.Errorf("line %d = %q, want \"<ID> %s\"", i, lines[i], title)
+		}
+	}
+
+	// ls is an alias, so every flag must behave the same under both names.
+	for _, args := range [][]string{{"--oneline"}, {"--tsv"}, {"--json", "--fields", "id,title"}} {
+		_, viaLs := runMainOutput(t, append([]string{"ls"}, args...)...)
+		_, viaList := runMainOutput(t, append([]string{"list"}, args...)...)
+		if viaLs != viaList {
+			t.Errorf("ls %v output differs from list:\n%s\nvs\n%s", args, viaLs, viaList)
+		}
+	}
+
+	if code, _ := runMainOutput(t, "ls", "--oneline", "--json"); code == 0 {
+		t.Error("ls --oneline --json exit code = 0, want an error")
+	}
+}
@@ -1,8 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/abarth/thicket/internal/config"
@@ -23,6 +28,45 @@ func (l *labelSlice) Set(value string) error {
 	return nil
 }
 
+// addSpec is a ticket read by add --stdin. Its fields mirror the add flags.
+type addSpec struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"`
+	Priority    *int     `json:"priority"`
+	Assignee    string   `json:"assignee"`
+	Estimate    int      `json:"estimate"`
+	Labels      []string `json:"labels"`
+	Blocks      string   `json:"blocks"`
+	BlockedBy   string   `json:"blocked_by"`
+	CreatedFrom string   `json:"created_from"`
+}
+
+// addSpecFlags are the add flags that set ticket fields, which --stdin
+// replaces.
+var addSpecFlags = []string{"title", "description", "type", "priority", "assignee", "estimate", "label", "blocks", "blocked-by", "created-from", "edit"}
+
+// readAddSpec reads a single JSON ticket from r, rejecting unknown keys.
+func readAddSpec(r io.Reader) (*addSpec, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var spec addSpec
+	if err := dec.Decode(&spec); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("no input")
+		}
+		return nil, thickerr.WithHint(
+			fmt.Sprintf("Invalid ticket JSON: %v", err),
+			`Pipe a single object, e.g. {"title": "Fix login", "priority": 1, "labels": ["bug"]}`,
+		)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, thickerr.WithHint("Invalid ticket JSON: expected a single object", "Run 'thicket add --stdin' once per ticket")
+	}
+	return &spec, nil
+}
+
 // Add creates a new ticket.
 func Add(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("add")
@@ -30,15 +74,22 @@ func Add(args []string) error {
 	description := fs.String("description", "", "Ticket description")
 	issueType := fs.String("type", "", "Ticket type (e.g., bug, feature, task)")
 	priority := fs.Int("priority", 2, "Ticket priority (lower = higher priority)")
-	assignee := fs.String("assignee", "", "Assign ticket to person")
+	assignee := fs.String("assignee", "", "Assign ticket to person (\"me\" for yourself)")
+	estimate := fs.Int("estimate", 0, "Rough size in points")
 	blocks := fs.String("blocks", "", "Existing ticket that is blocked by this new ticket")
 	blockedBy := fs.String("blocked-by", "", "Existing ticket that blocks this new ticket")
 	createdFrom := fs.String("created-from", "", "Existing ticket this was created from")
+	edit := fs.Bool("edit", false, "Write the description in $EDITOR")
+	allowDuplicate := fs.Bool("allow-duplicate", false, "Skip the check for an open ticket with the same title")
+	strict := fs.Bool("strict", false, "Fail instead of warning when an open ticket has the same title")
+	noLinkComment := fs.Bool("no-link-comment", false, "With --created-from, don't comment on the new ticket naming the parent")
+	idempotencyKey := fs.String("idempotency-key", "", "If a ticket was already added with this key, report it instead of adding another")
 	var labels labelSlice
 	fs.Var(&labels, "label", "Add a label (can be specified multiple times)")
+	fromStdin := fs.Bool("stdin", false, "Read the ticket from stdin as a JSON object instead of from flags")
 
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: thicket add [--title <TITLE>] [--description <DESC>] [--type <TYPE>] [--priority <N>] [--assignee <NAME>] [--label <LABEL>]... [--blocks <ID>] [--blocked-by <ID>] [--created-from <ID>] [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "Usage: thicket add [--title <TITLE>] [--description <DESC>] [--type <TYPE>] [--priority <N>] [--assignee <NAME>] [--estimate <N>] [--label <LABEL>]... [--blocks <ID>] [--blocked-by <ID>] [--created-from <ID> [--no-link-comment]] [--edit] [--stdin] [--allow-duplicate] [--strict] [--idempotency-key <KEY>] [--json] [--data-dir <DIR>]")
 		fmt.Fprintln(os.Stderr, "\nCreate a new ticket.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
@@ -50,10 +101,41 @@ func Add(args []string) error {
 
 	handleGlobalFlags(*dataDir)
 
+	if *fromStdin {
+		var conflict string
+		fs.Visit(func(f *flag.Flag) {
+			if conflict == "" && slices.Contains(addSpecFlags, f.Name) {
+				conflict = f.Name
+			}
+		})
+		if conflict != "" {
+			return thickerr.WithHint(
+				fmt.Sprintf("Cannot combine --stdin and --%s", conflict),
+				"Put the ticket's fields in the JSON object instead",
+			)
+		}
+		spec, err := readAddSpec(os.Stdin)
+		if err != nil {
+			return err
+		}
+		*title, *description, *issueType, *assignee = spec.Title, spec.Description, spec.Type, spec.Assignee
+		if spec.Priority != nil {
+			*priority = *spec.Priority
+		}
+		*estimate = spec.Estimate
+		labels = spec.Labels
+		*blocks, *blockedBy, *createdFrom = spec.Blocks, spec.BlockedBy, spec.CreatedFrom
+	}
+
 	if *title == "" {
 		return thickerr.MissingRequired("title")
 	}
 
+	assigneeName, err := resolveAssignee(*assignee)
+	if err != nil {
+		return err
+	}
+
 	root, err := config.FindRoot()
 	if err != nil {
 		return wrapConfigError(err)
@@ -63,6 +145,18 @@ func Add(args []string) error {
 	if err != nil {
 		return wrapConfigError(err)
 	}
+	applyConfig(cfg)
+
+	if *edit {
+		edited, err := editText(*description)
+		if err != nil {
+			return err
+		}
+		if edited == "" {
+			return thickerr.New("Aborting ticket creation: the editor returned an empty description")
+		}
+		*description = edited
+	}
 
 	paths := config.GetPaths(root)
 	store, err := storage.Open(paths)
@@ -71,9 +165,68 @@ func Add(args []string) error {
 	}
 	defer store.Close()
 
-	t, err := ticket.New(cfg.ProjectCode, *title, *description, ticket.Type(*issueType), *priority, labels, *assignee)
+	// A retry of an add that succeeded reports the ticket it created.
+	if *idempotencyKey != "" {
+		existing, err := store.FindByIdempotencyKey(*idempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			message := fmt.Sprintf("Ticket %s was already added with idempotency key %q", existing.ID, *idempotencyKey)
+			if *jsonOutput {
+				return printJSON(SuccessResponse{Success: true, ID: existing.ID, Message: message})
+			}
+			fmt.Println(message)
+			return nil
+		}
+	}
+
+	// Resolve linked tickets before creating anything so that a bad ID
+	// doesn't leave a half-linked ticket behind.
+	var blocksID, blockedByID string
+	var parent *ticket.Ticket
+	if *blocks != "" {
+		if blocksID, err = resolveTicketID(store, *blocks); err != nil {
+			return err
+		}
+	}
+	if *blockedBy != "" {
+		if blockedByID, err = resolveTicketID(store, *blockedBy); err != nil {
+			return err
+		}
+	}
+	if *createdFrom != "" {
+		id, err := resolveTicketID(store, *createdFrom)
+		if err != nil {
+			return err
+		}
+		if parent, err = store.Get(id); err != nil {
+			return err
+		}
+		if parent == nil {
+			return thickerr.TicketNotFound(id)
+		}
+	}
+
+	t, err := ticket.New(cfg.ProjectCode, *title, *description, ticket.Type(*issueType), *priority, labels, assigneeName, *estimate)
 	if err != nil {
-		return err
+		return wrapTicketError(err)
+	}
+	t.CreatedBy = config.ResolveIdentity()
+	t.IdempotencyKey = *idempotencyKey
+
+	var warning string
+	if !*allowDuplicate {
+		existing, err := store.FindByTitle(t.Title)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			if *strict {
+				return thickerr.DuplicateTitle(existing[0].ID)
+			}
+			warning = fmt.Sprintf("An open ticket with this title already exists: %s", existing[0].ID)
+		}
 	}
 
 	if err := store.Add(t); err != nil {
@@ -81,9 +234,8 @@ func Add(args []string) error {
 	}
 
 	// Create links if specified
-	if *blocks != "" {
-		targetID := normalizeTicketID(*blocks)
-		dep, err := ticket.NewDependency(targetID, t.ID, ticket.DependencyBlockedBy)
+	if blocksID != "" {
+		dep, err := ticket.NewDependency(blocksID, t.ID, ticket.DependencyBlockedBy)
 		if err != nil {
 			return err
 		}
@@ -91,9 +243,8 @@ func Add(args []string) error {
 			return err
 		}
 	}
-	if *blockedBy != "" {
-		targetID := normalizeTicketID(*blockedBy)
-		dep, err := ticket.NewDependency(t.ID, targetID, ticket.DependencyBlockedBy)
+	if blockedByID != "" {
+		dep, err := ticket.NewDependency(t.ID, blockedByID, ticket.DependencyBlockedBy)
 		if err != nil {
 			return err
 		}
@@ -101,25 +252,43 @@ func Add(args []string) error {
 			return err
 		}
 	}
-	if *createdFrom != "" {
-		targetID := normalizeTicketID(*createdFrom)
-		dep, err := ticket.NewDependency(t.ID, targetID, ticket.DependencyCreatedFrom)
+	if parent != nil {
+		dep, err := ticket.NewDependency(t.ID, parent.ID, ticket.DependencyCreatedFrom)
 		if err != nil {
 			return err
 		}
 		if err := store.AddDependency(dep); err != nil {
 			return err
 		}
+
+		// Record the parent in the comment stream too, so anyone reading
+		// the new ticket's history can trace where it came from.
+		if !*noLinkComment {
+			c, err := ticket.NewComment(t.ID, fmt.Sprintf("Created from %s: %s", parent.ID, parent.Title))
+			if err != nil {
+				return err
+			}
+			c.Author = t.CreatedBy
+			if err := store.AddComment(c); err != nil {
+				return err
+			}
+		}
 	}
 
+	runHook(root, cfg, config.HookOnCreate, t)
+
 	if *jsonOutput {
 		return printJSON(SuccessResponse{
 			Success: true,
 			ID:      t.ID,
 			Message: fmt.Sprintf("Created ticket %s", t.ID),
+			Hint:    warning,
 		})
 	}
 
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 	fmt.Printf("Created ticket %s\n", t.ID)
 	return nil
 }
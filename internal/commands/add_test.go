@@ -1,10 +1,14 @@
 package commands
 
 import (
+	"encoding/json"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
 )
 
 func TestAdd(t *testing.T) {
@@ -164,3 +168,371 @@ func TestAdd_Links(t *testing.T) {
 	}
 	store.Close()
 }
+
+func TestAdd_Edit(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	stubEditor(t, "Description from editor")
+
+	if err := Add([]string{"--title", "Edited", "--edit"}); err != nil {
+		t.Fatalf("Add(--edit) error = %v", err)
+	}
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	if len(tickets) != 1 {
+		t.Fatalf("Expected 1 ticket, got %d", len(tickets))
+	}
+	if tickets[0].Description != "Description from editor" {
+		t.Errorf("Description = %q, want 'Description from editor'", tickets[0].Description)
+	}
+}
+
+func TestAdd_EditEmptyAborts(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	t.Setenv("EDITOR", "true")
+
+	if err := Add([]string{"--title", "Edited", "--edit"}); err == nil {
+		t.Error("Add(--edit) expected error when editor returns empty description")
+	}
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	tickets, _ := store.List(nil)
+	store.Close()
+	if len(tickets) != 0 {
+		t.Errorf("Expected no tickets after abort, got %d", len(tickets))
+	}
+}
+
+func TestAdd_TitleTooLong(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	err := Add([]string{"--title", strings.Repeat("x", 201)})
+	if err == nil || !strings.Contains(err.Error(), "maximum is 200") {
+		t.Errorf("Add() error = %v, want title too long error", err)
+	}
+}
+
+func TestAdd_ConfiguredLengthLimits(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	cfgData := []byte(`{"project_code": "TH", "max_title_length": 10, "max_description_length": 20}`)
+	if err := os.WriteFile(paths.Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	defer ticket.SetLengthLimits(0, 0)
+
+	if err := Add([]string{"--title", "Short"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	err := Add([]string{"--title", "Short", "--description", strings.Repeat("x", 21)})
+	if err == nil || !strings.Contains(err.Error(), "maximum is 20") {
+		t.Errorf("Add() error = %v, want description too long error", err)
+	}
+}
+
+func TestAdd_ConfiguredPriorityMax(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	cfgData := []byte(`{"project_code": "TH", "priority_max": 3}`)
+	if err := os.WriteFile(paths.Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	defer ticket.SetMaxPriority(0)
+
+	if err := Add([]string{"--title", "At the max", "--priority", "3"}); err != nil {
+		t.Fatalf("Add(--priority 3) error = %v", err)
+	}
+
+	err := Add([]string{"--title", "Above the max", "--priority", "4"})
+	if err == nil || !strings.Contains(err.Error(), "between 0 and 3") {
+		t.Errorf("Add(--priority 4) error = %v, want priority out of range error", err)
+	}
+
+	id := firstTicketID(t, dir)
+	if err := Update([]string{"--priority", "4", id}); err == nil {
+		t.Error("Update(--priority 4) expected error")
+	}
+}
+
+func TestAdd_DuplicateTitleWarning(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Add([]string{"--title", "Fix the login bug"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+	existingID := tickets[0].ID
+
+	stderr, err := captureStderr(t, func() error {
+		return Add([]string{"--title", "  fix the LOGIN bug"})
+	})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if !strings.Contains(stderr, existingID) {
+		t.Errorf("Warning should mention existing ticket %s, got: %q", existingID, stderr)
+	}
+
+	stderr, err = captureStderr(t, func() error {
+		return Add([]string{"--allow-duplicate", "--title", "Fix the login bug"})
+	})
+	if err != nil {
+		t.Fatalf("Add(--allow-duplicate) error = %v", err)
+	}
+	if stderr != "" {
+		t.Errorf("--allow-duplicate should suppress the warning, got: %q", stderr)
+	}
+
+	store, _ = storage.Open(paths)
+	tickets, _ = store.List(nil)
+	store.Close()
+	if len(tickets) != 3 {
+		t.Errorf("Expected 3 tickets, got %d", len(tickets))
+	}
+}
+
+func TestAdd_DuplicateTitleStrict(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Fix the login bug"})
+
+	err := Add([]string{"--strict", "--title", "Fix the login bug"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Add(--strict) error = %v, want duplicate title error", err)
+	}
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+	if len(tickets) != 1 {
+		t.Errorf("Expected 1 ticket after strict rejection, got %d", len(tickets))
+	}
+}
+
+func TestAdd_IdempotencyKey(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	var ids []string
+	for range 2 {
+		output, err := captureStdout(t, func() error {
+			return Add([]string{"--json", "--idempotency-key", "run-42", "--title", "Retried task"})
+		})
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		var resp SuccessResponse
+		if err := json.Unmarshal([]byte(output), &resp); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v\n%s", err, output)
+		}
+		ids = append(ids, resp.ID)
+	}
+	if ids[0] == "" || ids[1] != ids[0] {
+		t.Errorf("Add() IDs = %v, want the same ticket twice", ids)
+	}
+
+	if err := Add([]string{"--idempotency-key", "run-43", "--title", "Retried task"}); err != nil {
+		t.Fatalf("Add() with another key error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+	if len(tickets) != 2 {
+		t.Errorf("Expected 2 tickets, one per key, got %d", len(tickets))
+	}
+}
+
+func TestAdd_AssigneeMe(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Add([]string{"--title", "Mine", "--assignee", "me"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	defer store.Close()
+	tickets, _ := store.List(nil)
+	if tickets[0].Assignee != "Alice" {
+		t.Errorf("Assignee = %q, want Alice", tickets[0].Assignee)
+	}
+}
+
+func TestAdd_Stdin(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Parent"})
+	parentID := firstTicketID(t, dir)
+
+	setStdin(t, `{
+		"title": "From stdin",
+		"description": "Piped in",
+		"type": "bug",
+		"priority": 1,
+		"assignee": "alice",
+		"labels": ["backend", "urgent"],
+		"created_from": "`+parentID+`"
+	}`)
+	output, err := captureStdout(t, func() error {
+		return Add([]string{"--stdin", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("Add(--stdin) error = %v", err)
+	}
+	if !strings.Contains(output, `"success": true`) {
+		t.Errorf("Add(--stdin --json) output = %s, want success", output)
+	}
+
+	tk := ticketsByTitle(t, dir)["From stdin"]
+	if tk == nil {
+		t.Fatal("ticket from stdin was not created")
+	}
+	if !strings.Contains(output, tk.ID) {
+		t.Errorf("output = %s, want the new ID %s", output, tk.ID)
+	}
+	if tk.Description != "Piped in" || tk.Type != ticket.TypeBug || tk.Priority != 1 ||
+		tk.Assignee != "alice" || strings.Join(tk.Labels, ",") != "backend,urgent" {
+		t.Errorf("created ticket = %+v, want the fields from stdin", tk)
+	}
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	defer store.Close()
+	parent, err := store.GetCreatedFrom(tk.ID)
+	if err != nil || parent == nil || parent.ID != parentID {
+		t.Errorf("GetCreatedFrom() = %v, %v, want %s", parent, err, parentID)
+	}
+}
+
+func TestAdd_StdinErrors(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		input string
+	}{
+		{"unknown key", nil, `{"title": "T", "severity": "high"}`},
+		{"missing title", nil, `{"priority": 1}`},
+		{"invalid priority", nil, `{"title": "T", "priority": 99}`},
+		{"not an object", nil, `["T"]`},
+		{"two objects", nil, `{"title": "A"} {"title": "B"}`},
+		{"empty", nil, ``},
+		{"flag conflict", []string{"--title", "T"}, `{"title": "T"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setStdin(t, tt.input)
+			if err := Add(append([]string{"--stdin"}, tt.args...)); err == nil {
+				t.Errorf("Add(--stdin) with %q expected error", tt.input)
+			}
+		})
+	}
+}
+
+func TestAdd_CreatedFromComment(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	Add([]string{"--title", "Parent"})
+	parentID := firstTicketID(t, dir)
+	if err := Add([]string{"--title", "Child", "--created-from", parentID}); err != nil {
+		t.Fatalf("Add(--created-from) error = %v", err)
+	}
+	if err := Add([]string{"--title", "Quiet child", "--created-from", parentID, "--no-link-comment"}); err != nil {
+		t.Fatalf("Add(--no-link-comment) error = %v", err)
+	}
+	byTitle := ticketsByTitle(t, dir)
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	defer store.Close()
+
+	comments, err := store.GetComments(byTitle["Child"].ID)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	want := "Created from " + parentID + ": Parent"
+	if len(comments) != 1 || comments[0].Content != want || comments[0].Author != "Alice" {
+		t.Errorf("comments = %+v, want one by Alice reading %q", comments, want)
+	}
+
+	comments, err = store.GetComments(byTitle["Quiet child"].ID)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("comments = %+v, want none with --no-link-comment", comments)
+	}
+}
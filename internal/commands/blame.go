@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// BlameEntry records when something happened to a ticket and who did it.
+// By is empty when the person is not known, such as for tickets created
+// before Thicket recorded authors.
+type BlameEntry struct {
+	Time      time.Time `json:"time"`
+	By        string    `json:"by,omitempty"`
+	CommentID string    `json:"comment_id,omitempty"`
+}
+
+// BlameResponse is the JSON output of the blame command.
+type BlameResponse struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Created     BlameEntry  `json:"created"`
+	Updated     BlameEntry  `json:"updated"`
+	LastComment *BlameEntry `json:"last_comment,omitempty"`
+}
+
+// Blame shows who created a ticket, who last changed it, and who last
+// commented on it.
+func Blame(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("blame")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket blame <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nShow who created, last updated, and last commented on a ticket.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket blame <TICKET-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	comments, err := store.GetComments(ticketID)
+	if err != nil {
+		return err
+	}
+
+	blame := buildBlame(t, comments)
+	if *jsonOutput {
+		return printJSON(blame)
+	}
+
+	printBlame(os.Stdout, blame)
+	return nil
+}
+
+// buildBlame summarizes the authorship of t and its comments.
+func buildBlame(t *ticket.Ticket, comments []*ticket.Comment) BlameResponse {
+	blame := BlameResponse{
+		ID:      t.ID,
+		Title:   t.Title,
+		Created: BlameEntry{Time: t.Created, By: t.CreatedBy},
+		Updated: BlameEntry{Time: t.Updated, By: t.UpdatedBy},
+	}
+	if blame.Updated.By == "" && t.Updated.Equal(t.Created) {
+		blame.Updated.By = t.CreatedBy
+	}
+
+	for _, c := range comments {
+		if blame.LastComment == nil || !c.Created.Before(blame.LastComment.Time) {
+			blame.LastComment = &BlameEntry{Time: c.Created, By: c.Author, CommentID: c.ID}
+		}
+	}
+	return blame
+}
+
+// printBlame writes a blame summary in text form.
+func printBlame(w io.Writer, blame BlameResponse) {
+	fmt.Fprintf(w, "Ticket %s: %s\n", blame.ID, ticket.SanitizeLine(blame.Title))
+	fmt.Fprintf(w, "Created:      %s\n", formatBlameEntry(blame.Created))
+	fmt.Fprintf(w, "Last updated: %s\n", formatBlameEntry(blame.Updated))
+	if blame.LastComment == nil {
+		fmt.Fprintln(w, "Last comment: (none)")
+		return
+	}
+	fmt.Fprintf(w, "Last comment: %s (%s)\n", formatBlameEntry(*blame.LastComment), blame.LastComment.CommentID)
+}
+
+// formatBlameEntry formats the time of e, followed by who was responsible if known.
+func formatBlameEntry(e BlameEntry) string {
+	s := e.Time.Format(time.RFC3339)
+	if e.By != "" {
+		s += " by " + ticket.SanitizeLine(e.By)
+	}
+	return s
+}
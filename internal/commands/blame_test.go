@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+)
+
+func TestBlame(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	t.Setenv(config.IdentityEnv, "Alice")
+	Add([]string{"--title", "Blamed ticket"})
+	id := firstTicketID(t, dir)
+
+	t.Setenv(config.IdentityEnv, "Bob")
+	if err := Update([]string{"--priority", "1", id}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	t.Setenv(config.IdentityEnv, "Carol")
+	if err := Comment([]string{id, "Looking into it"}); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Blame([]string{id})
+	})
+	if err != nil {
+		t.Fatalf("Blame() error = %v", err)
+	}
+	for _, want := range []string{"Created:      ", "by Alice", "Last updated: ", "by Bob", "Last comment: ", "by Carol"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Blame() output missing %q:\n%s", want, output)
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Blame([]string{"--json", id})
+	})
+	if err != nil {
+		t.Fatalf("Blame(--json) error = %v", err)
+	}
+
+	var blame BlameResponse
+	if err := json.Unmarshal([]byte(output), &blame); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if blame.Created.By != "Alice" {
+		t.Errorf("created by = %q, want Alice", blame.Created.By)
+	}
+	if blame.Updated.By != "Bob" {
+		t.Errorf("updated by = %q, want Bob", blame.Updated.By)
+	}
+	if blame.LastComment == nil || blame.LastComment.By != "Carol" || blame.LastComment.CommentID == "" {
+		t.Errorf("last comment = %+v, want a comment by Carol", blame.LastComment)
+	}
+}
+
+func TestBlame_NoComments(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	t.Setenv(config.IdentityEnv, "Alice")
+	Add([]string{"--title", "Quiet ticket"})
+	id := firstTicketID(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return Blame([]string{id})
+	})
+	if err != nil {
+		t.Fatalf("Blame() error = %v", err)
+	}
+	if !strings.Contains(output, "Last comment: (none)") {
+		t.Errorf("Blame() should report no comments:\n%s", output)
+	}
+}
+
+func TestBlame_MissingID(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Blame([]string{}); err == nil {
+		t.Error("Blame() expected error for missing ticket ID")
+	}
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// canonicalizeTickets puts tickets in a canonical form for diff-friendly
+// output: sorted by ID, with sorted labels and watchers, an empty rather
+// than null label list, and UTC timestamps. Field order is already fixed by the structs.
+func canonicalizeTickets(tickets []*ticket.Ticket) {
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].ID < tickets[j].ID
+	})
+	for _, t := range tickets {
+		if t.Labels == nil {
+			t.Labels = []string{}
+		}
+		slices.Sort(t.Labels)
+		slices.Sort(t.Watchers)
+		t.Created = t.Created.UTC()
+		t.Updated = t.Updated.UTC()
+	}
+}
+
+// canonicalizeComments sorts comments by ID and converts their timestamps to UTC.
+func canonicalizeComments(comments []*ticket.Comment) {
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].ID < comments[j].ID
+	})
+	for _, c := range comments {
+		c.Created = c.Created.UTC()
+	}
+}
+
+// canonicalizeDependencies sorts dependencies by ID and converts their
+// timestamps to UTC.
+func canonicalizeDependencies(dependencies []*ticket.Dependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		return dependencies[i].ID < dependencies[j].ID
+	})
+	for _, d := range dependencies {
+		d.Created = d.Created.UTC()
+	}
+}
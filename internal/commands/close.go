@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,11 +11,20 @@ import (
 	"github.com/abarth/thicket/internal/ticket"
 )
 
+// CloseResponse is the JSON output of the close command.
+type CloseResponse struct {
+	SuccessResponse
+	CommentID string `json:"comment_id,omitempty"`
+}
+
 // Close marks a ticket as closed.
 func Close(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("close")
+	commentText := fs.String("comment", "", "Add a comment explaining why the ticket was closed")
+	reasonFlag := fs.String("reason", "", "Why the ticket was closed (done, wontfix, duplicate, obsolete)")
+	duplicateOf := fs.String("duplicate-of", "", "Ticket that this one duplicates (implies --reason duplicate)")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: thicket close <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "Usage: thicket close <TICKET-ID> [--reason <REASON>] [--duplicate-of <ID>] [--comment <TEXT>] [--json] [--data-dir <DIR>]")
 		fmt.Fprintln(os.Stderr, "\nClose a ticket.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
@@ -30,9 +40,17 @@ func Close(args []string) error {
 		return thickerr.WithHint("Ticket ID is required", "Usage: thicket close <TICKET-ID>")
 	}
 
-	ticketID := normalizeTicketID(fs.Arg(0))
-	if err := ticket.ValidateID(ticketID); err != nil {
-		return thickerr.InvalidTicketID(ticketID)
+	reason := ticket.CloseReason(*reasonFlag)
+	if *duplicateOf != "" {
+		if reason == "" {
+			reason = ticket.CloseReasonDuplicate
+		}
+		if reason != ticket.CloseReasonDuplicate {
+			return thickerr.WithHint("--duplicate-of requires --reason duplicate", "Omit --reason or set it to duplicate")
+		}
+	}
+	if err := ticket.ValidateCloseReason(reason); err != nil {
+		return thickerr.InvalidCloseReason(*reasonFlag)
 	}
 
 	root, err := config.FindRoot()
@@ -40,6 +58,12 @@ func Close(args []string) error {
 		return wrapConfigError(err)
 	}
 
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
 	paths := config.GetPaths(root)
 	store, err := storage.Open(paths)
 	if err != nil {
@@ -47,6 +71,11 @@ func Close(args []string) error {
 	}
 	defer store.Close()
 
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
 	t, err := store.Get(ticketID)
 	if err != nil {
 		return err
@@ -55,36 +84,110 @@ func Close(args []string) error {
 		return thickerr.TicketNotFound(ticketID)
 	}
 
+	var duplicateID string
+	if *duplicateOf != "" {
+		if duplicateID, err = resolveTicketID(store, *duplicateOf); err != nil {
+			return err
+		}
+		if duplicateID == t.ID {
+			return thickerr.SelfDependency()
+		}
+	}
+
+	// Build the comment before closing so that bad content doesn't leave
+	// the ticket closed without its explanation.
+	var c *ticket.Comment
+	if *commentText != "" {
+		c, err = ticket.NewComment(t.ID, *commentText)
+		if errors.Is(err, ticket.ErrEmptyComment) {
+			return thickerr.EmptyComment()
+		}
+		if err != nil {
+			return wrapTicketError(err)
+		}
+		c.Author = config.ResolveIdentity()
+	}
+
 	if t.Status == ticket.StatusClosed {
+		if reason != "" {
+			return thickerr.WithHint(
+				fmt.Sprintf("Ticket %s is already closed", t.ID),
+				"To change why it was closed, reopen it with 'thicket reopen' and close it again",
+			)
+		}
+		if c != nil {
+			if err := store.AddComment(c); err != nil {
+				return err
+			}
+		}
 		if *jsonOutput {
-			return printJSON(SuccessResponse{
-				Success: true,
-				ID:      t.ID,
-				Message: fmt.Sprintf("Ticket %s is already closed", t.ID),
+			return printJSON(CloseResponse{
+				SuccessResponse: SuccessResponse{
+					Success: true,
+					ID:      t.ID,
+					Message: fmt.Sprintf("Ticket %s is already closed", t.ID),
+				},
+				CommentID: commentID(c),
 			})
 		}
 		fmt.Printf("Ticket %s is already closed\n", t.ID)
+		if c != nil {
+			fmt.Printf("Added comment %s\n", c.ID)
+		}
 		return nil
 	}
 
-	t.Close()
+	if err := t.CloseWithReason(reason); err != nil {
+		return err
+	}
+	t.UpdatedBy = config.ResolveIdentity()
 
 	if err := store.Update(t); err != nil {
 		return err
 	}
+	if duplicateID != "" {
+		dep, err := ticket.NewDependency(t.ID, duplicateID, ticket.DependencyRelatedTo)
+		if err != nil {
+			return err
+		}
+		if err := store.AddDependency(dep); err != nil && !errors.Is(err, ticket.ErrDuplicateDependency) {
+			return err
+		}
+	}
+	if c != nil {
+		if err := store.AddComment(c); err != nil {
+			return err
+		}
+	}
+
+	runHook(root, cfg, config.HookOnClose, t)
 
 	hint := "Before moving on, think about what additional work should be done and then file tickets for that work: thicket add --title \"...\" --created-from " + t.ID
 
 	if *jsonOutput {
-		return printJSON(SuccessResponse{
-			Success: true,
-			ID:      t.ID,
-			Message: fmt.Sprintf("Closed ticket %s", t.ID),
-			Hint:    hint,
+		return printJSON(CloseResponse{
+			SuccessResponse: SuccessResponse{
+				Success: true,
+				ID:      t.ID,
+				Message: fmt.Sprintf("Closed ticket %s", t.ID),
+				Hint:    hint,
+			},
+			CommentID: commentID(c),
 		})
 	}
 
 	fmt.Printf("Closed ticket %s\n", t.ID)
+	if c != nil {
+		fmt.Printf("Added comment %s\n", c.ID)
+	}
 	fmt.Printf("\nHint: %s\n", hint)
 	return nil
 }
+
+// commentID returns the ID of c, or an empty string if c is nil.
+func commentID(c *ticket.Comment) string {
+	if c == nil {
+		return ""
+	}
+	return c.ID
+}
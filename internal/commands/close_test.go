@@ -2,7 +2,9 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -124,3 +126,243 @@ func TestClose_JSONOutputIncludesFollowOnHint(t *testing.T) {
 		t.Errorf("Close JSON output hint should include ticket ID %s for --created-from", ticketID)
 	}
 }
+
+func writeHooksConfig(t *testing.T, dir, hooks string) {
+	t.Helper()
+	paths := config.GetPaths(dir)
+	cfgData := []byte(`{"project_code": "TH", "hooks": ` + hooks + `}`)
+	if err := os.WriteFile(paths.Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestClose_Hook(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	writeHooksConfig(t, dir, `{"on_close": "echo \"$THICKET_EVENT\" > \"$THICKET_TICKET_ID.closed\""}`)
+
+	Add([]string{"--title", "Hooked"})
+	ticketID := firstTicketID(t, dir)
+
+	if _, err := captureStdout(t, func() error { return Close([]string{ticketID}) }); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ticketID+".closed"))
+	if err != nil {
+		t.Fatalf("on_close hook did not write its sentinel file: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != config.HookOnClose {
+		t.Errorf("THICKET_EVENT = %q, want %q", strings.TrimSpace(string(data)), config.HookOnClose)
+	}
+}
+
+func TestClose_NoHooks(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	defer func() { hooksDisabled = false }()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	writeHooksConfig(t, dir, `{"on_close": "touch \"$THICKET_TICKET_ID.closed\""}`)
+
+	Add([]string{"--title", "Hooked"})
+	ticketID := firstTicketID(t, dir)
+
+	if _, err := captureStdout(t, func() error { return Close([]string{"--no-hooks", ticketID}) }); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ticketID+".closed")); !os.IsNotExist(err) {
+		t.Errorf("on_close hook ran despite --no-hooks (stat error = %v)", err)
+	}
+}
+
+func TestClose_FailingHook(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	writeHooksConfig(t, dir, `{"on_close": "exit 3"}`)
+
+	Add([]string{"--title", "Hooked"})
+	ticketID := firstTicketID(t, dir)
+
+	stderr, err := captureStderr(t, func() error {
+		_, err := captureStdout(t, func() error { return Close([]string{ticketID}) })
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Close() error = %v, want hook failure to be ignored", err)
+	}
+	if !strings.Contains(stderr, "Warning: on_close hook failed") {
+		t.Errorf("stderr = %q, want hook failure warning", stderr)
+	}
+}
+
+func TestClose_Comment(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+	ticketID := firstTicketID(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return Close([]string{"--json", "--comment", "Fixed in the login refactor", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	var resp CloseResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if !resp.Success || resp.ID != ticketID || resp.CommentID == "" {
+		t.Errorf("Close() response = %+v, want success with ticket and comment IDs", resp)
+	}
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	tk, _ := store.Get(ticketID)
+	if tk.Status != ticket.StatusClosed {
+		t.Errorf("Status = %q, want closed", tk.Status)
+	}
+	comments, err := store.GetComments(ticketID)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %d", len(comments))
+	}
+	c := comments[0]
+	if c.ID != resp.CommentID || c.Content != "Fixed in the login refactor" || c.Author != "Alice" {
+		t.Errorf("comment = %+v, want the closing comment by Alice", c)
+	}
+}
+
+func TestClose_EmptyComment(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+	ticketID := firstTicketID(t, dir)
+
+	if err := Close([]string{"--comment", "   ", ticketID}); err == nil {
+		t.Fatal("Close() with a blank comment should fail")
+	}
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	defer store.Close()
+	tk, _ := store.Get(ticketID)
+	if tk.Status != ticket.StatusOpen {
+		t.Errorf("Status = %q, want open after a rejected comment", tk.Status)
+	}
+}
+
+func TestClose_Reason(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+	ticketID := firstTicketID(t, dir)
+
+	if err := Close([]string{"--reason", "fixed", ticketID}); err == nil || !strings.Contains(err.Error(), "Invalid close reason") {
+		t.Errorf("Close(--reason fixed) error = %v, want invalid reason error", err)
+	}
+
+	if _, err := captureStdout(t, func() error { return Close([]string{"--reason", "wontfix", ticketID}) }); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Reopen the store from scratch so the reason comes from tickets.jsonl.
+	paths := config.GetPaths(dir)
+	if err := os.Remove(paths.Cache); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	store, err := storage.Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk, _ := store.Get(ticketID)
+	store.Close()
+	if tk.Status != ticket.StatusClosed || tk.CloseReason != ticket.CloseReasonWontfix {
+		t.Errorf("ticket = status %q, reason %q; want closed, wontfix", tk.Status, tk.CloseReason)
+	}
+
+	output, err := captureStdout(t, func() error { return Show([]string{ticketID}) })
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, "Reason:      wontfix") {
+		t.Errorf("Show() output should include the close reason, got:\n%s", output)
+	}
+}
+
+func TestClose_DuplicateOf(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Original", "--priority", "1"})
+	originalID := firstTicketID(t, dir)
+	Add([]string{"--title", "Copy", "--priority", "0"})
+	copyID := firstTicketID(t, dir)
+
+	if err := Close([]string{"--reason", "obsolete", "--duplicate-of", originalID, copyID}); err == nil {
+		t.Error("Close() should reject --duplicate-of with a reason other than duplicate")
+	}
+	if err := Close([]string{"--duplicate-of", copyID, copyID}); err == nil {
+		t.Error("Close() should reject a ticket that duplicates itself")
+	}
+
+	if _, err := captureStdout(t, func() error { return Close([]string{"--duplicate-of", originalID, copyID}) }); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	tk, _ := store.Get(copyID)
+	if tk.Status != ticket.StatusClosed || tk.CloseReason != ticket.CloseReasonDuplicate {
+		t.Errorf("ticket = status %q, reason %q; want closed, duplicate", tk.Status, tk.CloseReason)
+	}
+
+	deps, err := store.GetDependenciesFrom(copyID)
+	if err != nil {
+		t.Fatalf("GetDependenciesFrom() error = %v", err)
+	}
+	if len(deps) != 1 || deps[0].Type != ticket.DependencyRelatedTo || deps[0].ToTicketID != originalID {
+		t.Errorf("dependencies = %+v, want one related_to %s", deps, originalID)
+	}
+}
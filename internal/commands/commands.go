@@ -2,27 +2,180 @@
 package commands
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/term"
 
 	"github.com/abarth/thicket/internal/config"
 	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
 // TicketDetails holds all information about a ticket for display.
 type TicketDetails struct {
-	Ticket      *ticket.Ticket    `json:"ticket"`
-	Comments    []*ticket.Comment `json:"comments"`
-	BlockedBy   []*ticket.Ticket  `json:"blocked_by"`
-	Blocking    []*ticket.Ticket  `json:"blocking"`
-	CreatedFrom *ticket.Ticket    `json:"created_from"`
+	Ticket          *ticket.Ticket    `json:"ticket"`
+	Comments        []*ticket.Comment `json:"comments"`
+	BlockedBy       []*ticket.Ticket  `json:"blocked_by"`
+	Blocking        []*ticket.Ticket  `json:"blocking"`
+	CreatedFrom     *ticket.Ticket    `json:"created_from"`
+	CreatedChildren []*ticket.Ticket  `json:"created_children"`       // Tickets created from this one
+	Dependencies    []*DependencyLink `json:"dependencies"`           // Every dependency, in either direction
+	IsBlocked       bool              `json:"is_blocked"`             // Blocked by an open ticket
+	BlockerTree     []*BlockerNode    `json:"blocker_tree,omitempty"` // Transitive blockers, with show --depth
+	Omitted         []string          `json:"-"`                      // JSON keys of collections not loaded, with show --expand
+}
+
+// BlockerNode is a ticket that blocks another, along with its own blockers.
+type BlockerNode struct {
+	Ticket    *ticket.Ticket `json:"ticket"`
+	BlockedBy []*BlockerNode `json:"blocked_by,omitempty"`
+}
+
+// Directions of a DependencyLink.
+const (
+	DependencyOutgoing = "outgoing" // The ticket has the dependency on the linked ticket
+	DependencyIncoming = "incoming" // The linked ticket has the dependency on the ticket
+)
+
+// DependencyLink is one of a ticket's dependencies, along with the ticket at
+// its other end.
+type DependencyLink struct {
+	Type      ticket.DependencyType `json:"type"`
+	Direction string                `json:"direction"` // DependencyOutgoing or DependencyIncoming
+	Ticket    *ticket.Ticket        `json:"ticket"`
+}
+
+// loadTicketDetails gathers the related tickets and dependencies of t.
+func loadTicketDetails(store *storage.Store, t *ticket.Ticket, comments []*ticket.Comment) (*TicketDetails, error) {
+	details, err := loadExpandedDetails(store, t, nil)
+	if err != nil {
+		return nil, err
+	}
+	details.Comments = comments
+	return details, nil
+}
+
+// loadExpandedDetails gathers the related collections of t named in expand
+// (see detailExpansions), or all of them if expand is nil. The others are
+// left empty and listed in Omitted. Comments are left for the caller to load,
+// since it usually has them already.
+func loadExpandedDetails(store *storage.Store, t *ticket.Ticket, expand map[string]bool) (*TicketDetails, error) {
+	details := &TicketDetails{Ticket: t}
+	var err error
+	for _, e := range detailExpansions {
+		if expand != nil && !expand[e.Name] {
+			details.Omitted = append(details.Omitted, e.Key)
+			continue
+		}
+		switch e.Name {
+		case "blockers":
+			details.BlockedBy, err = store.GetBlockers(t.ID)
+		case "blocking":
+			details.Blocking, err = store.GetBlocking(t.ID)
+		case "created_from":
+			details.CreatedFrom, err = store.GetCreatedFrom(t.ID)
+		case "children":
+			details.CreatedChildren, err = store.GetCreatedChildren(t.ID)
+		case "dependencies":
+			details.Dependencies, err = loadDependencyLinks(store, t.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if details.IsBlocked, err = store.IsBlocked(t.ID); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// detailExpansions lists the related collections of a ticket that
+// show --expand can select, with their keys in the JSON output.
+var detailExpansions = []struct{ Name, Key string }{
+	{"comments", "comments"},
+	{"blockers", "blocked_by"},
+	{"blocking", "blocking"},
+	{"created_from", "created_from"},
+	{"children", "created_children"},
+	{"dependencies", "dependencies"},
+}
+
+// loadBlockerTree returns the blockers of the given ticket, each with its own
+// blockers, down to depth levels. A ticket already expanded elsewhere in the
+// tree is listed again without its blockers, which guards against cycles.
+func loadBlockerTree(store *storage.Store, ticketID string, depth int) ([]*BlockerNode, error) {
+	seen := map[string]bool{ticketID: true}
+	var expand func(id string, level int) ([]*BlockerNode, error)
+	expand = func(id string, level int) ([]*BlockerNode, error) {
+		blockers, err := store.GetBlockers(id)
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]*BlockerNode, len(blockers))
+		for i, b := range blockers {
+			nodes[i] = &BlockerNode{Ticket: b}
+			if level < depth && !seen[b.ID] {
+				seen[b.ID] = true
+				if nodes[i].BlockedBy, err = expand(b.ID, level+1); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nodes, nil
+	}
+	return expand(ticketID, 1)
+}
+
+// loadDependencyLinks returns the dependencies of the given ticket in both
+// directions, outgoing first. Dependencies on missing tickets are skipped.
+func loadDependencyLinks(store *storage.Store, ticketID string) ([]*DependencyLink, error) {
+	from, err := store.GetDependenciesFrom(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := store.GetDependenciesTo(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []*DependencyLink
+	add := func(d *ticket.Dependency, direction, otherID string) error {
+		other, err := store.Get(otherID)
+		if err != nil {
+			return err
+		}
+		if other != nil {
+			links = append(links, &DependencyLink{Type: d.Type, Direction: direction, Ticket: other})
+		}
+		return nil
+	}
+	for _, d := range from {
+		if err := add(d, DependencyOutgoing, d.ToTicketID); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range to {
+		if err := add(d, DependencyIncoming, d.FromTicketID); err != nil {
+			return nil, err
+		}
+	}
+	return links, nil
 }
 
 // SuccessResponse is a common JSON response for mutating commands.
@@ -33,25 +186,248 @@ type SuccessResponse struct {
 	Hint    string `json:"hint,omitempty"`
 }
 
+// ErrorResponse is the JSON response for a command that failed in a way
+// callers are expected to handle, such as a conflicting change.
+type ErrorResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// printJSONError prints err as an ErrorResponse and returns it marked as
+// shown, so it keeps its exit code but isn't printed again on stderr.
+func printJSONError(err *thickerr.UserError) error {
+	if printErr := printJSON(ErrorResponse{Error: err.Message, Hint: err.Hint}); printErr != nil {
+		return printErr
+	}
+	err.Shown = true
+	return err
+}
+
 func printJSON(v interface{}) error {
-	enc := json.NewEncoder(os.Stdout)
+	return writeJSON(os.Stdout, v)
+}
+
+// printGzipJSON prints v as printJSON does, but gzip-compressed.
+func printGzipJSON(v interface{}) error {
+	return printGzip(func(w io.Writer) error { return writeJSON(w, v) })
+}
+
+// printGzip calls write with a writer that gzips what it is given to stdout.
+func printGzip(write func(io.Writer) error) error {
+	zw := gzip.NewWriter(os.Stdout)
+	if err := write(zw); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeTicketLines writes tickets to w as newline-delimited JSON: each ticket
+// as compact JSON on a line of its own.
+func writeTicketLines(w io.Writer, tickets []*TicketJSON) error {
+	enc := json.NewEncoder(w)
+	for _, t := range tickets {
+		if err := enc.Encode(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(v)
 }
 
+// TicketJSON is the --json representation of a ticket. It adds fields that
+// are computed for output and never stored.
+type TicketJSON struct {
+	*ticket.Ticket
+	Severity string `json:"severity"`
+
+	// fields, if set, limits the output to the named fields.
+	fields []string
+}
+
+// newTicketJSON wraps a ticket for JSON output. It returns nil for a nil ticket.
+func newTicketJSON(t *ticket.Ticket, cfg *config.Config) *TicketJSON {
+	if t == nil {
+		return nil
+	}
+	return &TicketJSON{Ticket: t, Severity: cfg.Severity(t.Priority)}
+}
+
+// newTicketsJSON wraps tickets for JSON output, preserving a nil slice.
+func newTicketsJSON(tickets []*ticket.Ticket, cfg *config.Config) []*TicketJSON {
+	if tickets == nil {
+		return nil
+	}
+	out := make([]*TicketJSON, len(tickets))
+	for i, t := range tickets {
+		out[i] = newTicketJSON(t, cfg)
+	}
+	return out
+}
+
+// ticketDetailsJSON is the --json representation of TicketDetails.
+type ticketDetailsJSON struct {
+	Ticket          *TicketJSON        `json:"ticket"`
+	Comments        []*ticket.Comment  `json:"comments"`
+	BlockedBy       []*TicketJSON      `json:"blocked_by"`
+	Blocking        []*TicketJSON      `json:"blocking"`
+	CreatedFrom     *TicketJSON        `json:"created_from"`
+	CreatedChildren []*TicketJSON      `json:"created_children"`
+	Dependencies    []*dependencyJSON  `json:"dependencies"`
+	IsBlocked       bool               `json:"is_blocked"`
+	IsReady         bool               `json:"is_ready"`
+	BlockerTree     []*blockerNodeJSON `json:"blocker_tree,omitempty"`
+
+	omit []string // Keys to leave out of the output
+}
+
+// MarshalJSON encodes the details, leaving out the keys in omit.
+func (d *ticketDetailsJSON) MarshalJSON() ([]byte, error) {
+	type plain ticketDetailsJSON
+	data, err := json.Marshal((*plain)(d))
+	if err != nil || d.omit == nil {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	// Build the object by hand to keep the keys in struct order.
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for _, key := range jsonFieldNames(reflect.TypeOf(plain{})) {
+		if _, ok := all[key]; !ok || slices.Contains(d.omit, key) {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(all[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// blockerNodeJSON is the --json representation of a BlockerNode.
+type blockerNodeJSON struct {
+	Ticket    *TicketJSON        `json:"ticket"`
+	BlockedBy []*blockerNodeJSON `json:"blocked_by,omitempty"`
+}
+
+// newBlockerTreeJSON converts a blocker tree to its JSON representation,
+// limiting each ticket to fields if it is not nil.
+func newBlockerTreeJSON(nodes []*BlockerNode, cfg *config.Config, fields []string) []*blockerNodeJSON {
+	if nodes == nil {
+		return nil
+	}
+	out := make([]*blockerNodeJSON, len(nodes))
+	for i, n := range nodes {
+		t := newTicketJSON(n.Ticket, cfg)
+		selectFields([]*TicketJSON{t}, fields)
+		out[i] = &blockerNodeJSON{Ticket: t, BlockedBy: newBlockerTreeJSON(n.BlockedBy, cfg, fields)}
+	}
+	return out
+}
+
+// dependencyJSON is the --json representation of a DependencyLink.
+type dependencyJSON struct {
+	Type      ticket.DependencyType `json:"type"`
+	Direction string                `json:"direction"`
+	Ticket    *TicketJSON           `json:"ticket"`
+}
+
+// printDetailsJSON prints ticket details in JSON format. If fields is not
+// nil, each ticket in the output is limited to those fields.
+func printDetailsJSON(details *TicketDetails, cfg *config.Config, fields []string) error {
+	return printJSON(newTicketDetailsJSON(details, cfg, fields))
+}
+
+// newTicketDetailsJSON converts ticket details to their JSON representation.
+// If fields is not nil, each ticket is limited to those fields.
+func newTicketDetailsJSON(details *TicketDetails, cfg *config.Config, fields []string) *ticketDetailsJSON {
+	out := &ticketDetailsJSON{
+		Ticket:          newTicketJSON(details.Ticket, cfg),
+		Comments:        details.Comments,
+		BlockedBy:       newTicketsJSON(details.BlockedBy, cfg),
+		Blocking:        newTicketsJSON(details.Blocking, cfg),
+		CreatedFrom:     newTicketJSON(details.CreatedFrom, cfg),
+		CreatedChildren: newTicketsJSON(details.CreatedChildren, cfg),
+		IsBlocked:       details.IsBlocked,
+		IsReady:         details.Ticket.Status == ticket.StatusOpen && !details.IsBlocked,
+		BlockerTree:     newBlockerTreeJSON(details.BlockerTree, cfg, fields),
+		omit:            details.Omitted,
+	}
+	linked := make([]*TicketJSON, len(details.Dependencies))
+	for i, d := range details.Dependencies {
+		linked[i] = newTicketJSON(d.Ticket, cfg)
+		out.Dependencies = append(out.Dependencies, &dependencyJSON{Type: d.Type, Direction: d.Direction, Ticket: linked[i]})
+	}
+	selectFields([]*TicketJSON{out.Ticket, out.CreatedFrom}, fields)
+	selectFields(out.BlockedBy, fields)
+	selectFields(out.Blocking, fields)
+	selectFields(out.CreatedChildren, fields)
+	selectFields(linked, fields)
+	return out
+}
+
 // newFlagSet creates a new FlagSet with global flags already defined.
 func newFlagSet(name string) (*flag.FlagSet, *bool, *string) {
 	fs := flag.NewFlagSet(name, flag.ExitOnError)
 	jsonOutput := fs.Bool("json", false, "Output in JSON format")
 	dataDir := fs.String("data-dir", "", "Custom .thicket directory location")
+	fs.BoolVar(&verbose, "verbose", false, "Print timing and cache rebuild details to stderr")
+	fs.StringVar(&projectRoot, "project-root", "", "Use the Thicket project in this directory instead of searching from the current directory")
+	fs.BoolVar(&noWalk, "no-walk", false, "Only look for .thicket in the current directory, not its parents")
+	fs.BoolVar(&noHooks, "no-hooks", false, "Don't run hooks from config.json")
+	fs.BoolVar(&memoryCache, "memory-cache", false, "Keep the cache in memory instead of in cache.db")
 	return fs, jsonOutput, dataDir
 }
 
+// Global flags shared by every command, set by the most recently parsed flag set.
+var (
+	verbose     bool
+	projectRoot string
+	noWalk      bool
+	noHooks     bool
+	memoryCache bool
+)
+
 // handleGlobalFlags sets global configuration based on flags.
 func handleGlobalFlags(dataDir string) {
 	if dataDir != "" {
 		config.SetDataDir(dataDir)
 	}
+	if verbose {
+		storage.SetVerbose(os.Stderr)
+	}
+	if projectRoot != "" {
+		config.SetProjectRoot(projectRoot)
+	}
+	if noWalk {
+		config.SetNoWalk(true)
+	}
+	if noHooks {
+		DisableHooks()
+	}
+	if memoryCache {
+		storage.SetMemoryCache(true)
+	}
 }
 
 // ErrTicketNotFound is returned when a ticket cannot be found.
@@ -67,46 +443,171 @@ func normalizeTicketID(id string) string {
 	return strings.ToUpper(id[:2]) + "-" + strings.ToLower(id[3:])
 }
 
+// resolveTicketID expands a full or partial ticket ID (e.g., "abc" or
+// "TH-abc") into the full ID of the single ticket it matches.
+func resolveTicketID(store *storage.Store, id string) (string, error) {
+	resolved, err := store.ResolveID(normalizeTicketID(id))
+	if err == nil {
+		return resolved, nil
+	}
+
+	var ambiguous *storage.AmbiguousIDError
+	switch {
+	case errors.Is(err, ticket.ErrInvalidID):
+		return "", thickerr.InvalidTicketID(id)
+	case errors.Is(err, storage.ErrNoMatchingTicket):
+		return "", thickerr.TicketNotFound(id)
+	case errors.As(err, &ambiguous):
+		return "", thickerr.AmbiguousTicketID(id, ambiguous.Candidates)
+	}
+	return "", err
+}
+
+// resolveAssignee expands "me" and "@me" to the current user's identity.
+// Other names are returned unchanged.
+func resolveAssignee(name string) (string, error) {
+	if name != "me" && name != "@me" {
+		return name, nil
+	}
+	identity := config.ResolveIdentity()
+	if identity == "" {
+		return "", thickerr.WithHint(
+			fmt.Sprintf("Cannot tell who %q is", name),
+			"Set the THICKET_USER environment variable or git's user.name",
+		)
+	}
+	return identity, nil
+}
+
 // wrapConfigError converts config errors to user-friendly errors.
 func wrapConfigError(err error) error {
 	if err == config.ErrNotInitialized {
 		return thickerr.NotInitialized()
 	}
 	if err == config.ErrAlreadyInit {
-		return thickerr.New("Thicket is already initialized in this directory")
+		return thickerr.WithHint("Thicket is already initialized in this directory", "Use --force to repair its config while keeping its tickets")
+	}
+	return err
+}
+
+// applyConfig applies project-wide settings from the config to the ticket model.
+func applyConfig(cfg *config.Config) {
+	ticket.SetLengthLimits(cfg.MaxTitleLength, cfg.MaxDescriptionLength)
+	ticket.SetMaxCommentLength(cfg.MaxCommentLength)
+	ticket.SetMaxPriority(cfg.PriorityMax)
+}
+
+// wrapTicketError converts ticket validation errors to user-friendly errors.
+func wrapTicketError(err error) error {
+	switch err {
+	case ticket.ErrEmptyTitle:
+		return thickerr.EmptyTitle()
+	case ticket.ErrTitleTooLong:
+		return thickerr.TitleTooLong(ticket.MaxTitleLength())
+	case ticket.ErrDescriptionTooLong:
+		return thickerr.DescriptionTooLong(ticket.MaxDescriptionLength())
+	case ticket.ErrCommentTooLong:
+		return thickerr.CommentTooLong(ticket.MaxCommentLength())
+	case ticket.ErrInvalidPriority:
+		return thickerr.InvalidPriority(ticket.MaxPriority())
+	case ticket.ErrInvalidEstimate:
+		return thickerr.InvalidEstimate()
 	}
 	return err
 }
 
-func printTicketTable(w io.Writer, tickets []*ticket.Ticket) {
+// commentAuthorPrefix returns "Author: " for comments with a known author.
+func commentAuthorPrefix(c *ticket.Comment) string {
+	if c.Author == "" {
+		return ""
+	}
+	return ticket.SanitizeLine(c.Author) + ": "
+}
+
+// displayOptions controls how tickets are rendered in human-readable output.
+type displayOptions struct {
+	Config         *config.Config // Project configuration (may be nil)
+	PriorityLabels bool           // Show the configured label next to each priority
+	NoHeader       bool           // Omit the header and separator rows from tables
+	NoTruncate     bool           // Show full titles in tables
+	Width          int            // Wrap descriptions and comments to this many columns (0 for no wrapping)
+	TimeFormat     string         // Format for timestamps (see ticket.FormatTime); empty for each field's default
+}
+
+// formatPriority renders a priority, including its label when requested.
+func formatPriority(priority int, opts displayOptions) string {
+	if opts.PriorityLabels {
+		if label := opts.Config.PriorityLabel(priority); label != "" {
+			return fmt.Sprintf("%d (%s)", priority, label)
+		}
+	}
+	return fmt.Sprintf("%d", priority)
+}
+
+func printTicketTable(w io.Writer, tickets []*ticket.Ticket, opts displayOptions) {
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(tw, "ID\tPRI\tTYPE\tSTATUS\tASSIGNEE\tTITLE")
-	fmt.Fprintln(tw, "--\t---\t----\t------\t--------\t-----")
+	if !opts.NoHeader {
+		fmt.Fprintln(tw, "ID\tPRI\tEST\tTYPE\tSTATUS\tASSIGNEE\tLABELS\tTITLE")
+		fmt.Fprintln(tw, "--\t---\t---\t----\t------\t--------\t------\t-----")
+	}
 	for _, t := range tickets {
-		title := t.Title
-		if len(title) > 50 {
+		title := ticket.SanitizeLine(t.Title)
+		if len(title) > 50 && !opts.NoTruncate {
 			title = title[:47] + "..."
 		}
-		assignee := t.Assignee
+		assignee := ticket.SanitizeLine(t.Assignee)
 		if assignee == "" {
 			assignee = "-"
 		}
 		if len(assignee) > 12 {
 			assignee = assignee[:9] + "..."
 		}
+		labels := ticket.SanitizeLine(strings.Join(t.Labels, ","))
+		if labels == "" {
+			labels = "-"
+		}
+		if len(labels) > 20 {
+			labels = labels[:17] + "..."
+		}
 		issueType := string(t.Type)
 		if issueType == "" {
 			issueType = "-"
 		}
-		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", t.ID, t.Priority, issueType, t.Status, assignee, title)
+		estimate := "-"
+		if t.Estimate > 0 {
+			estimate = strconv.Itoa(t.Estimate)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", t.ID, formatPriority(t.Priority, opts), estimate, issueType, t.Status, assignee, labels, title)
 	}
 	tw.Flush()
 }
 
-func printTicketDetail(w io.Writer, details *TicketDetails) {
+// printTicketTSV prints one tab-separated row per ticket, with no header,
+// padding, or truncation, for scripts to split on tabs.
+func printTicketTSV(w io.Writer, tickets []*ticket.Ticket) {
+	for _, t := range tickets {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", t.ID, t.Priority, t.Status, ticket.SanitizeLine(t.Title))
+	}
+}
+
+// printTicketOneline prints the ID and title of each ticket, one per line.
+func printTicketOneline(w io.Writer, tickets []*ticket.Ticket) {
+	for _, t := range tickets {
+		fmt.Fprintf(w, "%s %s\n", t.ID, ticket.SanitizeLine(t.Title))
+	}
+}
+
+// printTicketIDs prints the ID of each ticket, one per line.
+func printTicketIDs(w io.Writer, tickets []*ticket.Ticket) {
+	for _, t := range tickets {
+		fmt.Fprintln(w, t.ID)
+	}
+}
+
+func printTicketDetail(w io.Writer, details *TicketDetails, opts displayOptions) {
 	t := details.Ticket
 	fmt.Fprintf(w, "ID:          %s\n", t.ID)
-	fmt.Fprintf(w, "Title:       %s\n", t.Title)
+	fmt.Fprintf(w, "Title:       %s\n", ticket.SanitizeLine(t.Title))
 
 	issueType := string(t.Type)
 	if issueType == "" {
@@ -114,52 +615,211 @@ func printTicketDetail(w io.Writer, details *TicketDetails) {
 	}
 	fmt.Fprintf(w, "Type:        %s\n", issueType)
 	fmt.Fprintf(w, "Status:      %s\n", t.Status)
-	fmt.Fprintf(w, "Priority:    %d\n", t.Priority)
+	if t.Status == ticket.StatusClosed && t.CloseReason != "" {
+		fmt.Fprintf(w, "Reason:      %s\n", t.CloseReason)
+	}
+	fmt.Fprintf(w, "Priority:    %s\n", formatPriority(t.Priority, opts))
 
-	assignee := t.Assignee
+	assignee := ticket.SanitizeLine(t.Assignee)
 	if assignee == "" {
 		assignee = "(unassigned)"
 	}
 	fmt.Fprintf(w, "Assignee:    %s\n", assignee)
+	if t.Estimate > 0 {
+		fmt.Fprintf(w, "Estimate:    %d\n", t.Estimate)
+	}
 
 	labels := strings.Join(t.Labels, ", ")
 	if labels == "" {
 		labels = "(none)"
 	}
 	fmt.Fprintf(w, "Labels:      %s\n", labels)
+	if len(t.Watchers) > 0 {
+		fmt.Fprintf(w, "Watchers:    %s\n", ticket.SanitizeLine(strings.Join(t.Watchers, ", ")))
+	}
 
-	fmt.Fprintf(w, "Created:     %s\n", t.Created.Format(time.RFC3339))
-	fmt.Fprintf(w, "Updated:     %s\n", t.Updated.Format(time.RFC3339))
+	fmt.Fprintf(w, "Created:     %s\n", ticket.FormatTime(t.Created, opts.TimeFormat, time.RFC3339))
+	fmt.Fprintf(w, "Updated:     %s\n", ticket.FormatTime(t.Updated, opts.TimeFormat, time.RFC3339))
 
 	if details.CreatedFrom != nil {
-		fmt.Fprintf(w, "Created from: %s (%s)\n", details.CreatedFrom.ID, details.CreatedFrom.Title)
+		fmt.Fprintf(w, "Created from: %s (%s)\n", details.CreatedFrom.ID, ticket.SanitizeLine(details.CreatedFrom.Title))
 	}
 
-	if len(details.BlockedBy) > 0 {
+	if details.BlockerTree != nil {
+		if len(details.BlockerTree) > 0 {
+			fmt.Fprintf(w, "\nBlocked by:\n")
+			printBlockerTree(w, details.BlockerTree, "  ")
+		}
+	} else if len(details.BlockedBy) > 0 {
 		fmt.Fprintf(w, "\nBlocked by:\n")
 		for _, b := range details.BlockedBy {
-			status := ""
-			if b.Status == ticket.StatusClosed {
-				status = " [closed]"
-			}
-			fmt.Fprintf(w, "  - %s: %s%s\n", b.ID, b.Title, status)
+			printBlocker(w, b, "  ")
 		}
 	}
 
 	if len(details.Blocking) > 0 {
 		fmt.Fprintf(w, "\nBlocking:\n")
 		for _, b := range details.Blocking {
-			fmt.Fprintf(w, "  - %s: %s\n", b.ID, b.Title)
+			fmt.Fprintf(w, "  - %s: %s\n", b.ID, ticket.SanitizeLine(b.Title))
+		}
+	}
+
+	if len(details.CreatedChildren) > 0 {
+		fmt.Fprintf(w, "\nCreated from this ticket:\n")
+		for _, c := range details.CreatedChildren {
+			fmt.Fprintf(w, "  - %s: %s [%s]\n", c.ID, ticket.SanitizeLine(c.Title), c.Status)
 		}
 	}
 
+	printOtherDependencies(w, details.Dependencies)
+
 	if t.Description != "" {
-		fmt.Fprintf(w, "\nDescription:\n%s\n", t.Description)
+		fmt.Fprintf(w, "\nDescription:\n%s\n", wrapText(ticket.SanitizeText(t.Description), opts.Width))
 	}
 	if len(details.Comments) > 0 {
 		fmt.Fprintf(w, "\nComments:\n")
 		for _, c := range details.Comments {
-			fmt.Fprintf(w, "  [%s] %s\n", c.Created.Format("2006-01-02 15:04:05"), c.Content)
+			line := fmt.Sprintf("  [%s] %s%s", ticket.FormatTime(c.Created, opts.TimeFormat, time.DateTime), commentAuthorPrefix(c), ticket.SanitizeText(c.Content))
+			fmt.Fprintln(w, wrapText(line, opts.Width))
+			if c.IsAcked() {
+				fmt.Fprintf(w, "    (%s)\n", commentAckNote(c, opts.TimeFormat, time.DateTime))
+			}
+		}
+	}
+}
+
+// printBlocker prints one line of the "Blocked by" section.
+func printBlocker(w io.Writer, b *ticket.Ticket, indent string) {
+	status := ""
+	if b.Status == ticket.StatusClosed {
+		status = " [closed]"
+	}
+	fmt.Fprintf(w, "%s- %s: %s%s\n", indent, b.ID, ticket.SanitizeLine(b.Title), status)
+}
+
+// printBlockerTree prints blockers with each one's own blockers indented
+// beneath it.
+func printBlockerTree(w io.Writer, nodes []*BlockerNode, indent string) {
+	for _, n := range nodes {
+		printBlocker(w, n.Ticket, indent)
+		printBlockerTree(w, n.BlockedBy, indent+"  ")
+	}
+}
+
+// dependencyHeaders names the sections of the detail view for dependency
+// types that have no section of their own above it.
+var dependencyHeaders = map[ticket.DependencyType]string{
+	ticket.DependencyRelatedTo: "Related to",
+}
+
+// dependencyGroup is a section of the detail view listing dependencies of one type.
+type dependencyGroup struct {
+	Header string
+	Links  []*DependencyLink
+}
+
+// otherDependencyGroups groups the dependencies of types other than
+// blocked_by and created_from, which have sections of their own. Groups
+// follow the order in which their types first appear.
+func otherDependencyGroups(links []*DependencyLink) []dependencyGroup {
+	var groups []dependencyGroup
+	index := make(map[ticket.DependencyType]int)
+	for _, l := range links {
+		if l.Type == ticket.DependencyBlockedBy || l.Type == ticket.DependencyCreatedFrom {
+			continue
+		}
+		i, ok := index[l.Type]
+		if !ok {
+			header, named := dependencyHeaders[l.Type]
+			if !named {
+				header = string(l.Type)
+			}
+			i = len(groups)
+			index[l.Type] = i
+			groups = append(groups, dependencyGroup{Header: header})
+		}
+		groups[i].Links = append(groups[i].Links, l)
+	}
+	return groups
+}
+
+// printOtherDependencies prints a section for each group from otherDependencyGroups.
+func printOtherDependencies(w io.Writer, links []*DependencyLink) {
+	for _, g := range otherDependencyGroups(links) {
+		fmt.Fprintf(w, "\n%s:\n", g.Header)
+		for _, l := range g.Links {
+			fmt.Fprintf(w, "  - %s: %s [%s]\n", l.Ticket.ID, ticket.SanitizeLine(l.Ticket.Title), l.Ticket.Status)
+		}
+	}
+}
+
+// terminalWidth returns the width of the terminal on stdout, or 0 if stdout
+// is not a terminal. It is a variable so tests can simulate a terminal.
+var terminalWidth = func() int {
+	fd := os.Stdout.Fd()
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// useColor reports whether to color text output: only when stdout is a
+// terminal and NO_COLOR is unset. It is a variable so tests can force it.
+var useColor = func() bool {
+	return os.Getenv("NO_COLOR") == "" && term.IsTerminal(os.Stdout.Fd())
+}
+
+// wrapWidth picks the width to wrap text output to: the --width flag if it
+// was given, then wrap_width from the config, then the terminal's width.
+// A result of 0 means no wrapping.
+func wrapWidth(flagWidth int, flagSet bool, cfg *config.Config) int {
+	if flagSet {
+		return flagWidth
+	}
+	if cfg != nil && cfg.WrapWidth > 0 {
+		return cfg.WrapWidth
+	}
+	return terminalWidth()
+}
+
+// wrapText word-wraps each line of s to at most width characters, breaking
+// at spaces. A word longer than width is left whole on its own line.
+// Leading indentation is kept on the first line. A width of 0 or less
+// returns s unchanged.
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) <= width {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		var b strings.Builder
+		b.WriteString(indent)
+		col := utf8.RuneCountInString(indent)
+		start := true
+		for _, word := range strings.Fields(line) {
+			n := utf8.RuneCountInString(word)
+			if !start && col+1+n > width {
+				b.WriteString("\n")
+				col = 0
+				start = true
+			}
+			if !start {
+				b.WriteString(" ")
+				col++
+			}
+			b.WriteString(word)
+			col += n
+			start = false
 		}
+		lines[i] = b.String()
 	}
+	return strings.Join(lines, "\n")
 }
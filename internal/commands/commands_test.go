@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
@@ -38,6 +40,80 @@ func setupTestProject(t *testing.T) (string, func()) {
 	return dir, cleanup
 }
 
+// firstTicketID returns the ID of the highest priority ticket in the project at dir.
+func firstTicketID(t *testing.T, dir string) string {
+	t.Helper()
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tickets) == 0 {
+		t.Fatal("no tickets in project")
+	}
+	return tickets[0].ID
+}
+
+// captureStdout runs fn and returns everything it wrote to os.Stdout.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+// captureStderr runs fn and returns everything it wrote to os.Stderr.
+func captureStderr(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	return captureFile(t, &os.Stderr, fn)
+}
+
+func captureFile(t *testing.T, file **os.File, fn func() error) (string, error) {
+	t.Helper()
+
+	old := *file
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+	*file = w
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		done <- buf.String()
+	}()
+
+	fnErr := fn()
+	w.Close()
+	*file = old
+
+	return <-done, fnErr
+}
+
+// setStdin makes os.Stdin read input for the rest of the test.
+func setStdin(t *testing.T, input string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	old := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = old
+		f.Close()
+	})
+}
+
 func TestPrintTicketTable(t *testing.T) {
 	tickets := []*ticket.Ticket{
 		{ID: "TH-111111", Title: "First ticket", Status: ticket.StatusOpen, Priority: 1},
@@ -45,7 +121,7 @@ func TestPrintTicketTable(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	printTicketTable(&buf, tickets)
+	printTicketTable(&buf, tickets, displayOptions{})
 
 	output := buf.String()
 	if !strings.Contains(output, "TH-111111") {
@@ -65,7 +141,7 @@ func TestPrintTicketTable_LongTitle(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	printTicketTable(&buf, tickets)
+	printTicketTable(&buf, tickets, displayOptions{})
 
 	output := buf.String()
 	if strings.Contains(output, "displayed in the table") {
@@ -76,6 +152,83 @@ func TestPrintTicketTable_LongTitle(t *testing.T) {
 	}
 }
 
+func TestPrintTicketTable_NoHeader(t *testing.T) {
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "First ticket", Status: ticket.StatusOpen, Priority: 1},
+	}
+
+	var buf bytes.Buffer
+	printTicketTable(&buf, tickets, displayOptions{NoHeader: true})
+
+	output := buf.String()
+	if strings.Contains(output, "TITLE") || strings.Contains(output, "---") {
+		t.Errorf("Output should not contain the header, got: %s", output)
+	}
+	if lines := strings.Split(strings.TrimSpace(output), "\n"); len(lines) != 1 || !strings.HasPrefix(lines[0], "TH-111111") {
+		t.Errorf("Output should be a single ticket row, got: %q", output)
+	}
+}
+
+func TestPrintTicketTable_PriorityLabels(t *testing.T) {
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "First ticket", Status: ticket.StatusOpen, Priority: 1},
+	}
+
+	var buf bytes.Buffer
+	printTicketTable(&buf, tickets, displayOptions{PriorityLabels: true})
+	if !strings.Contains(buf.String(), "1 (High)") {
+		t.Errorf("Output should contain priority label, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	printTicketTable(&buf, tickets, displayOptions{})
+	if strings.Contains(buf.String(), "High") {
+		t.Errorf("Output should not contain priority label by default, got: %s", buf.String())
+	}
+}
+
+func TestPrintTicketTable_EscapesControlCharacters(t *testing.T) {
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "\x1b[31mRed alert\x1b[0m", Status: ticket.StatusOpen, Priority: 1},
+	}
+
+	var buf bytes.Buffer
+	printTicketTable(&buf, tickets, displayOptions{})
+
+	output := buf.String()
+	if strings.Contains(output, "\x1b") {
+		t.Error("Table output should not contain a raw escape character")
+	}
+	if !strings.Contains(output, `\x1b[31mRed alert`) {
+		t.Errorf("Table output should show the escape sequence as visible text, got: %q", output)
+	}
+}
+
+func TestPrintTicketDetail_EscapesControlCharacters(t *testing.T) {
+	details := &TicketDetails{
+		Ticket: &ticket.Ticket{
+			ID:          "TH-111111",
+			Title:       "Title\x1b]0;pwned\x07",
+			Description: "Line one\nLine two\x1b[2J",
+			Status:      ticket.StatusOpen,
+		},
+		Comments: []*ticket.Comment{
+			{ID: "TH-c111111", TicketID: "TH-111111", Content: "\x1b[1mbold"},
+		},
+	}
+
+	var buf bytes.Buffer
+	printTicketDetail(&buf, details, displayOptions{})
+
+	output := buf.String()
+	if strings.Contains(output, "\x1b") || strings.Contains(output, "\x07") {
+		t.Errorf("Detail output should not contain raw control characters, got: %q", output)
+	}
+	if !strings.Contains(output, "Line one\nLine two") {
+		t.Error("Detail output should preserve newlines in the description")
+	}
+}
+
 func TestPrintTicketDetail_WithComments(t *testing.T) {
 	tk := &ticket.Ticket{
 		ID:          "TH-111111",
@@ -95,7 +248,7 @@ func TestPrintTicketDetail_WithComments(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	printTicketDetail(&buf, details)
+	printTicketDetail(&buf, details, displayOptions{})
 
 	output := buf.String()
 	if !strings.Contains(output, "TH-111111") {
@@ -112,6 +265,54 @@ func TestPrintTicketDetail_WithComments(t *testing.T) {
 	}
 }
 
+func TestPrintTicketDetail_Wrap(t *testing.T) {
+	tk := &ticket.Ticket{
+		ID:          "TH-111111",
+		Title:       "A title that is longer than the wrap width",
+		Description: "The quick brown fox jumps over the lazy dog",
+		Status:      ticket.StatusOpen,
+	}
+	details := &TicketDetails{
+		Ticket:   tk,
+		Comments: []*ticket.Comment{{ID: "TH-c111111", TicketID: tk.ID, Content: "a comment long enough to wrap"}},
+	}
+
+	var buf bytes.Buffer
+	printTicketDetail(&buf, details, displayOptions{Width: 20})
+
+	output := buf.String()
+	if !strings.Contains(output, "\nThe quick brown fox\njumps over the lazy\ndog\n") {
+		t.Errorf("description should wrap at 20 columns:\n%s", output)
+	}
+	if !strings.Contains(output, "Title:       A title that is longer than the wrap width\n") {
+		t.Errorf("title should not wrap:\n%s", output)
+	}
+	if strings.Contains(output, "a comment long enough to wrap") {
+		t.Errorf("comment should wrap:\n%s", output)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"one two three", 0, "one two three"},
+		{"one two three", 20, "one two three"},
+		{"one two three", 7, "one two\nthree"},
+		{"one two three", 8, "one two\nthree"},
+		{"a https://example.com/very/long b", 10, "a\nhttps://example.com/very/long\nb"},
+		{"short\n\none two three", 7, "short\n\none two\nthree"},
+		{"  indented words here", 12, "  indented\nwords here"},
+	}
+	for _, tt := range tests {
+		if got := wrapText(tt.in, tt.width); got != tt.want {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
 func TestPrintTicketDetail_NoComments(t *testing.T) {
 	tk := &ticket.Ticket{
 		ID:     "TH-111111",
@@ -124,7 +325,7 @@ func TestPrintTicketDetail_NoComments(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	printTicketDetail(&buf, details)
+	printTicketDetail(&buf, details, displayOptions{})
 
 	output := buf.String()
 	if strings.Contains(output, "Comments:") {
@@ -13,10 +13,25 @@ import (
 
 // Comment adds a comment to a ticket.
 func Comment(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "list":
+			return commentList(args[1:])
+		case "ack":
+			return commentAck(args[1:])
+		}
+	}
+
 	fs, jsonOutput, dataDir := newFlagSet("comment")
+	edit := fs.Bool("edit", false, "Write the comment in $EDITOR")
+	file := fs.String("file", "", "Read the comment from this file (\"-\" for stdin)")
 	fs.Usage = func() {
 		fmt.Fprintln(os.Stderr, "Usage: thicket comment <TICKET-ID> <MESSAGE> [--json] [--data-dir <DIR>]")
-		fmt.Fprintln(os.Stderr, "\nAdd a comment to a ticket.")
+		fmt.Fprintln(os.Stderr, "       thicket comment --edit <TICKET-ID>")
+		fmt.Fprintln(os.Stderr, "       thicket comment --file <PATH> <TICKET-ID>")
+		fmt.Fprintln(os.Stderr, "       thicket comment list <TICKET-ID>")
+		fmt.Fprintln(os.Stderr, "       thicket comment ack <COMMENT-ID>")
+		fmt.Fprintln(os.Stderr, "\nAdd a comment to a ticket, list a ticket's comments, or acknowledge a comment.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
 	}
@@ -30,20 +45,134 @@ func Comment(args []string) error {
 	if fs.NArg() < 1 {
 		return thickerr.WithHint("Ticket ID is required", "Usage: thicket comment <TICKET-ID> \"Comment text\"")
 	}
-	if fs.NArg() < 2 {
+	if *file != "" {
+		if *edit {
+			return thickerr.WithHint("Cannot combine --file and --edit", "Use one of --file or --edit")
+		}
+		if fs.NArg() > 1 {
+			return thickerr.WithHint("Cannot combine --file with comment text", "Usage: thicket comment --file <PATH> <TICKET-ID>")
+		}
+	} else if fs.NArg() < 2 && !*edit {
 		return thickerr.WithHint("Comment text is required", "Usage: thicket comment <TICKET-ID> \"Comment text\"")
 	}
 
-	ticketID := normalizeTicketID(fs.Arg(0))
-	if err := ticket.ValidateID(ticketID); err != nil {
-		return thickerr.InvalidTicketID(ticketID)
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// Verify ticket exists
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
 	}
 
 	content := fs.Arg(1)
+	if *file != "" {
+		if content, err = readInputFile(*file); err != nil {
+			return err
+		}
+	}
+	if *edit {
+		edited, err := editText(content)
+		if err != nil {
+			return err
+		}
+		if edited == "" {
+			return thickerr.New("Aborting comment: the editor returned an empty message")
+		}
+		content = edited
+	}
 	if strings.TrimSpace(content) == "" {
 		return thickerr.EmptyComment()
 	}
 
+	c, err := ticket.NewComment(ticketID, content)
+	if err != nil {
+		return wrapTicketError(err)
+	}
+	c.Author = config.ResolveIdentity()
+
+	if err := store.AddComment(c); err != nil {
+		return wrapTicketError(err)
+	}
+
+	warning, err := commentCountWarning(store, ticketID, cfg)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return printJSON(SuccessResponse{
+			Success: true,
+			ID:      c.ID,
+			Message: fmt.Sprintf("Added comment %s to ticket %s", c.ID, ticketID),
+			Hint:    warning,
+		})
+	}
+
+	fmt.Printf("Added comment %s to ticket %s\n", c.ID, ticketID)
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return nil
+}
+
+// commentCountWarning returns a warning if the ticket has more comments than
+// the config's comment_warning_count, or an empty string otherwise.
+func commentCountWarning(store *storage.Store, ticketID string, cfg *config.Config) (string, error) {
+	comments, err := store.GetComments(ticketID)
+	if err != nil {
+		return "", err
+	}
+	threshold := cfg.CommentWarningThreshold()
+	if len(comments) <= threshold {
+		return "", nil
+	}
+	return fmt.Sprintf("Ticket %s has %d comments, more than the limit of %d; consider summarizing them in the description", ticketID, len(comments), threshold), nil
+}
+
+// commentList prints the comments on a ticket.
+func commentList(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("comment list")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket comment list <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nList the comments on a ticket with their IDs, timestamps, and authors.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket comment list <TICKET-ID>")
+	}
+
 	root, err := config.FindRoot()
 	if err != nil {
 		return wrapConfigError(err)
@@ -56,7 +185,11 @@ func Comment(args []string) error {
 	}
 	defer store.Close()
 
-	// Verify ticket exists
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
 	t, err := store.Get(ticketID)
 	if err != nil {
 		return err
@@ -65,23 +198,117 @@ func Comment(args []string) error {
 		return thickerr.TicketNotFound(ticketID)
 	}
 
-	c, err := ticket.NewComment(ticketID, content)
+	comments, err := store.GetComments(ticketID)
 	if err != nil {
 		return err
 	}
 
-	if err := store.AddComment(c); err != nil {
+	if *jsonOutput {
+		if comments == nil {
+			comments = []*ticket.Comment{}
+		}
+		return printJSON(comments)
+	}
+
+	if len(comments) == 0 {
+		fmt.Printf("No comments on ticket %s.\n", ticketID)
+		return nil
+	}
+
+	for i, c := range comments {
+		if i > 0 {
+			fmt.Println()
+		}
+		author := c.Author
+		if author == "" {
+			author = "-"
+		}
+		fmt.Printf("%s  %s  %s\n", c.ID, c.Created.Format("2006-01-02 15:04:05"), ticket.SanitizeLine(author))
+		for _, line := range strings.Split(ticket.SanitizeText(c.Content), "\n") {
+			fmt.Printf("  %s\n", line)
+		}
+		if c.IsAcked() {
+			fmt.Printf("  (%s)\n", commentAckNote(c, "", "2006-01-02 15:04:05"))
+		}
+	}
+	return nil
+}
+
+// commentAck marks a comment as acknowledged by the current user.
+func commentAck(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("comment ack")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket comment ack <COMMENT-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nMark a comment as acknowledged, recording who acknowledged it and when.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Comment ID is required", "Usage: thicket comment ack <COMMENT-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	id := fs.Arg(0)
+	c, err := store.GetComment(id)
+	if err != nil {
 		return err
 	}
+	if c == nil {
+		return thickerr.CommentNotFound(id)
+	}
+
+	// Acknowledging a comment again keeps the first acknowledgement.
+	message := fmt.Sprintf("Comment %s was already acknowledged", c.ID)
+	if !c.IsAcked() {
+		c.Ack(config.ResolveIdentity())
+		if err := store.UpdateComment(c); err != nil {
+			return wrapTicketError(err)
+		}
+		message = fmt.Sprintf("Acknowledged comment %s on ticket %s", c.ID, c.TicketID)
+	}
 
 	if *jsonOutput {
 		return printJSON(SuccessResponse{
 			Success: true,
 			ID:      c.ID,
-			Message: fmt.Sprintf("Added comment %s to ticket %s", c.ID, ticketID),
+			Message: message,
 		})
 	}
 
-	fmt.Printf("Added comment %s to ticket %s\n", c.ID, ticketID)
+	fmt.Println(message)
 	return nil
 }
+
+// commentAckNote describes who acknowledged c and when, using the given time
+// format or, if it is empty, fallback.
+func commentAckNote(c *ticket.Comment, format, fallback string) string {
+	note := "acknowledged"
+	if c.AckedBy != "" {
+		note += " by " + ticket.SanitizeLine(c.AckedBy)
+	}
+	return note + " " + ticket.FormatTime(c.Acked, format, fallback)
+}
@@ -1,11 +1,18 @@
 package commands
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
 	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
 )
 
 func TestComment(t *testing.T) {
@@ -131,3 +138,392 @@ func TestComment_TicketNotFound(t *testing.T) {
 		t.Errorf("Comment() error = %v, want error containing 'not found'", err)
 	}
 }
+
+func TestComment_Edit(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	ticketID := tickets[0].ID
+	store.Close()
+
+	stubEditor(t, "Comment from editor")
+
+	if err := Comment([]string{"--edit", ticketID}); err != nil {
+		t.Fatalf("Comment(--edit) error = %v", err)
+	}
+
+	store, _ = storage.Open(paths)
+	comments, _ := store.GetComments(ticketID)
+	store.Close()
+
+	if len(comments) != 1 {
+		t.Fatalf("Expected 1 comment, got %d", len(comments))
+	}
+	if comments[0].Content != "Comment from editor" {
+		t.Errorf("Content = %q, want 'Comment from editor'", comments[0].Content)
+	}
+}
+
+func TestComment_EditEmptyAborts(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	ticketID := tickets[0].ID
+	store.Close()
+
+	// An editor that exits without writing anything leaves the file empty.
+	t.Setenv("EDITOR", "true")
+
+	err := Comment([]string{"--edit", ticketID})
+	if err == nil || !strings.Contains(err.Error(), "Aborting") {
+		t.Errorf("Comment(--edit) error = %v, want abort error", err)
+	}
+
+	store, _ = storage.Open(paths)
+	comments, _ := store.GetComments(ticketID)
+	store.Close()
+	if len(comments) != 0 {
+		t.Errorf("Expected no comments after abort, got %d", len(comments))
+	}
+}
+
+func TestComment_Author(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	ticketID := tickets[0].ID
+	store.Close()
+
+	if err := Comment([]string{ticketID, "Signed comment"}); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	store, _ = storage.Open(paths)
+	comments, _ := store.GetComments(ticketID)
+	store.Close()
+
+	if len(comments) != 1 || comments[0].Author != "Alice" {
+		t.Errorf("Comments = %+v, want one comment by Alice", comments)
+	}
+}
+
+func TestComment_Ack(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+	ticketID := firstTicketID(t, dir)
+	if err := Comment([]string{ticketID, "Please review"}); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	comments, _ := store.GetComments(ticketID)
+	store.Close()
+	commentID := comments[0].ID
+
+	t.Setenv(config.IdentityEnv, "Bob")
+	if err := Comment([]string{"ack", commentID}); err != nil {
+		t.Fatalf("Comment(ack) error = %v", err)
+	}
+
+	// The acknowledgement is in the JSONL files, not just the cache.
+	if err := storage.RemoveCache(paths); err != nil {
+		t.Fatalf("RemoveCache() error = %v", err)
+	}
+	store, _ = storage.Open(paths)
+	acked, err := store.GetComment(commentID)
+	store.Close()
+	if err != nil || acked == nil {
+		t.Fatalf("GetComment() = %v, %v; want the comment", acked, err)
+	}
+	if !acked.IsAcked() || acked.AckedBy != "Bob" || acked.Author != "Alice" {
+		t.Errorf("comment = %+v, want it by Alice and acknowledged by Bob", acked)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, "acknowledged by Bob") {
+		t.Errorf("Show() output should note the acknowledgement, got: %s", output)
+	}
+
+	// Acknowledging again keeps the first acknowledgement.
+	t.Setenv(config.IdentityEnv, "Carol")
+	output, err = captureStdout(t, func() error {
+		return Comment([]string{"ack", "--json", commentID})
+	})
+	if err != nil {
+		t.Fatalf("Comment(ack --json) error = %v", err)
+	}
+	if !strings.Contains(output, "already acknowledged") {
+		t.Errorf("Comment(ack) output = %s, want it already acknowledged", output)
+	}
+	store, _ = storage.Open(paths)
+	again, _ := store.GetComment(commentID)
+	store.Close()
+	if again.AckedBy != "Bob" || !again.Acked.Equal(acked.Acked) {
+		t.Errorf("comment = %+v, want the first acknowledgement kept", again)
+	}
+
+	if err := Comment([]string{"ack", "TH-czzzzzz"}); !thickerr.IsNotFound(err) {
+		t.Errorf("Comment(ack) of a missing comment error = %v, want not found", err)
+	}
+}
+
+func TestCommentList(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	ticketID := tickets[0].ID
+	store.Close()
+
+	Comment([]string{ticketID, "First comment"})
+	Comment([]string{ticketID, "Second comment"})
+
+	store, _ = storage.Open(paths)
+	comments, _ := store.GetComments(ticketID)
+	store.Close()
+
+	output, err := captureStdout(t, func() error {
+		return Comment([]string{"list", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Comment(list) error = %v", err)
+	}
+	for _, c := range comments {
+		if !strings.Contains(output, c.ID) {
+			t.Errorf("Output should contain comment ID %s, got: %s", c.ID, output)
+		}
+	}
+	for _, want := range []string{"Alice", "First comment", "Second comment"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got: %s", want, output)
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Comment([]string{"list", "--json", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Comment(list --json) error = %v", err)
+	}
+
+	var listed []*ticket.Comment
+	if err := json.Unmarshal([]byte(output), &listed); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(listed) != 2 || listed[0].ID != comments[0].ID || listed[1].ID != comments[1].ID {
+		t.Errorf("JSON comments = %+v, want both comments in order", listed)
+	}
+}
+
+func TestCommentList_NoComments(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	output, err := captureStdout(t, func() error {
+		return Comment([]string{"list", "--json", tickets[0].ID})
+	})
+	if err != nil {
+		t.Fatalf("Comment(list --json) error = %v", err)
+	}
+	if strings.TrimSpace(output) != "[]" {
+		t.Errorf("Output = %q, want empty JSON array", output)
+	}
+}
+
+func TestComment_File(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Test ticket"})
+	id := firstTicketID(t, dir)
+
+	body := "first line\n  indented line\n\n"
+	path := filepath.Join(t.TempDir(), "log.txt")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := Comment([]string{"--file", path, id}); err != nil {
+		t.Fatalf("Comment(--file) error = %v", err)
+	}
+
+	setStdin(t, "from stdin\n")
+	if err := Comment([]string{"--file", "-", id}); err != nil {
+		t.Fatalf("Comment(--file -) error = %v", err)
+	}
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	comments, err := store.GetComments(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want 2", len(comments))
+	}
+	contents := []string{comments[0].Content, comments[1].Content}
+	if !slices.Contains(contents, "first line\n  indented line") || !slices.Contains(contents, "from stdin") {
+		t.Errorf("comments = %q, want the file and stdin contents", contents)
+	}
+}
+
+func TestComment_FileErrors(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Test ticket"})
+	id := firstTicketID(t, dir)
+
+	blank := filepath.Join(t.TempDir(), "blank.txt")
+	if err := os.WriteFile(blank, []byte(" \n\t\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"blank file", []string{"--file", blank, id}},
+		{"missing file", []string{"--file", filepath.Join(t.TempDir(), "missing.txt"), id}},
+		{"file and text", []string{"--file", blank, id, "text"}},
+		{"file and edit", []string{"--file", blank, "--edit", id}},
+	}
+	for _, tt := range tests {
+		if err := Comment(tt.args); err == nil {
+			t.Errorf("Comment() with %s expected error", tt.name)
+		}
+	}
+}
+
+func TestComment_TooLong(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	defer ticket.SetMaxCommentLength(0)
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	cfgData := []byte(`{"project_code": "TH", "max_comment_length": 20}`)
+	if err := os.WriteFile(config.GetPaths(dir).Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	Add([]string{"--title", "Test ticket"})
+	id := firstTicketID(t, dir)
+
+	err := Comment([]string{id, strings.Repeat("x", 21)})
+	if err == nil {
+		t.Fatal("Comment() expected error for an over-length comment")
+	}
+	if !strings.Contains(err.Error(), "maximum is 20 characters") {
+		t.Errorf("Comment() error = %v, want the configured limit", err)
+	}
+	if err := Comment([]string{id, strings.Repeat("x", 20)}); err != nil {
+		t.Errorf("Comment() at the limit error = %v", err)
+	}
+}
+
+func TestComment_CountWarning(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	cfgData := []byte(`{"project_code": "TH", "comment_warning_count": 2}`)
+	if err := os.WriteFile(config.GetPaths(dir).Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	Add([]string{"--title", "Test ticket"})
+	id := firstTicketID(t, dir)
+
+	hints := make([]string, 3)
+	for i := range hints {
+		output, err := captureStdout(t, func() error {
+			return Comment([]string{"--json", id, fmt.Sprintf("Comment %d", i)})
+		})
+		if err != nil {
+			t.Fatalf("Comment() error = %v", err)
+		}
+		var resp SuccessResponse
+		if err := json.Unmarshal([]byte(output), &resp); err != nil {
+			t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+		}
+		hints[i] = resp.Hint
+	}
+
+	if hints[0] != "" || hints[1] != "" {
+		t.Errorf("hints = %q, want no warning up to the threshold", hints[:2])
+	}
+	if !strings.Contains(hints[2], "has 3 comments") {
+		t.Errorf("hint = %q, want a warning about 3 comments", hints[2])
+	}
+}
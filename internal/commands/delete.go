@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Delete soft-deletes a ticket.
+func Delete(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("delete")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket delete <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nDelete a ticket. Deleted tickets are hidden from list and ready but can be")
+		fmt.Fprintln(os.Stderr, "brought back with 'thicket restore'.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket delete <TICKET-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	if t.Status == ticket.StatusDeleted {
+		if *jsonOutput {
+			return printJSON(SuccessResponse{
+				Success: true,
+				ID:      t.ID,
+				Message: fmt.Sprintf("Ticket %s is already deleted", t.ID),
+			})
+		}
+		fmt.Printf("Ticket %s is already deleted\n", t.ID)
+		return nil
+	}
+
+	t.Delete()
+	t.UpdatedBy = config.ResolveIdentity()
+
+	if err := store.Update(t); err != nil {
+		return err
+	}
+
+	hint := "To undo, run: thicket restore " + t.ID
+
+	if *jsonOutput {
+		return printJSON(SuccessResponse{
+			Success: true,
+			ID:      t.ID,
+			Message: fmt.Sprintf("Deleted ticket %s", t.ID),
+			Hint:    hint,
+		})
+	}
+
+	fmt.Printf("Deleted ticket %s\n", t.ID)
+	fmt.Printf("\nHint: %s\n", hint)
+	return nil
+}
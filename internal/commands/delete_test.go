@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestDelete(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Keep me"})
+	Add([]string{"--title", "Delete me"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	var deleteID string
+	for _, tk := range tickets {
+		if tk.Title == "Delete me" {
+			deleteID = tk.ID
+		}
+	}
+
+	if err := Delete([]string{deleteID}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// Hidden by default
+	titles := listTitles(t)
+	if len(titles) != 1 || titles[0] != "Keep me" {
+		t.Errorf("List() titles = %v, want [Keep me]", titles)
+	}
+
+	// Revealed with --include-deleted
+	titles = listTitles(t, "--include-deleted")
+	if len(titles) != 2 {
+		t.Errorf("List(--include-deleted) titles = %v, want both tickets", titles)
+	}
+
+	// Still in the tickets file
+	store, _ = storage.Open(paths)
+	tk, _ := store.Get(deleteID)
+	store.Close()
+	if tk == nil || tk.Status != ticket.StatusDeleted {
+		t.Errorf("Deleted ticket = %+v, want status deleted", tk)
+	}
+}
+
+func TestDelete_AlreadyDeleted(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	Delete([]string{tickets[0].ID})
+	if err := Delete([]string{tickets[0].ID}); err != nil {
+		t.Errorf("Delete() of deleted ticket error = %v", err)
+	}
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Delete([]string{"TH-999999"}); err == nil {
+		t.Error("Delete() expected error for nonexistent ticket")
+	}
+}
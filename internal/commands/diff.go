@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Kinds of ticket change reported by diff.
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+// FieldChange is a change to one field of a ticket.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// TicketChange describes how a ticket differs between two revisions.
+type TicketChange struct {
+	ID     string        `json:"id"`
+	Title  string        `json:"title"`
+	Change string        `json:"change"`           // added, removed, or modified
+	Fields []FieldChange `json:"fields,omitempty"` // Changed fields of a modified ticket
+}
+
+// DiffResponse is the JSON output of the diff command.
+type DiffResponse struct {
+	From    string         `json:"from"`
+	To      string         `json:"to"`
+	Changes []TicketChange `json:"changes"`
+}
+
+// Diff reports how tickets changed between two git revisions of tickets.jsonl.
+func Diff(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("diff")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket diff <REV1> <REV2> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nShow the tickets added, removed, and modified in tickets.jsonl between two git revisions.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 2 {
+		return thickerr.WithHint("Two git revisions are required", "Usage: thicket diff <REV1> <REV2> (e.g., thicket diff HEAD~1 HEAD)")
+	}
+	from, to := fs.Arg(0), fs.Arg(1)
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	before, err := ticketsAtRevision(paths.Tickets, from)
+	if err != nil {
+		return err
+	}
+	after, err := ticketsAtRevision(paths.Tickets, to)
+	if err != nil {
+		return err
+	}
+
+	changes := diffTickets(before, after)
+
+	if *jsonOutput {
+		if changes == nil {
+			changes = []TicketChange{}
+		}
+		return printJSON(DiffResponse{From: from, To: to, Changes: changes})
+	}
+
+	if len(changes) == 0 {
+		fmt.Printf("No ticket changes between %s and %s.\n", from, to)
+		return nil
+	}
+	printDiff(os.Stdout, changes)
+	return nil
+}
+
+// ticketsAtRevision reads the tickets in file as of git revision rev.
+func ticketsAtRevision(file, rev string) ([]*ticket.Ticket, error) {
+	data, err := revisions.ReadAt(file, rev)
+	if err != nil {
+		return nil, thickerr.WithHint(
+			fmt.Sprintf("Cannot read tickets at %s: %v", rev, err),
+			"Use a git revision, such as HEAD~1 or a branch name, in which tickets.jsonl exists",
+		)
+	}
+	r, err := storage.NewJSONLReader(file, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("reading tickets at %s: %w", rev, err)
+	}
+	tickets, _, _, err := storage.DecodeAllJSONL(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading tickets at %s: %w", rev, err)
+	}
+	return tickets, nil
+}
+
+// diffTickets compares two sets of tickets and returns the changes, sorted
+// by ticket ID.
+func diffTickets(before, after []*ticket.Ticket) []TicketChange {
+	old := make(map[string]*ticket.Ticket, len(before))
+	for _, t := range before {
+		old[t.ID] = t
+	}
+
+	var changes []TicketChange
+	seen := make(map[string]bool, len(after))
+	for _, t := range after {
+		seen[t.ID] = true
+		prev, ok := old[t.ID]
+		if !ok {
+			changes = append(changes, TicketChange{ID: t.ID, Title: t.Title, Change: changeAdded})
+			continue
+		}
+		if fields := diffFields(prev, t); len(fields) > 0 {
+			changes = append(changes, TicketChange{ID: t.ID, Title: t.Title, Change: changeModified, Fields: fields})
+		}
+	}
+	for _, t := range before {
+		if !seen[t.ID] {
+			changes = append(changes, TicketChange{ID: t.ID, Title: t.Title, Change: changeRemoved})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ID < changes[j].ID
+	})
+	return changes
+}
+
+// diffFields returns the user-visible fields that differ between two
+// versions of a ticket. Bookkeeping fields such as the update time and rank
+// are ignored.
+func diffFields(before, after *ticket.Ticket) []FieldChange {
+	pairs := []FieldChange{
+		{"title", before.Title, after.Title},
+		{"description", before.Description, after.Description},
+		{"type", string(before.Type), string(after.Type)},
+		{"status", string(before.Status), string(after.Status)},
+		{"close_reason", string(before.CloseReason), string(after.CloseReason)},
+		{"priority", strconv.Itoa(before.Priority), strconv.Itoa(after.Priority)},
+		{"estimate", strconv.Itoa(before.Estimate), strconv.Itoa(after.Estimate)},
+		{"assignee", before.Assignee, after.Assignee},
+		{"labels", strings.Join(before.Labels, ", "), strings.Join(after.Labels, ", ")},
+	}
+
+	var changed []FieldChange
+	for _, p := range pairs {
+		if p.Old != p.New {
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
+
+// printDiff prints one line per changed ticket, followed by the changed
+// fields of modified tickets.
+func printDiff(w io.Writer, changes []TicketChange) {
+	markers := map[string]string{changeAdded: "+", changeRemoved: "-", changeModified: "~"}
+	for _, c := range changes {
+		fmt.Fprintf(w, "%s %s %s\n", markers[c.Change], c.ID, ticket.SanitizeLine(c.Title))
+		for _, f := range c.Fields {
+			if f.Field == "description" {
+				fmt.Fprintln(w, "    description changed")
+				continue
+			}
+			fmt.Fprintf(w, "    %s: %s -> %s\n", f.Field, formatDiffValue(f.Old), formatDiffValue(f.New))
+		}
+	}
+}
+
+// formatDiffValue renders a field value for printDiff, showing empty values
+// as "-".
+func formatDiffValue(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return ticket.SanitizeLine(s)
+}
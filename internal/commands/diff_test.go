@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// stubRevisions makes diff and --ref read tickets from in-memory snapshots, keyed by
+// revision, instead of from git.
+func stubRevisions(t *testing.T, snapshots map[string][]*ticket.Ticket) {
+	t.Helper()
+
+	old := revisions
+	revisions = revisionsFunc(func(file, rev string) ([]byte, error) {
+		tickets, ok := snapshots[rev]
+		if !ok {
+			return nil, fmt.Errorf("unknown revision %s", rev)
+		}
+		var buf bytes.Buffer
+		if err := storage.EncodeAllJSONL(&buf, tickets, nil, nil); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	})
+	t.Cleanup(func() { revisions = old })
+}
+
+// revisionsFunc is a revisionReader that calls itself.
+type revisionsFunc func(file, rev string) ([]byte, error)
+
+func (f revisionsFunc) ReadAt(file, rev string) ([]byte, error) { return f(file, rev) }
+
+func TestDiff(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	now := time.Now().UTC()
+	snapshot := func(id, title string, status ticket.Status, priority int, labels ...string) *ticket.Ticket {
+		return &ticket.Ticket{ID: id, Title: title, Status: status, Priority: priority, Labels: labels, Created: now, Updated: now}
+	}
+	stubRevisions(t, map[string][]*ticket.Ticket{
+		"v1": {
+			snapshot("TH-aaaaaa", "Unchanged", ticket.StatusOpen, 2),
+			snapshot("TH-bbbbbb", "Old title", ticket.StatusOpen, 2, "bug"),
+			snapshot("TH-cccccc", "Removed", ticket.StatusOpen, 1),
+		},
+		"v2": {
+			snapshot("TH-aaaaaa", "Unchanged", ticket.StatusOpen, 2),
+			snapshot("TH-bbbbbb", "New title", ticket.StatusClosed, 2, "bug", "urgent"),
+			snapshot("TH-dddddd", "Added", ticket.StatusOpen, 3),
+		},
+	})
+
+	output, err := captureStdout(t, func() error {
+		return Diff([]string{"--json", "v1", "v2"})
+	})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	var resp DiffResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(resp.Changes) != 3 {
+		t.Fatalf("changes = %+v, want 3", resp.Changes)
+	}
+
+	modified, removed, added := resp.Changes[0], resp.Changes[1], resp.Changes[2]
+	if modified.ID != "TH-bbbbbb" || modified.Change != "modified" {
+		t.Errorf("first change = %+v, want TH-bbbbbb modified", modified)
+	}
+	want := []FieldChange{
+		{"title", "Old title", "New title"},
+		{"status", "open", "closed"},
+		{"labels", "bug", "bug, urgent"},
+	}
+	if fmt.Sprint(modified.Fields) != fmt.Sprint(want) {
+		t.Errorf("modified fields = %v, want %v", modified.Fields, want)
+	}
+	if removed.ID != "TH-cccccc" || removed.Change != "removed" {
+		t.Errorf("second change = %+v, want TH-cccccc removed", removed)
+	}
+	if added.ID != "TH-dddddd" || added.Change != "added" || len(added.Fields) != 0 {
+		t.Errorf("third change = %+v, want TH-dddddd added", added)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Diff([]string{"v1", "v2"})
+	})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	for _, line := range []string{"~ TH-bbbbbb New title", "    status: open -> closed", "- TH-cccccc Removed", "+ TH-dddddd Added"} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("Diff() output should contain %q:\n%s", line, output)
+		}
+	}
+	if strings.Contains(output, "TH-aaaaaa") {
+		t.Errorf("Diff() output should not mention unchanged tickets:\n%s", output)
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	stubRevisions(t, map[string][]*ticket.Ticket{"v1": nil})
+
+	if err := Diff([]string{"v1"}); err == nil {
+		t.Error("Diff() with one revision expected error")
+	}
+	if err := Diff([]string{"v1", "missing"}); err == nil {
+		t.Error("Diff() with an unknown revision expected error")
+	}
+}
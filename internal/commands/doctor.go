@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+// DoctorResponse is the JSON output of the doctor command.
+type DoctorResponse struct {
+	Success  bool                 `json:"success"`
+	Repaired bool                 `json:"repaired"` // The cache was rebuilt from scratch
+	Counts   storage.RecordCounts `json:"counts"`
+	Problems []string             `json:"problems"`
+}
+
+// Doctor checks the project's data and cache for problems, and with
+// --repair-cache rebuilds the cache from scratch.
+func Doctor(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("doctor")
+	repair := fs.Bool("repair-cache", false, "Delete the cache and rebuild it from tickets.jsonl")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket doctor [--repair-cache] [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nCheck the project's data and cache for problems.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	problems, err := storage.CheckTimestamps(paths)
+	if err != nil {
+		return err
+	}
+
+	// Malformed data stops the cache from loading, so only check the cache
+	// once the data is known to be good.
+	var counts storage.RecordCounts
+	var repaired bool
+	hint := "Correct the timestamps in the JSONL files, e.g. 2026-01-25T10:00:00Z"
+	if len(problems) == 0 {
+		hint = "Run 'thicket doctor --repair-cache' to rebuild the cache"
+		if *repair {
+			if err := storage.RemoveCache(paths); err != nil {
+				return err
+			}
+		}
+		store, err := storage.Open(paths)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		repaired = *repair || store.Repaired()
+
+		tickets, comments, dependencies, err := storage.ReadAllJSONL(paths)
+		if err != nil {
+			return err
+		}
+		if counts, err = store.CacheCounts(); err != nil {
+			return err
+		}
+		want := storage.RecordCounts{Tickets: len(tickets), Comments: len(comments), Dependencies: len(dependencies)}
+		if counts != want {
+			problems = append(problems, fmt.Sprintf(
+				"cache has %d tickets, %d comments, %d dependencies, but the JSONL files have %d, %d, %d",
+				counts.Tickets, counts.Comments, counts.Dependencies, want.Tickets, want.Comments, want.Dependencies))
+		}
+	}
+	if problems == nil {
+		problems = []string{}
+	}
+
+	if *jsonOutput {
+		if err := printJSON(DoctorResponse{
+			Success:  len(problems) == 0,
+			Repaired: repaired,
+			Counts:   counts,
+			Problems: problems,
+		}); err != nil {
+			return err
+		}
+	} else {
+		if repaired {
+			fmt.Println("Rebuilt cache from tickets.jsonl")
+		}
+		for _, p := range problems {
+			fmt.Printf("Problem: %s\n", p)
+		}
+		if len(problems) == 0 {
+			fmt.Printf("No problems found in %d tickets, %d comments, %d dependencies\n", counts.Tickets, counts.Comments, counts.Dependencies)
+		}
+	}
+
+	if len(problems) > 0 {
+		return thickerr.WithHint(
+			fmt.Sprintf("Found %d problem(s)", len(problems)),
+			hint,
+		)
+	}
+	return nil
+}
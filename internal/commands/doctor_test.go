@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+)
+
+func TestDoctor_CorruptCache(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Task"})
+
+	cache := config.GetPaths(dir).Cache
+	corrupt := func() {
+		t.Helper()
+		if err := os.WriteFile(cache, []byte("this is not a SQLite database"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	// Commands rebuild a corrupt cache rather than failing.
+	corrupt()
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("List() with corrupt cache error = %v", err)
+	}
+	var tickets []TicketJSON
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil || len(tickets) != 1 {
+		t.Fatalf("List() = %s, want the one ticket", output)
+	}
+
+	corrupt()
+	output, err = captureStdout(t, func() error {
+		return Doctor([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("Doctor() error = %v", err)
+	}
+	var resp DoctorResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v\n%s", err, output)
+	}
+	if !resp.Success || !resp.Repaired || resp.Counts.Tickets != 1 || len(resp.Problems) != 0 {
+		t.Errorf("response = %+v, want a successful repair with 1 ticket", resp)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Doctor([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("Doctor() error = %v", err)
+	}
+	resp = DoctorResponse{}
+	json.Unmarshal([]byte(output), &resp)
+	if !resp.Success || resp.Repaired {
+		t.Errorf("response = %+v, want success without a repair", resp)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Doctor([]string{"--repair-cache", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("Doctor(--repair-cache) error = %v", err)
+	}
+	resp = DoctorResponse{}
+	json.Unmarshal([]byte(output), &resp)
+	if !resp.Success || !resp.Repaired || resp.Counts.Tickets != 1 {
+		t.Errorf("response = %+v, want a successful repair with 1 ticket", resp)
+	}
+}
+
+func TestDoctor_MalformedTimestamp(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	line := `{"id":"TH-111111","title":"Edited by hand","description":"","status":"open","priority":1,"created":"last tuesday","updated":"2024-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(config.GetPaths(dir).Tickets, []byte(line), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Doctor([]string{"--json"})
+	})
+	if err == nil {
+		t.Error("Doctor() expected error for a malformed timestamp")
+	}
+	var resp DoctorResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v\n%s", err, output)
+	}
+	if resp.Success || len(resp.Problems) != 1 || !strings.Contains(resp.Problems[0], `tickets.jsonl line 1: created time "last tuesday"`) {
+		t.Errorf("response = %+v, want the malformed created time", resp)
+	}
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// defaultEditor is used when $EDITOR is not set.
+const defaultEditor = "vi"
+
+// editText opens the user's $EDITOR on a temporary file containing initial
+// and returns the saved content with surrounding whitespace trimmed.
+func editText(initial string) (string, error) {
+	file, err := os.CreateTemp("", "thicket-*.md")
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(initial); err != nil {
+		file.Close()
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("closing temp file: %w", err)
+	}
+
+	editor := os.Getenv("EDITOR")
+	if strings.TrimSpace(editor) == "" {
+		editor = defaultEditor
+	}
+
+	// $EDITOR may include arguments, such as "code --wait".
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", thickerr.WithHint(
+			fmt.Sprintf("Editor %q failed: %v", editor, err),
+			"Set the EDITOR environment variable to your preferred editor",
+		)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading temp file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// editDescription opens the description of t in $EDITOR and returns the saved
+// text and whether it differs from the current description. Saving an empty
+// description is an error, so quitting the editor after deleting everything
+// can't wipe a description by accident.
+func editDescription(t *ticket.Ticket) (string, bool, error) {
+	edited, err := editText(t.Description)
+	if err != nil {
+		return "", false, err
+	}
+	if edited == strings.TrimSpace(t.Description) {
+		return t.Description, false, nil
+	}
+	if edited == "" {
+		return "", false, thickerr.New("Aborting edit: the editor returned an empty description")
+	}
+	return edited, true, nil
+}
+
+// readInputFile returns the contents of the file at path, or of stdin if
+// path is "-".
+func readInputFile(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", thickerr.New(fmt.Sprintf("File not found: %s", path))
+		}
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// stubEditor points $EDITOR at a script that overwrites the edited file with content.
+func stubEditor(t *testing.T, content string) {
+	t.Helper()
+
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	body := "#!/bin/sh\nprintf '%s' '" + content + "' > \"$1\"\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	t.Setenv("EDITOR", script)
+}
+
+func TestEditText(t *testing.T) {
+	stubEditor(t, "  Edited text\n")
+
+	got, err := editText("initial")
+	if err != nil {
+		t.Fatalf("editText() error = %v", err)
+	}
+	if got != "Edited text" {
+		t.Errorf("editText() = %q, want 'Edited text'", got)
+	}
+}
+
+func TestEditText_KeepsUnchangedContent(t *testing.T) {
+	t.Setenv("EDITOR", "true")
+
+	got, err := editText("initial")
+	if err != nil {
+		t.Fatalf("editText() error = %v", err)
+	}
+	if got != "initial" {
+		t.Errorf("editText() = %q, want 'initial'", got)
+	}
+}
+
+func TestEditText_EditorFails(t *testing.T) {
+	t.Setenv("EDITOR", "false")
+
+	if _, err := editText(""); err == nil {
+		t.Error("editText() expected error when editor exits non-zero")
+	}
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Export writes tickets, comments, and dependencies to stdout as JSONL.
+func Export(args []string) error {
+	fs, _, dataDir := newFlagSet("export")
+	since := fs.String("since", "", "Only export records created or updated after this time (RFC 3339 or YYYY-MM-DD)")
+	canonical := fs.Bool("canonical", false, "Sort records by ID, sort labels, and use UTC times so exports of the same data are identical")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket export [--since <TIME>] [--canonical] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nWrite tickets, comments, and dependencies to stdout in the tickets.jsonl format.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	var cutoff time.Time
+	if *since != "" {
+		var err error
+		if cutoff, err = parseSince(*since); err != nil {
+			return err
+		}
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	// Read the JSONL file directly: it is the source of truth, and the
+	// export should match it record for record.
+	paths := config.GetPaths(root)
+	tickets, comments, dependencies, err := storage.ReadAllJSONL(paths)
+	if err != nil {
+		return err
+	}
+
+	if !cutoff.IsZero() {
+		tickets, comments, dependencies = filterSince(cutoff, tickets, comments, dependencies)
+	}
+
+	if *canonical {
+		canonicalizeTickets(tickets)
+		canonicalizeComments(comments)
+		canonicalizeDependencies(dependencies)
+	}
+
+	return storage.EncodeAllJSONL(os.Stdout, tickets, comments, dependencies)
+}
+
+// parseSince parses an RFC 3339 timestamp or a YYYY-MM-DD date, which is
+// taken as midnight UTC.
+func parseSince(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, thickerr.WithHint(
+		fmt.Sprintf("Invalid time: %s", s),
+		"Use an RFC 3339 timestamp (e.g., 2026-01-02T15:04:05Z) or a date (e.g., 2026-01-02)",
+	)
+}
+
+// filterSince keeps the records created or updated after cutoff.
+func filterSince(cutoff time.Time, tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.Dependency) {
+	var recentTickets []*ticket.Ticket
+	for _, t := range tickets {
+		if t.Created.After(cutoff) || t.Updated.After(cutoff) {
+			recentTickets = append(recentTickets, t)
+		}
+	}
+
+	var recentComments []*ticket.Comment
+	for _, c := range comments {
+		if c.Created.After(cutoff) {
+			recentComments = append(recentComments, c)
+		}
+	}
+
+	var recentDependencies []*ticket.Dependency
+	for _, d := range dependencies {
+		if d.Created.After(cutoff) {
+			recentDependencies = append(recentDependencies, d)
+		}
+	}
+
+	return recentTickets, recentComments, recentDependencies
+}
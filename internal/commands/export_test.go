@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+func TestExport(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "One"})
+	Add([]string{"--title", "Two", "--priority", "0"})
+	Comment([]string{firstTicketID(t, dir), "A comment"})
+
+	output, err := captureStdout(t, func() error {
+		return Export([]string{})
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	tickets, comments, _, err := storage.DecodeAllJSONL(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("DecodeAllJSONL() error = %v", err)
+	}
+	if len(tickets) != 2 || len(comments) != 1 {
+		t.Errorf("exported %d tickets and %d comments, want 2 and 1", len(tickets), len(comments))
+	}
+}
+
+func TestExport_Since(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Old and untouched"})
+	Add([]string{"--title", "Old but updated", "--priority", "0"})
+	updatedID := firstTicketID(t, dir)
+	Comment([]string{updatedID, "Old comment"})
+
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+
+	Update([]string{"--title", "Recently updated", updatedID})
+	Comment([]string{updatedID, "New comment"})
+	Add([]string{"--title", "New ticket", "--blocked-by", updatedID})
+
+	output, err := captureStdout(t, func() error {
+		return Export([]string{"--since", cutoff.Format(time.RFC3339Nano)})
+	})
+	if err != nil {
+		t.Fatalf("Export(--since) error = %v", err)
+	}
+
+	tickets, comments, dependencies, err := storage.DecodeAllJSONL(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("DecodeAllJSONL() error = %v", err)
+	}
+
+	var titles []string
+	for _, tk := range tickets {
+		titles = append(titles, tk.Title)
+	}
+	slices.Sort(titles)
+	if strings.Join(titles, ",") != "New ticket,Recently updated" {
+		t.Errorf("exported tickets = %v, want the new and updated tickets", titles)
+	}
+	if len(comments) != 1 || comments[0].Content != "New comment" {
+		t.Errorf("exported comments = %v, want only the new comment", comments)
+	}
+	if len(dependencies) != 1 {
+		t.Errorf("exported %d dependencies, want 1", len(dependencies))
+	}
+}
+
+func TestExport_InvalidSince(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Export([]string{"--since", "yesterday"}); err == nil {
+		t.Error("Export(--since yesterday) expected error")
+	}
+}
+
+func TestExport_Canonical(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Labeled", "--label", "zeta", "--label", "alpha"})
+	Add([]string{"--title", "Other", "--priority", "0"})
+	Comment([]string{firstTicketID(t, dir), "A comment"})
+
+	// Store a ticket with unsorted labels and a non-UTC time, as a hand
+	// edit or another tool might.
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk := ticketsByTitle(t, dir)["Labeled"]
+	tk.Labels = []string{"zeta", "alpha"}
+	tk.Updated = tk.Updated.In(time.FixedZone("UTC+2", 2*60*60))
+	if err := store.Update(tk); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	store.Close()
+
+	export := func() string {
+		output, err := captureStdout(t, func() error {
+			return Export([]string{"--canonical"})
+		})
+		if err != nil {
+			t.Fatalf("Export(--canonical) error = %v", err)
+		}
+		return output
+	}
+
+	first := export()
+	if second := export(); first != second {
+		t.Errorf("canonical exports differ:\n%s\n---\n%s", first, second)
+	}
+	if !strings.Contains(first, `"labels":["alpha","zeta"]`) {
+		t.Errorf("canonical export should sort labels:\n%s", first)
+	}
+	if strings.Contains(first, "+02:00") {
+		t.Errorf("canonical export should use UTC times:\n%s", first)
+	}
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	thickerr "github.com/abarth/thicket/internal/errors"
+)
+
+// ticketFields lists the fields of TicketJSON, in output order.
+var ticketFields = jsonFieldNames(reflect.TypeOf(TicketJSON{}))
+
+// jsonFieldNames returns the JSON names of the exported fields of a struct
+// type, including the fields of embedded structs.
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			names = append(names, jsonFieldNames(embedded)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// parseFields parses a comma-separated --fields value. It returns nil if
+// spec is empty, meaning all fields.
+func parseFields(spec string) ([]string, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var fields []string
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		if !slices.Contains(ticketFields, f) {
+			return nil, thickerr.UnknownField(f, ticketFields)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parseJSONFields parses the --fields flag, which only applies to --json output.
+func parseJSONFields(spec string, jsonOutput bool) ([]string, error) {
+	if spec != "" && !jsonOutput {
+		return nil, thickerr.WithHint("--fields requires --json", "Add --json to select fields of the JSON output")
+	}
+	return parseFields(spec)
+}
+
+// selectFields limits the JSON output of each ticket to the given fields.
+func selectFields(tickets []*TicketJSON, fields []string) {
+	for _, t := range tickets {
+		if t != nil {
+			t.fields = fields
+		}
+	}
+}
+
+// MarshalJSON encodes the ticket, keeping only the selected fields if any
+// were chosen with --fields.
+func (t *TicketJSON) MarshalJSON() ([]byte, error) {
+	type plain TicketJSON
+	data, err := json.Marshal((*plain)(t))
+	if err != nil || t.fields == nil {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	// Build the object by hand to keep the fields in the requested order.
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range t.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		if value, ok := all[f]; ok {
+			buf.Write(value)
+		} else {
+			// Fields tagged omitempty are left out when unset.
+			buf.WriteString("null")
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Fields that list --group-by can group tickets by.
+var groupByFields = []string{"status", "type", "assignee", "priority"}
+
+// ticketGroup is a set of tickets that share a value of the grouped field.
+type ticketGroup struct {
+	Key     string
+	Tickets []*ticket.Ticket
+}
+
+// validateGroupBy checks a --group-by value.
+func validateGroupBy(field string) error {
+	if slices.Contains(groupByFields, field) {
+		return nil
+	}
+	return thickerr.WithHint(
+		fmt.Sprintf("Invalid group: %s", field),
+		"Valid groups are: status, type, assignee, priority",
+	)
+}
+
+// groupKey returns the value of field for t, as shown in a group header.
+func groupKey(t *ticket.Ticket, field string) string {
+	switch field {
+	case "status":
+		return string(t.Status)
+	case "type":
+		if t.Type == "" {
+			return "none"
+		}
+		return string(t.Type)
+	case "assignee":
+		if t.Assignee == "" {
+			return "unassigned"
+		}
+		return t.Assignee
+	case "priority":
+		return strconv.Itoa(t.Priority)
+	}
+	return ""
+}
+
+// groupTickets groups tickets by field, keeping the order of tickets within
+// each group. Groups are sorted by key, numerically for priority.
+func groupTickets(tickets []*ticket.Ticket, field string) []ticketGroup {
+	index := make(map[string]int)
+	var groups []ticketGroup
+	for _, t := range tickets {
+		key := groupKey(t, field)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ticketGroup{Key: key})
+		}
+		groups[i].Tickets = append(groups[i].Tickets, t)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if field == "priority" {
+			return groups[i].Tickets[0].Priority < groups[j].Tickets[0].Priority
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// printTicketGroups prints each group under a header, as a separate table.
+func printTicketGroups(w io.Writer, groups []ticketGroup, field string, opts displayOptions) {
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		header := g.Key
+		if field == "priority" {
+			header = formatPriority(g.Tickets[0].Priority, opts)
+		}
+		fmt.Fprintf(w, "%s: %s (%d)\n", field, ticket.SanitizeLine(header), len(g.Tickets))
+		printTicketTable(w, g.Tickets, opts)
+	}
+}
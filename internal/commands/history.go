@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// HistoryEntry is a single event in a ticket's history.
+//
+// Thicket does not store an audit log, so history is derived from the
+// records that do exist: the ticket's created and updated timestamps, its
+// comments, and its dependencies. A ticket that is no longer open is assumed
+// to have changed status at its last update.
+type HistoryEntry struct {
+	Time   time.Time `json:"time"`
+	Event  string    `json:"event"`
+	Field  string    `json:"field,omitempty"`
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// History event names.
+const (
+	historyCreated   = "created"
+	historyUpdated   = "updated"
+	historyChanged   = "changed"
+	historyCommented = "commented"
+	historyLinked    = "linked"
+)
+
+// buildHistory derives the history of a ticket, oldest event first.
+func buildHistory(store *storage.Store, t *ticket.Ticket, comments []*ticket.Comment) ([]HistoryEntry, error) {
+	history := []HistoryEntry{{
+		Time:   t.Created,
+		Event:  historyCreated,
+		Detail: t.Title,
+	}}
+
+	for _, c := range comments {
+		history = append(history, HistoryEntry{
+			Time:   c.Created,
+			Event:  historyCommented,
+			Detail: c.ID,
+		})
+	}
+
+	from, err := store.GetDependenciesFrom(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range from {
+		detail := fmt.Sprintf("blocked by %s", d.ToTicketID)
+		switch d.Type {
+		case ticket.DependencyCreatedFrom:
+			detail = fmt.Sprintf("created from %s", d.ToTicketID)
+		case ticket.DependencyRelatedTo:
+			detail = fmt.Sprintf("related to %s", d.ToTicketID)
+		}
+		history = append(history, HistoryEntry{Time: d.Created, Event: historyLinked, Detail: detail})
+	}
+
+	to, err := store.GetDependenciesTo(t.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range to {
+		if d.Type != ticket.DependencyBlockedBy {
+			continue
+		}
+		history = append(history, HistoryEntry{
+			Time:   d.Created,
+			Event:  historyLinked,
+			Detail: fmt.Sprintf("blocks %s", d.FromTicketID),
+		})
+	}
+
+	if t.Updated.After(t.Created) {
+		if t.Status != ticket.StatusOpen {
+			history = append(history, HistoryEntry{
+				Time:  t.Updated,
+				Event: historyChanged,
+				Field: "status",
+				From:  string(ticket.StatusOpen),
+				To:    string(t.Status),
+			})
+		} else {
+			history = append(history, HistoryEntry{Time: t.Updated, Event: historyUpdated})
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Time.Before(history[j].Time)
+	})
+	return history, nil
+}
+
+// printHistory prints a ticket's history in human-readable format, with
+// times in timeFormat (see ticket.FormatTime).
+func printHistory(w io.Writer, id string, history []HistoryEntry, timeFormat string) {
+	fmt.Fprintf(w, "History of %s:\n", id)
+	for _, h := range history {
+		line := h.Event
+		switch {
+		case h.Field != "":
+			line = fmt.Sprintf("%s %s: %s -> %s", h.Event, h.Field, h.From, h.To)
+		case h.Detail != "":
+			line = fmt.Sprintf("%s: %s", h.Event, ticket.SanitizeLine(h.Detail))
+		}
+		fmt.Fprintf(w, "  %s  %s\n", ticket.FormatTime(h.Time, timeFormat, time.DateTime), line)
+	}
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// hooksDisabled is set by --no-hooks.
+var hooksDisabled bool
+
+// DisableHooks stops configured hooks from running.
+func DisableHooks() {
+	hooksDisabled = true
+}
+
+// runHook runs the shell command configured for event, if any, after the
+// event has been saved. Hooks are best-effort: a failing hook prints a
+// warning but doesn't fail the command. The hook runs in the project root
+// with details of the ticket in its environment, and its output goes to
+// stderr so that it can't corrupt JSON output.
+func runHook(root string, cfg *config.Config, event string, t *ticket.Ticket) {
+	command := cfg.HookCommand(event)
+	if command == "" || hooksDisabled {
+		return
+	}
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(),
+		"THICKET_EVENT="+event,
+		"THICKET_TICKET_ID="+t.ID,
+		"THICKET_TICKET_TITLE="+t.Title,
+		"THICKET_TICKET_STATUS="+string(t.Status),
+	)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %s hook failed: %v\n", event, err)
+	}
+}
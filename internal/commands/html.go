@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// ticketHTMLTemplate renders a self-contained HTML page for a single ticket.
+// html/template escapes all ticket content, so titles, descriptions, and
+// comments cannot inject markup or scripts into the page.
+var ticketHTMLTemplate = template.Must(template.New("ticket").Funcs(template.FuncMap{
+	"timestamp": func(t time.Time) string { return t.Format(time.RFC3339) },
+	"isClosed":  func(t *ticket.Ticket) bool { return t.Status == ticket.StatusClosed },
+	"otherDeps": otherDependencyGroups,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Ticket.ID}}: {{.Ticket.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 2em auto; padding: 0 1em; color: #222; }
+h1 { font-size: 1.4em; }
+th { text-align: left; padding-right: 1em; color: #555; }
+pre { background: #f5f5f5; padding: 1em; white-space: pre-wrap; }
+.closed { color: #888; text-decoration: line-through; }
+.comment { border-left: 3px solid #ccc; padding-left: 1em; margin-bottom: 1em; }
+.meta { color: #777; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{.Ticket.ID}}: {{.Ticket.Title}}</h1>
+<table>
+<tr><th>Type</th><td>{{if .Ticket.Type}}{{.Ticket.Type}}{{else}}-{{end}}</td></tr>
+<tr><th>Status</th><td>{{.Ticket.Status}}</td></tr>
+{{if and (isClosed .Ticket) .Ticket.CloseReason}}<tr><th>Reason</th><td>{{.Ticket.CloseReason}}</td></tr>
+{{end}}<tr><th>Priority</th><td>{{.Ticket.Priority}}</td></tr>
+<tr><th>Assignee</th><td>{{if .Ticket.Assignee}}{{.Ticket.Assignee}}{{else}}(unassigned){{end}}</td></tr>
+{{if .Ticket.Estimate}}<tr><th>Estimate</th><td>{{.Ticket.Estimate}}</td></tr>
+{{end}}<tr><th>Labels</th><td>{{range $i, $l := .Ticket.Labels}}{{if $i}}, {{end}}{{$l}}{{else}}(none){{end}}</td></tr>
+{{if .Ticket.Watchers}}<tr><th>Watchers</th><td>{{range $i, $w := .Ticket.Watchers}}{{if $i}}, {{end}}{{$w}}{{end}}</td></tr>
+{{end}}<tr><th>Created</th><td>{{timestamp .Ticket.Created}}</td></tr>
+<tr><th>Updated</th><td>{{timestamp .Ticket.Updated}}</td></tr>
+{{- if .CreatedFrom}}
+<tr><th>Created from</th><td>{{.CreatedFrom.ID}} ({{.CreatedFrom.Title}})</td></tr>
+{{- end}}
+</table>
+{{- if .BlockedBy}}
+<h2>Blocked by</h2>
+<ul>
+{{- range .BlockedBy}}
+<li{{if isClosed .}} class="closed"{{end}}>{{.ID}}: {{.Title}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Blocking}}
+<h2>Blocking</h2>
+<ul>
+{{- range .Blocking}}
+<li>{{.ID}}: {{.Title}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .CreatedChildren}}
+<h2>Created from this ticket</h2>
+<ul>
+{{- range .CreatedChildren}}
+<li{{if isClosed .}} class="closed"{{end}}>{{.ID}}: {{.Title}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- range otherDeps .Dependencies}}
+<h2>{{.Header}}</h2>
+<ul>
+{{- range .Links}}
+<li{{if isClosed .Ticket}} class="closed"{{end}}>{{.Ticket.ID}}: {{.Ticket.Title}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Ticket.Description}}
+<h2>Description</h2>
+<pre>{{.Ticket.Description}}</pre>
+{{- end}}
+{{- if .Comments}}
+<h2>Comments</h2>
+{{- range .Comments}}
+<div class="comment">
+<div class="meta">{{timestamp .Created}}</div>
+<pre>{{.Content}}</pre>
+</div>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// printTicketHTML writes a self-contained HTML page describing the ticket.
+func printTicketHTML(w io.Writer, details *TicketDetails) error {
+	return ticketHTMLTemplate.Execute(w, details)
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestPrintTicketHTML(t *testing.T) {
+	details := &TicketDetails{
+		Ticket: &ticket.Ticket{
+			ID:          "TH-111111",
+			Title:       "Fix login",
+			Description: "Steps:\n1. Log in",
+			Status:      ticket.StatusOpen,
+			Priority:    1,
+			Labels:      []string{"auth", "bug"},
+		},
+		Comments: []*ticket.Comment{
+			{ID: "TH-c111111", TicketID: "TH-111111", Content: "Looking into it"},
+		},
+		BlockedBy: []*ticket.Ticket{
+			{ID: "TH-222222", Title: "Blocker", Status: ticket.StatusClosed},
+		},
+		Blocking: []*ticket.Ticket{
+			{ID: "TH-333333", Title: "Downstream", Status: ticket.StatusOpen},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := printTicketHTML(&buf, details); err != nil {
+		t.Fatalf("printTicketHTML() error = %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"TH-111111: Fix login",
+		"<pre>Steps:\n1. Log in</pre>",
+		"auth, bug",
+		"Looking into it",
+		`class="closed">TH-222222: Blocker`,
+		"TH-333333: Downstream",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("HTML output missing %q", want)
+		}
+	}
+}
+
+func TestPrintTicketHTML_EscapesContent(t *testing.T) {
+	details := &TicketDetails{
+		Ticket: &ticket.Ticket{
+			ID:          "TH-111111",
+			Title:       "<script>alert('x')</script>",
+			Description: "<b>bold</b>",
+			Status:      ticket.StatusOpen,
+		},
+		Comments: []*ticket.Comment{
+			{ID: "TH-c111111", TicketID: "TH-111111", Content: "<img src=x onerror=alert(1)>"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := printTicketHTML(&buf, details); err != nil {
+		t.Fatalf("printTicketHTML() error = %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "<script>") {
+		t.Error("HTML output should escape <script> in the title")
+	}
+	if !strings.Contains(output, "&lt;script&gt;") {
+		t.Errorf("HTML output should contain escaped script tag, got: %s", output)
+	}
+	if strings.Contains(output, "<b>bold</b>") || strings.Contains(output, "<img") {
+		t.Error("HTML output should escape markup in description and comments")
+	}
+}
@@ -14,9 +14,13 @@ import (
 func Init(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("init")
 	projectCode := fs.String("project", "", "Two-letter project code (e.g., TH)")
+	force := fs.Bool("force", false, "Reinitialize an existing project, setting its project code but keeping its other settings and its tickets")
+	wipe := fs.Bool("wipe", false, "With --force, delete all existing tickets and start fresh")
+	splitFiles := fs.Bool("split-files", false, "Store comments and dependencies in comments.jsonl and deps.jsonl instead of tickets.jsonl")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: thicket init --project <CODE> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "Usage: thicket init --project <CODE> [--split-files] [--force [--wipe]] [--json] [--data-dir <DIR>]")
 		fmt.Fprintln(os.Stderr, "\nInitialize a new Thicket project in the current directory.")
+		fmt.Fprintln(os.Stderr, "Use --force to repair a broken project; its tickets are kept unless --wipe is also given.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
 	}
@@ -31,6 +35,13 @@ func Init(args []string) error {
 		return thickerr.MissingRequired("project")
 	}
 
+	if *wipe && !*force {
+		return thickerr.WithHint("--wipe requires --force", "Use --force --wipe to delete all tickets and reinitialize")
+	}
+	if *splitFiles && *force {
+		return thickerr.New("--split-files cannot be combined with --force, which keeps the project's existing file layout")
+	}
+
 	*projectCode = strings.ToUpper(*projectCode)
 
 	if err := ticket.ValidateProjectCode(*projectCode); err != nil {
@@ -42,17 +53,33 @@ func Init(args []string) error {
 		return fmt.Errorf("getting working directory: %w", err)
 	}
 
-	if err := config.Init(wd, *projectCode); err != nil {
+	message := fmt.Sprintf("Initialized Thicket project with code %s", *projectCode)
+	if *force {
+		if err := config.Reinit(wd, *projectCode, *wipe); err != nil {
+			return wrapConfigError(err)
+		}
+		message = fmt.Sprintf("Reinitialized Thicket project with code %s", *projectCode)
+		if *wipe {
+			message += "; all tickets were deleted"
+		} else {
+			message += "; existing tickets were kept"
+		}
+	} else if *splitFiles {
+		if err := config.InitSplit(wd, *projectCode); err != nil {
+			return wrapConfigError(err)
+		}
+		message += " using separate files for tickets, comments, and dependencies"
+	} else if err := config.Init(wd, *projectCode); err != nil {
 		return wrapConfigError(err)
 	}
 
 	if *jsonOutput {
 		return printJSON(SuccessResponse{
 			Success: true,
-			Message: fmt.Sprintf("Initialized Thicket project with code %s", *projectCode),
+			Message: message,
 		})
 	}
 
-	fmt.Printf("Initialized Thicket project with code %s\n", *projectCode)
+	fmt.Println(message)
 	return nil
 }
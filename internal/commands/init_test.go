@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
 )
 
 func TestInit(t *testing.T) {
@@ -65,3 +68,155 @@ func TestInit_AlreadyInitialized(t *testing.T) {
 		t.Error("Init() expected error for already initialized")
 	}
 }
+
+func TestInit_ForceRepairsConfig(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := Add([]string{"--title", "Keep me"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	// Break the project by removing its config.
+	paths := config.GetPaths(dir)
+	if err := os.Remove(paths.Config); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Init([]string{"--project", "TH", "--force"}); err != nil {
+		t.Fatalf("Init(--force) error = %v", err)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	if cfg.ProjectCode != "TH" {
+		t.Errorf("ProjectCode = %q, want TH", cfg.ProjectCode)
+	}
+
+	store, err := storage.Open(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 1 || tickets[0].Title != "Keep me" {
+		t.Errorf("tickets after --force = %v, want the existing ticket", tickets)
+	}
+}
+
+func TestInit_ForceWipe(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := Add([]string{"--title", "Delete me"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := Init([]string{"--project", "AB", "--force", "--wipe"}); err != nil {
+		t.Fatalf("Init(--force --wipe) error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	if cfg.ProjectCode != "AB" {
+		t.Errorf("ProjectCode = %q, want AB", cfg.ProjectCode)
+	}
+
+	store, err := storage.Open(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 0 {
+		t.Errorf("tickets after --wipe = %d, want 0", len(tickets))
+	}
+}
+
+func TestInit_WipeRequiresForce(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := Add([]string{"--title", "Keep me"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := Init([]string{"--project", "TH", "--wipe"}); err == nil {
+		t.Fatal("Init(--wipe) expected error without --force")
+	}
+
+	data, err := os.ReadFile(config.GetPaths(dir).Tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) == 0 {
+		t.Error("tickets file was emptied without --force")
+	}
+}
+
+func TestInit_SplitFiles(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH", "--split-files"}); err != nil {
+		t.Fatalf("Init(--split-files) error = %v", err)
+	}
+	if err := Add([]string{"--title", "Split"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	id := firstTicketID(t, dir)
+	if err := Comment([]string{id, "Stored separately"}); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	if !paths.Split() {
+		t.Fatal("project does not use the split layout")
+	}
+	data, err := os.ReadFile(paths.Comments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Stored separately") {
+		t.Errorf("comments.jsonl = %q, want the comment", data)
+	}
+	data, err = os.ReadFile(paths.Tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Stored separately") {
+		t.Error("tickets.jsonl should not hold comments in the split layout")
+	}
+}
+
+func TestInit_SplitFilesWithForce(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := Init([]string{"--project", "TH", "--force", "--split-files"}); err == nil {
+		t.Error("Init(--force --split-files) expected error")
+	}
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// LabelRenameResponse is the JSON output of the label rename command.
+type LabelRenameResponse struct {
+	Success bool     `json:"success"`
+	Old     string   `json:"old"`
+	New     string   `json:"new"`
+	Tickets []string `json:"tickets"` // IDs of the tickets whose labels changed
+}
+
+// Label manages labels across the whole project.
+func Label(args []string) error {
+	usage := "Usage: thicket label rename <OLD> <NEW>"
+	if len(args) == 0 {
+		return thickerr.WithHint("A label subcommand is required", usage)
+	}
+
+	switch args[0] {
+	case "rename":
+		return labelRename(args[1:])
+	case "-h", "--help":
+		fmt.Fprintln(os.Stderr, usage)
+		fmt.Fprintln(os.Stderr, "\nSubcommands:")
+		fmt.Fprintln(os.Stderr, "  rename  Rename a label on every ticket, merging it into <NEW> where both exist")
+		return nil
+	default:
+		return thickerr.WithHint(fmt.Sprintf("Unknown label subcommand: %s", args[0]), usage)
+	}
+}
+
+// labelRename renames a label on every ticket.
+func labelRename(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("label rename")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket label rename <OLD> <NEW> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nRename a label on every ticket. Tickets that already have <NEW> just lose <OLD>.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 2 {
+		return thickerr.WithHint("The old and new labels are required", "Usage: thicket label rename <OLD> <NEW>")
+	}
+	old, new := fs.Arg(0), fs.Arg(1)
+	for _, l := range []string{old, new} {
+		if err := ticket.ValidateLabel(l); err != nil {
+			return thickerr.WithHint(fmt.Sprintf("Invalid label: %s", l), "Labels must be 1-30 alphanumeric characters, hyphens, or underscores")
+		}
+	}
+	if old == new {
+		return thickerr.New("The old and new labels are the same")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	changed, err := store.RenameLabel(old, new)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		if changed == nil {
+			changed = []string{}
+		}
+		return printJSON(LabelRenameResponse{Success: true, Old: old, New: new, Tickets: changed})
+	}
+
+	if len(changed) == 0 {
+		fmt.Printf("No tickets have the label %s\n", old)
+		return nil
+	}
+	fmt.Printf("Renamed label %s to %s on %d ticket(s)\n", old, new, len(changed))
+	return nil
+}
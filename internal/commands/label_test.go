@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestLabelRename(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Typo", "--label", "bugg"})
+	Add([]string{"--title", "Both", "--label", "bugg", "--label", "bug"})
+	Add([]string{"--title", "Closed typo", "--label", "bugg", "--label", "ui"})
+	Add([]string{"--title", "Unrelated", "--label", "docs"})
+	Close([]string{ticketsByTitle(t, dir)["Closed typo"].ID})
+
+	output, err := captureStdout(t, func() error {
+		return Label([]string{"rename", "--json", "bugg", "bug"})
+	})
+	if err != nil {
+		t.Fatalf("Label(rename) error = %v", err)
+	}
+	var resp LabelRenameResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(resp.Tickets) != 3 {
+		t.Errorf("renamed tickets = %v, want 3", resp.Tickets)
+	}
+
+	// Check both the cache and tickets.jsonl, which a rebuild reads.
+	for _, rebuild := range []bool{false, true} {
+		if rebuild {
+			if err := Sync([]string{"--rebuild"}); err != nil {
+				t.Fatalf("Sync(--rebuild) error = %v", err)
+			}
+		}
+		byTitle := ticketsByTitle(t, dir)
+		for _, title := range []string{"Typo", "Both", "Closed typo"} {
+			labels := byTitle[title].Labels
+			if slices.Contains(labels, "bugg") {
+				t.Errorf("%s labels = %v, should not contain bugg (rebuild=%v)", title, labels, rebuild)
+			}
+			count := 0
+			for _, l := range labels {
+				if l == "bug" {
+					count++
+				}
+			}
+			if count != 1 {
+				t.Errorf("%s labels = %v, want bug exactly once (rebuild=%v)", title, labels, rebuild)
+			}
+		}
+		if got := byTitle["Unrelated"].Labels; !slices.Equal(got, []string{"docs"}) {
+			t.Errorf("Unrelated labels = %v, want [docs]", got)
+		}
+	}
+}
+
+func TestLabelRename_Errors(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, args := range [][]string{
+		{},
+		{"unknown"},
+		{"rename", "only-one"},
+		{"rename", "same", "same"},
+		{"rename", "ok", "not valid"},
+	} {
+		if err := Label(args); err == nil {
+			t.Errorf("Label(%v) expected error", args)
+		}
+	}
+}
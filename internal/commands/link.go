@@ -38,11 +38,6 @@ func Link(args []string) error {
 		return thickerr.WithHint("Ticket ID is required", "Usage: thicket link <TICKET-ID> --blocked-by <ID>")
 	}
 
-	ticketID := normalizeTicketID(fs.Arg(0))
-	if err := ticket.ValidateID(ticketID); err != nil {
-		return thickerr.InvalidTicketID(ticketID)
-	}
-
 	if *blockedBy == "" && *createdFrom == "" {
 		return thickerr.WithHint(
 			"No dependency type specified",
@@ -69,6 +64,11 @@ func Link(args []string) error {
 	}
 	defer store.Close()
 
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
 	// Verify the main ticket exists
 	t, err := store.Get(ticketID)
 	if err != nil {
@@ -78,19 +78,20 @@ func Link(args []string) error {
 		return thickerr.TicketNotFound(ticketID)
 	}
 
-	var targetID string
+	var targetArg string
 	var depType ticket.DependencyType
 
 	if *blockedBy != "" {
-		targetID = normalizeTicketID(*blockedBy)
+		targetArg = *blockedBy
 		depType = ticket.DependencyBlockedBy
 	} else {
-		targetID = normalizeTicketID(*createdFrom)
+		targetArg = *createdFrom
 		depType = ticket.DependencyCreatedFrom
 	}
 
-	if err := ticket.ValidateID(targetID); err != nil {
-		return thickerr.InvalidTicketID(targetID)
+	targetID, err := resolveTicketID(store, targetArg)
+	if err != nil {
+		return err
 	}
 
 	// Verify target ticket exists
@@ -115,14 +116,19 @@ func Link(args []string) error {
 	}
 
 	if err := store.AddDependency(dep); err != nil {
+		var conflict *thickerr.UserError
 		switch err {
 		case ticket.ErrCircularDependency:
-			return thickerr.CircularDependency()
+			conflict = thickerr.CircularDependency()
 		case ticket.ErrDuplicateDependency:
-			return thickerr.DuplicateDependency()
+			conflict = thickerr.DuplicateDependency()
 		default:
 			return err
 		}
+		if *jsonOutput {
+			return printJSONError(conflict)
+		}
+		return conflict
 	}
 
 	if *jsonOutput {
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	thickerr "github.com/abarth/thicket/internal/errors"
+)
+
+func TestLink_JSONConflict(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, title := range []string{"Blocked", "Blocker"} {
+		if err := Add([]string{"--title", title}); err != nil {
+			t.Fatalf("Add(%s) error = %v", title, err)
+		}
+	}
+	tickets := ticketsByTitle(t, dir)
+	blocked, blocker := tickets["Blocked"].ID, tickets["Blocker"].ID
+
+	if err := Link([]string{"--blocked-by", blocker, blocked}); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"duplicate", []string{"--json", "--blocked-by", blocker, blocked}, "This dependency already exists"},
+		{"circular", []string{"--json", "--blocked-by", blocked, blocker}, "This would create a circular dependency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := captureStdout(t, func() error { return Link(tt.args) })
+			if !thickerr.IsConflict(err) {
+				t.Errorf("Link() error = %v, want a conflict", err)
+			}
+			if !thickerr.IsShown(err) {
+				t.Error("Link() error isn't marked as shown, so it would be printed again")
+			}
+
+			var resp map[string]interface{}
+			if err := json.Unmarshal([]byte(out), &resp); err != nil {
+				t.Fatalf("output isn't JSON: %v\n%s", err, out)
+			}
+			if resp["success"] != false || resp["error"] != tt.want {
+				t.Errorf("response = %v, want success false and error %q", resp, tt.want)
+			}
+		})
+	}
+}
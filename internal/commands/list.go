@@ -2,22 +2,78 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/abarth/thicket/internal/config"
 	thickerr "github.com/abarth/thicket/internal/errors"
-	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
+// ListFilters echoes the filters applied by the list command.
+type ListFilters struct {
+	Status         string   `json:"status,omitempty"`
+	Types          []string `json:"types,omitempty"`
+	Labels         []string `json:"labels,omitempty"`
+	LabelMatch     string   `json:"label_match,omitempty"`
+	ExcludeLabels  []string `json:"exclude_labels,omitempty"`
+	Assignee       string   `json:"assignee,omitempty"`
+	Unassigned     bool     `json:"unassigned,omitempty"`
+	Watching       string   `json:"watching,omitempty"`
+	Ready          bool     `json:"ready,omitempty"`
+	Blocked        bool     `json:"blocked,omitempty"`
+	IncludeDeleted bool     `json:"include_deleted,omitempty"`
+	Stale          string   `json:"stale,omitempty"`
+	ModifiedSince  string   `json:"modified_since,omitempty"`
+}
+
+// ListEnvelope is the JSON output of list --envelope.
+type ListEnvelope struct {
+	Count   int           `json:"count"`
+	Filters ListFilters   `json:"filters"`
+	Tickets []*TicketJSON `json:"tickets"`
+}
+
 // List displays tickets.
 func List(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("list")
-	statusFilter := fs.String("status", "", "Filter by status (open, closed)")
-	labelFilter := fs.String("label", "", "Filter by label")
+	ref := addRefFlag(fs)
+	statusFilter := fs.String("status", "", "Filter by status (open, closed, icebox, deleted, or all; default: default_list_status from config.json)")
+	typeFilter := fs.String("type", "", "Filter by type; separate several types with commas (e.g., bug,cleanup)")
+	var labelFilters labelSlice
+	fs.Var(&labelFilters, "label", "Filter by label (can be specified multiple times)")
+	labelMatch := fs.String("label-match", labelMatchAny, "With several --label flags, match tickets with any or all of them")
+	var excludeLabels labelSlice
+	fs.Var(&excludeLabels, "exclude-label", "Hide tickets with this label (can be specified multiple times)")
+	assigneeFilter := fs.String("assignee", "", "Filter by assignee (\"me\" for yourself)")
+	unassigned := fs.Bool("unassigned", false, "Only show tickets with no assignee")
+	watching := fs.Bool("watching", false, "Only show tickets you watch")
+	readyOnly := fs.Bool("ready", false, "Only show open tickets that are not blocked")
+	blockedOnly := fs.Bool("blocked", false, "Only show open tickets blocked by another open ticket")
+	blockedLast := fs.Bool("blocked-last", false, "Sort blocked tickets after unblocked ones, keeping priority order within each")
+	includeDeleted := fs.Bool("include-deleted", false, "Include deleted tickets")
+	staleFor := fs.String("stale", "", "Only show open tickets not updated for this long (e.g., 30d, 2w), oldest first")
+	modifiedSince := fs.String("modified-since", "", "Only show tickets updated at or after this time (e.g., 2026-01-25T10:00:00Z or 2026-01-25), least recently updated first")
+	priorityLabels := fs.Bool("priority-labels", false, "Show priority labels (e.g., High) next to priority numbers")
+	noHeader := fs.Bool("no-header", false, "Omit the header and separator rows from the table")
+	oneline := fs.Bool("oneline", false, "Print only the ID and title of each ticket, one per line")
+	tsv := fs.Bool("tsv", false, "Print tab-separated rows (id, priority, status, title) with no header or truncation")
+	noTruncate := fs.Bool("no-truncate", false, "Show full titles in the table instead of cutting them at 50 characters")
+	idsOnly := fs.Bool("ids-only", false, "Print only the ID of each ticket, one per line")
+	porcelain := fs.Bool("porcelain", false, "Print one key=value record per ticket in a format that is stable across versions")
+	fieldList := fs.String("fields", "", "Comma-separated ticket fields to include in --json output (e.g., id,title,status)")
+	groupBy := fs.String("group-by", "", "Group tickets by status, type, assignee, or priority")
+	envelope := fs.Bool("envelope", false, "Wrap --json output in an object with the count and applied filters")
+	jsonCompact := fs.Bool("json-compact", false, "Print newline-delimited JSON: one compact ticket object per line")
+	gzipOutput := fs.Bool("gzip", false, "Gzip the --json or --json-compact output, for large transfers over a pipe")
+	canonical := fs.Bool("canonical", false, "Make --json output canonical for diffing: sorted by ID, sorted labels, UTC times")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: thicket list [--status <STATUS>] [--label <LABEL>] [--json] [--data-dir <DIR>]")
-		fmt.Fprintln(os.Stderr, "\nList tickets, ordered by priority.")
+		fmt.Fprintln(os.Stderr, "Usage: thicket list [--status <STATUS>] [--type <TYPES>] [--label <LABEL>]... [--label-match any|all] [--exclude-label <LABEL>]... [--assignee <NAME> | --unassigned] [--watching] [--ready | --blocked | --stale <AGE> | --modified-since <TIME>] [--blocked-last] [--include-deleted] [--group-by <FIELD>] [--priority-labels] [--no-header] [--no-truncate] [--oneline | --ids-only | --tsv | --porcelain] [--json [--envelope] | --json-compact] [--canonical] [--fields <FIELDS>] [--gzip] [--ref <REV>] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nList tickets, ordered by priority. 'thicket ls' is an alias with the same flags.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
 	}
@@ -28,48 +84,293 @@ func List(args []string) error {
 
 	handleGlobalFlags(*dataDir)
 
+	if *readyOnly && *blockedOnly {
+		return thickerr.WithHint("Cannot combine --ready and --blocked", "Use one of --ready or --blocked")
+	}
+
+	types, err := parseTypes(*typeFilter)
+	if err != nil {
+		return err
+	}
+
+	var staleBefore time.Time
+	if *staleFor != "" {
+		if *readyOnly || *blockedOnly {
+			return thickerr.WithHint("Cannot combine --stale with --ready or --blocked", "Use only one of --ready, --blocked, or --stale")
+		}
+		age, err := parseAge(*staleFor)
+		if err != nil {
+			return err
+		}
+		staleBefore = time.Now().Add(-age)
+	}
+
+	var since time.Time
+	if *modifiedSince != "" {
+		if *readyOnly || *blockedOnly || *staleFor != "" {
+			return thickerr.WithHint("Cannot combine --modified-since with --ready, --blocked, or --stale", "Use only one of --ready, --blocked, --stale, or --modified-since")
+		}
+		if *includeDeleted {
+			return thickerr.WithHint("Cannot combine --modified-since and --include-deleted", "Use --status deleted to list recently deleted tickets")
+		}
+		if since, err = parseSince(*modifiedSince); err != nil {
+			return err
+		}
+	}
+
+	if *jsonCompact {
+		if *envelope || *groupBy != "" {
+			return thickerr.WithHint("--json-compact cannot be combined with --envelope or --group-by", "Use --json for an envelope or groups")
+		}
+		*jsonOutput = true
+	}
+
+	fields, err := parseJSONFields(*fieldList, *jsonOutput)
+	if err != nil {
+		return err
+	}
+
+	if *groupBy != "" {
+		if err := validateGroupBy(*groupBy); err != nil {
+			return err
+		}
+	}
+
+	if *tsv {
+		if *jsonOutput {
+			return thickerr.WithHint("Cannot combine --tsv and --json", "Use one of --tsv or --json")
+		}
+		if *groupBy != "" {
+			return thickerr.New("Cannot combine --tsv and --group-by")
+		}
+	}
+
+	if *porcelain && (*jsonOutput || *tsv || *groupBy != "") {
+		return thickerr.WithHint("--porcelain cannot be combined with --json, --tsv, or --group-by", "--porcelain is already a machine-readable format")
+	}
+
+	if *oneline && (*jsonOutput || *tsv || *porcelain || *groupBy != "") {
+		return thickerr.WithHint("--oneline cannot be combined with --json, --tsv, --porcelain, or --group-by", "Use --oneline on its own for a compact list")
+	}
+
+	if *idsOnly && (*jsonOutput || *tsv || *porcelain || *oneline || *groupBy != "") {
+		return thickerr.WithHint("--ids-only cannot be combined with --json, --tsv, --porcelain, --oneline, or --group-by", "Use --ids-only on its own to feed IDs to other commands")
+	}
+
+	if *gzipOutput && !*jsonOutput {
+		return thickerr.WithHint("--gzip requires --json or --json-compact", "Add --json to get machine-readable output")
+	}
+
+	if *canonical && !*jsonOutput {
+		return thickerr.WithHint("--canonical requires --json", "Add --json to get machine-readable output")
+	}
+
+	if *envelope {
+		if !*jsonOutput {
+			return thickerr.WithHint("--envelope requires --json", "Add --json to get machine-readable output")
+		}
+		if *groupBy != "" {
+			return thickerr.New("Cannot combine --envelope and --group-by")
+		}
+	}
+
+	assignee, err := resolveAssignee(*assigneeFilter)
+	if err != nil {
+		return err
+	}
+	if *unassigned && assignee != "" {
+		return thickerr.WithHint("Cannot combine --assignee and --unassigned", "Use one of --assignee or --unassigned")
+	}
+
+	var watcher string
+	if *watching {
+		if watcher, err = resolveAssignee("me"); err != nil {
+			return err
+		}
+	}
+
 	root, err := config.FindRoot()
 	if err != nil {
 		return wrapConfigError(err)
 	}
 
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
 	paths := config.GetPaths(root)
-	store, err := storage.Open(paths)
+	store, closeStore, err := openStore(paths, *ref)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer closeStore()
 
+	// Without --status, list the project's default status, unless asked to
+	// include deleted tickets. "all" turns the filter off.
+	statusName := *statusFilter
+	if statusName == "" && !*includeDeleted {
+		statusName = cfg.DefaultListStatus
+	}
+	if statusName == "all" {
+		statusName = ""
+	}
 	var status *ticket.Status
-	if *statusFilter != "" {
-		if *statusFilter == "ready" {
+	if statusName != "" {
+		if statusName == "ready" {
 			return thickerr.StatusReadySuggestion()
 		}
-		s := ticket.Status(*statusFilter)
+		s := ticket.Status(statusName)
 		if err := ticket.ValidateStatus(s); err != nil {
-			return thickerr.InvalidStatus(*statusFilter)
+			if *statusFilter == "" {
+				return thickerr.WithHint(
+					fmt.Sprintf("Invalid default_list_status in config.json: %s", statusName),
+					"Valid values are: open, closed, icebox, deleted, all",
+				)
+			}
+			return thickerr.InvalidStatus(statusName)
 		}
 		status = &s
 	}
 
-	var tickets []*ticket.Ticket
-	if *labelFilter != "" {
-		if err := ticket.ValidateLabel(*labelFilter); err != nil {
+	if *labelMatch != labelMatchAny && *labelMatch != labelMatchAll {
+		return thickerr.WithHint(
+			fmt.Sprintf("Invalid label match: %s", *labelMatch),
+			"Valid values are: any, all",
+		)
+	}
+	matchAll := *labelMatch == labelMatchAll
+
+	for _, l := range append(slices.Clone(labelFilters), excludeLabels...) {
+		if err := ticket.ValidateLabel(l); err != nil {
 			return thickerr.WithHint(err.Error(), "Labels must be 1-30 alphanumeric characters, hyphens, or underscores")
 		}
-		tickets, err = store.ListByLabel(*labelFilter, status)
-	} else {
+	}
+
+	var tickets []*ticket.Ticket
+	switch {
+	case *staleFor != "":
+		tickets, err = store.ListStale(staleBefore)
+		tickets = filterByWatcher(withoutLabels(filterTickets(tickets, status, labelFilters, matchAll), excludeLabels), watcher)
+	case *modifiedSince != "":
+		tickets, err = store.ListUpdatedSince(since, status)
+		tickets = filterByWatcher(withoutLabels(filterTickets(tickets, nil, labelFilters, matchAll), excludeLabels), watcher)
+	case *readyOnly || *blockedOnly:
+		if *readyOnly {
+			tickets, err = store.ListReady()
+		} else {
+			tickets, err = store.ListBlocked()
+		}
+		tickets = filterByWatcher(withoutLabels(filterTickets(tickets, status, labelFilters, matchAll), excludeLabels), watcher)
+	case *includeDeleted && status == nil:
+		tickets, err = store.ListAll()
+		tickets = filterByWatcher(withoutLabels(filterTickets(tickets, nil, labelFilters, matchAll), excludeLabels), watcher)
+	case watcher != "":
+		tickets, err = store.ListByWatcher(watcher, status)
+		tickets = withoutLabels(filterTickets(tickets, nil, labelFilters, matchAll), excludeLabels)
+	case *unassigned:
+		tickets, err = store.ListUnassigned(status)
+		tickets = withoutLabels(filterTickets(tickets, nil, labelFilters, matchAll), excludeLabels)
+	case len(types) > 0:
+		tickets, err = store.ListByTypes(types, status)
+		tickets = withoutLabels(filterTickets(tickets, nil, labelFilters, matchAll), excludeLabels)
+	case len(excludeLabels) > 0:
+		tickets, err = store.ListWithLabels(status, labelFilters, matchAll, excludeLabels)
+	case len(labelFilters) > 0:
+		tickets, err = store.ListByLabels(labelFilters, matchAll, status)
+	default:
 		tickets, err = store.List(status)
 	}
 	if err != nil {
 		return err
 	}
 
+	if assignee != "" || *unassigned {
+		tickets = filterByAssignee(tickets, assignee)
+	}
+	if len(types) > 0 {
+		tickets = filterByTypes(tickets, types)
+	}
+
+	if *blockedLast {
+		blocked, err := store.BlockedTicketIDs()
+		if err != nil {
+			return err
+		}
+		tickets = sortBlockedLast(tickets, blocked)
+	}
+
 	if *jsonOutput {
+		emit := printJSON
+		if *gzipOutput {
+			emit = printGzipJSON
+		}
+		if *canonical {
+			canonicalizeTickets(tickets)
+		}
+		if *groupBy != "" {
+			grouped := make(map[string][]*TicketJSON)
+			for _, g := range groupTickets(tickets, *groupBy) {
+				out := newTicketsJSON(g.Tickets, cfg)
+				selectFields(out, fields)
+				grouped[g.Key] = out
+			}
+			return emit(grouped)
+		}
 		if tickets == nil {
 			tickets = []*ticket.Ticket{}
 		}
-		return printJSON(tickets)
+		out := newTicketsJSON(tickets, cfg)
+		selectFields(out, fields)
+		if *jsonCompact {
+			write := func(w io.Writer) error { return writeTicketLines(w, out) }
+			if *gzipOutput {
+				return printGzip(write)
+			}
+			return write(os.Stdout)
+		}
+		if *envelope {
+			return emit(ListEnvelope{
+				Count: len(out),
+				Filters: ListFilters{
+					Labels:         labelFilters,
+					LabelMatch:     labelMatchFilter(labelFilters, *labelMatch),
+					Status:         statusName,
+					Types:          typeNames(types),
+					ExcludeLabels:  excludeLabels,
+					Assignee:       assignee,
+					Unassigned:     *unassigned,
+					Watching:       watcher,
+					Ready:          *readyOnly,
+					Blocked:        *blockedOnly,
+					IncludeDeleted: *includeDeleted,
+					Stale:          *staleFor,
+					ModifiedSince:  *modifiedSince,
+				},
+				Tickets: out,
+			})
+		}
+		return emit(out)
+	}
+
+	if *tsv {
+		printTicketTSV(os.Stdout, tickets)
+		return nil
+	}
+
+	if *oneline {
+		printTicketOneline(os.Stdout, tickets)
+		return nil
+	}
+
+	if *idsOnly {
+		printTicketIDs(os.Stdout, tickets)
+		return nil
+	}
+
+	if *porcelain {
+		printTicketsPorcelain(os.Stdout, tickets)
+		return nil
 	}
 
 	if len(tickets) == 0 {
@@ -77,6 +378,179 @@ func List(args []string) error {
 		return nil
 	}
 
-	printTicketTable(os.Stdout, tickets)
+	opts := displayOptions{Config: cfg, PriorityLabels: *priorityLabels, NoHeader: *noHeader, NoTruncate: *noTruncate}
+	if *groupBy != "" {
+		printTicketGroups(os.Stdout, groupTickets(tickets, *groupBy), *groupBy, opts)
+		return nil
+	}
+	printTicketTable(os.Stdout, tickets, opts)
 	return nil
 }
+
+// Values for list --label-match.
+const (
+	labelMatchAny = "any"
+	labelMatchAll = "all"
+)
+
+// labelMatchFilter returns how labels were matched, for echoing in the list
+// envelope. Matching only matters with more than one label.
+func labelMatchFilter(labels []string, match string) string {
+	if len(labels) < 2 {
+		return ""
+	}
+	return match
+}
+
+// filterTickets keeps the tickets matching the optional status and labels.
+// With matchAll a ticket needs every label; otherwise any one of them will do.
+func filterTickets(tickets []*ticket.Ticket, status *ticket.Status, labels []string, matchAll bool) []*ticket.Ticket {
+	var filtered []*ticket.Ticket
+	for _, t := range tickets {
+		if status != nil && t.Status != *status {
+			continue
+		}
+		if len(labels) > 0 && !hasLabels(t, labels, matchAll) {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// hasLabels reports whether t has all of labels, or any of them if matchAll
+// is false.
+func hasLabels(t *ticket.Ticket, labels []string, matchAll bool) bool {
+	has := func(l string) bool { return slices.Contains(t.Labels, l) }
+	if matchAll {
+		for _, l := range labels {
+			if !has(l) {
+				return false
+			}
+		}
+		return true
+	}
+	return slices.ContainsFunc(labels, has)
+}
+
+// filterByAssignee keeps the tickets assigned to assignee, or the unassigned
+// tickets if assignee is empty.
+func filterByAssignee(tickets []*ticket.Ticket, assignee string) []*ticket.Ticket {
+	var filtered []*ticket.Ticket
+	for _, t := range tickets {
+		if t.Assignee == assignee {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// parseTypes parses a comma-separated list of ticket types, dropping
+// repeats. An empty list means every type.
+func parseTypes(s string) ([]ticket.Type, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var types []ticket.Type
+	for _, name := range strings.Split(s, ",") {
+		t := ticket.Type(strings.TrimSpace(name))
+		if t == "" || ticket.ValidateType(t) != nil {
+			return nil, thickerr.InvalidType(string(t))
+		}
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// typeNames returns the names of types.
+func typeNames(types []ticket.Type) []string {
+	var names []string
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+// filterByTypes keeps the tickets with any of types.
+func filterByTypes(tickets []*ticket.Ticket, types []ticket.Type) []*ticket.Ticket {
+	var filtered []*ticket.Ticket
+	for _, t := range tickets {
+		if slices.Contains(types, t.Type) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// sortBlockedLast moves the tickets in blocked after the others, keeping the
+// order of the tickets within each group.
+func sortBlockedLast(tickets []*ticket.Ticket, blocked map[string]bool) []*ticket.Ticket {
+	slices.SortStableFunc(tickets, func(a, b *ticket.Ticket) int {
+		switch {
+		case blocked[a.ID] == blocked[b.ID]:
+			return 0
+		case blocked[a.ID]:
+			return 1
+		default:
+			return -1
+		}
+	})
+	return tickets
+}
+
+// filterByWatcher keeps the tickets that watcher watches. An empty watcher
+// keeps every ticket.
+func filterByWatcher(tickets []*ticket.Ticket, watcher string) []*ticket.Ticket {
+	if watcher == "" {
+		return tickets
+	}
+	var filtered []*ticket.Ticket
+	for _, t := range tickets {
+		if slices.Contains(t.Watchers, watcher) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// parseAge parses a duration such as 30d or 2w. Besides days (d) and weeks
+// (w), it accepts anything time.ParseDuration does, such as 12h.
+func parseAge(s string) (time.Duration, error) {
+	invalid := thickerr.WithHint(
+		fmt.Sprintf("Invalid duration: %s", s),
+		"Use a number followed by d (days) or w (weeks), e.g., 30d or 2w",
+	)
+
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return 0, invalid
+		}
+		return d, nil
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, invalid
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// withoutLabels drops the tickets that carry any of labels.
+func withoutLabels(tickets []*ticket.Ticket, labels []string) []*ticket.Ticket {
+	var filtered []*ticket.Ticket
+	for _, t := range tickets {
+		if !slices.ContainsFunc(t.Labels, func(l string) bool { return slices.Contains(labels, l) }) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
@@ -1,11 +1,18 @@
 package commands
 
 import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
 )
 
 func TestList(t *testing.T) {
@@ -89,3 +96,1145 @@ func TestList_Empty(t *testing.T) {
 		t.Fatalf("List() error = %v", err)
 	}
 }
+
+func TestList_PriorityLabels(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	cfgData := []byte(`{"project_code": "TH", "priority_labels": {"1": "Urgent"}}`)
+	if err := os.WriteFile(paths.Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	Add([]string{"--title", "Important", "--priority", "1"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--priority-labels"})
+	})
+	if err != nil {
+		t.Fatalf("List(--priority-labels) error = %v", err)
+	}
+	if !strings.Contains(output, "1 (Urgent)") {
+		t.Errorf("List output should contain mapped priority label, got: %s", output)
+	}
+}
+
+// setupBlockedTickets adds a blocker, a labeled ticket it blocks, and an
+// independent labeled ticket.
+func setupBlockedTickets(t *testing.T, dir string) {
+	t.Helper()
+
+	Add([]string{"--title", "Blocker", "--priority", "1"})
+	Add([]string{"--title", "Blocked", "--priority", "2", "--label", "backend"})
+	Add([]string{"--title", "Independent", "--priority", "3", "--label", "backend"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	var blockerID, blockedID string
+	for _, tk := range tickets {
+		switch tk.Title {
+		case "Blocker":
+			blockerID = tk.ID
+		case "Blocked":
+			blockedID = tk.ID
+		}
+	}
+
+	if err := Link([]string{"--blocked-by", blockerID, blockedID}); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+}
+
+func listTitles(t *testing.T, args ...string) []string {
+	t.Helper()
+
+	output, err := captureStdout(t, func() error {
+		return List(append(args, "--json"))
+	})
+	if err != nil {
+		t.Fatalf("List(%v) error = %v", args, err)
+	}
+
+	var tickets []*ticket.Ticket
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+
+	var titles []string
+	for _, tk := range tickets {
+		titles = append(titles, tk.Title)
+	}
+	return titles
+}
+
+func TestList_Ready(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	setupBlockedTickets(t, dir)
+
+	titles := listTitles(t, "--ready")
+	if !slices.Equal(titles, []string{"Blocker", "Independent"}) {
+		t.Errorf("List(--ready) titles = %v, want [Blocker Independent]", titles)
+	}
+
+	titles = listTitles(t, "--ready", "--label", "backend")
+	if !slices.Equal(titles, []string{"Independent"}) {
+		t.Errorf("List(--ready --label backend) titles = %v, want [Independent]", titles)
+	}
+}
+
+func TestList_Blocked(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	setupBlockedTickets(t, dir)
+
+	titles := listTitles(t, "--blocked")
+	if !slices.Equal(titles, []string{"Blocked"}) {
+		t.Errorf("List(--blocked) titles = %v, want [Blocked]", titles)
+	}
+
+	titles = listTitles(t, "--blocked", "--label", "frontend")
+	if len(titles) != 0 {
+		t.Errorf("List(--blocked --label frontend) titles = %v, want none", titles)
+	}
+}
+
+func TestList_ReadyAndBlocked(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	err := List([]string{"--ready", "--blocked"})
+	if err == nil {
+		t.Error("List() expected error when combining --ready and --blocked")
+	}
+}
+
+func TestList_Verbose(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+	defer storage.SetVerbose(nil)
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	stderr, err := captureStderr(t, func() error {
+		return List([]string{"--verbose"})
+	})
+	if err != nil {
+		t.Fatalf("List(--verbose) error = %v", err)
+	}
+	if !strings.Contains(stderr, "opened store in") {
+		t.Errorf("List(--verbose) should print timing to stderr, got: %q", stderr)
+	}
+}
+
+func TestList_JSONSeverity(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Outage", "--priority", "0"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("List(--json) error = %v", err)
+	}
+
+	var tickets []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(tickets) != 1 {
+		t.Fatalf("Expected 1 ticket, got %d", len(tickets))
+	}
+	if tickets[0]["severity"] != "critical" {
+		t.Errorf("severity = %v, want critical", tickets[0]["severity"])
+	}
+	if tickets[0]["priority"] != float64(0) {
+		t.Errorf("priority = %v, want 0", tickets[0]["priority"])
+	}
+
+	// Severity is computed for output only and never stored.
+	data, err := os.ReadFile(config.GetPaths(dir).Tickets)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), "severity") {
+		t.Error("tickets.jsonl should not contain severity")
+	}
+}
+
+func TestList_Fields(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "First", "--description", "Long text"})
+	Add([]string{"--title", "Second"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--json", "--fields", "id,title,severity"})
+	})
+	if err != nil {
+		t.Fatalf("List(--fields) error = %v", err)
+	}
+
+	var tickets []map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(tickets) != 2 {
+		t.Fatalf("Expected 2 tickets, got %d", len(tickets))
+	}
+	for _, tk := range tickets {
+		if len(tk) != 3 {
+			t.Errorf("ticket has keys %v, want only id, title, severity", tk)
+		}
+		for _, key := range []string{"id", "title", "severity"} {
+			if _, ok := tk[key]; !ok {
+				t.Errorf("ticket %v is missing %q", tk, key)
+			}
+		}
+	}
+}
+
+func TestList_FieldsUnset(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "No estimate"})
+
+	for _, args := range [][]string{
+		{"--json", "--fields", "id,estimate,close_reason,watchers"},
+		{"--json-compact", "--fields", "id,estimate,close_reason,watchers"},
+	} {
+		output, err := captureStdout(t, func() error {
+			return List(args)
+		})
+		if err != nil {
+			t.Fatalf("List(%v) error = %v", args, err)
+		}
+		var tk map[string]interface{}
+		data := strings.TrimSpace(output)
+		if args[0] == "--json" {
+			var tickets []map[string]interface{}
+			if err := json.Unmarshal([]byte(data), &tickets); err != nil || len(tickets) != 1 {
+				t.Fatalf("List(%v) = %s, want one ticket (error %v)", args, output, err)
+			}
+			tk = tickets[0]
+		} else if err := json.Unmarshal([]byte(data), &tk); err != nil {
+			t.Fatalf("List(%v) = %s, error = %v", args, output, err)
+		}
+		for _, key := range []string{"estimate", "close_reason", "watchers"} {
+			if v, ok := tk[key]; !ok || v != nil {
+				t.Errorf("List(%v) %s = %v (present %v), want null", args, key, v, ok)
+			}
+		}
+	}
+}
+
+func TestList_FieldsErrors(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	err := List([]string{"--json", "--fields", "id,colour"})
+	if err == nil || !strings.Contains(err.Error(), "Unknown field: colour") {
+		t.Errorf("List(--fields id,colour) error = %v, want unknown field error", err)
+	}
+
+	err = List([]string{"--fields", "id"})
+	if err == nil || !strings.Contains(err.Error(), "--fields requires --json") {
+		t.Errorf("List(--fields without --json) error = %v, want --json required error", err)
+	}
+}
+
+func TestList_GroupBy(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Crash on save", "--type", "bug", "--priority", "0"})
+	Add([]string{"--title", "Dark mode", "--type", "feature", "--priority", "1"})
+	Add([]string{"--title", "Typo in help", "--type", "bug", "--priority", "2"})
+	Add([]string{"--title", "Untyped"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--group-by", "type"})
+	})
+	if err != nil {
+		t.Fatalf("List(--group-by type) error = %v", err)
+	}
+
+	bugs := strings.Index(output, "type: bug (2)")
+	features := strings.Index(output, "type: feature (1)")
+	none := strings.Index(output, "type: none (1)")
+	if bugs < 0 || features < 0 || none < 0 {
+		t.Fatalf("List(--group-by type) missing section headers:\n%s", output)
+	}
+	if !(bugs < features && features < none) {
+		t.Errorf("sections should be sorted by type:\n%s", output)
+	}
+	bugSection := output[bugs:features]
+	if !strings.Contains(bugSection, "Crash on save") || !strings.Contains(bugSection, "Typo in help") || strings.Contains(bugSection, "Dark mode") {
+		t.Errorf("bug section has the wrong tickets:\n%s", bugSection)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return List([]string{"--group-by", "type", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("List(--group-by type --json) error = %v", err)
+	}
+	var groups map[string][]map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &groups); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(groups["bug"]) != 2 || len(groups["feature"]) != 1 || len(groups["none"]) != 1 {
+		t.Errorf("groups = %v, want 2 bugs, 1 feature, 1 untyped", groups)
+	}
+	if groups["bug"][0]["title"] != "Crash on save" {
+		t.Errorf("bug group should keep priority order, got %v", groups["bug"])
+	}
+}
+
+func TestList_GroupByInvalid(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	err := List([]string{"--group-by", "label"})
+	if err == nil || !strings.Contains(err.Error(), "Invalid group: label") {
+		t.Errorf("List(--group-by label) error = %v, want invalid group error", err)
+	}
+}
+
+func TestList_AssigneeMe(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Alice's", "--assignee", "Alice"})
+	Add([]string{"--title", "Bob's", "--assignee", "Bob"})
+	Add([]string{"--title", "Nobody's"})
+
+	for _, me := range []string{"me", "@me", "Alice"} {
+		titles := listTitles(t, "--assignee", me)
+		if len(titles) != 1 || titles[0] != "Alice's" {
+			t.Errorf("List(--assignee %s) = %v, want [Alice's]", me, titles)
+		}
+	}
+}
+
+func TestList_Envelope(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Open one"})
+	Add([]string{"--title", "Open two"})
+	Add([]string{"--title", "Closed"})
+	Close([]string{firstTicketID(t, dir)})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--status", "open", "--json", "--envelope"})
+	})
+	if err != nil {
+		t.Fatalf("List(--envelope) error = %v", err)
+	}
+
+	var envelope struct {
+		Count   int              `json:"count"`
+		Filters map[string]any   `json:"filters"`
+		Tickets []*ticket.Ticket `json:"tickets"`
+	}
+	if err := json.Unmarshal([]byte(output), &envelope); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if envelope.Count != 2 || len(envelope.Tickets) != 2 {
+		t.Errorf("count = %d with %d tickets, want 2", envelope.Count, len(envelope.Tickets))
+	}
+	if envelope.Filters["status"] != "open" {
+		t.Errorf("filters = %v, want status open", envelope.Filters)
+	}
+	if len(envelope.Filters) != 1 {
+		t.Errorf("filters = %v, want only the applied status filter", envelope.Filters)
+	}
+}
+
+func TestList_EnvelopeErrors(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := List([]string{"--envelope"}); err == nil {
+		t.Error("List(--envelope) without --json expected error")
+	}
+	if err := List([]string{"--json", "--envelope", "--group-by", "status"}); err == nil {
+		t.Error("List(--envelope --group-by) expected error")
+	}
+}
+
+func TestList_Stale(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Fresh"})
+	Add([]string{"--title", "Month old", "--priority", "0"})
+	Add([]string{"--title", "Quarter old", "--priority", "1"})
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	ages := map[string]time.Duration{
+		"Month old":   35 * 24 * time.Hour,
+		"Quarter old": 90 * 24 * time.Hour,
+	}
+	for _, tk := range tickets {
+		if age, ok := ages[tk.Title]; ok {
+			tk.Updated = time.Now().UTC().Add(-age)
+			if err := store.Update(tk); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		}
+	}
+	store.Close()
+
+	got := listTitles(t, "--stale", "30d")
+	want := []string{"Quarter old", "Month old"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("list --stale 30d = %v, want %v", got, want)
+	}
+
+	got = listTitles(t, "--stale", "8w")
+	if strings.Join(got, ",") != "Quarter old" {
+		t.Errorf("list --stale 8w = %v, want [Quarter old]", got)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"0d", 0, true},
+		{"-1w", 0, true},
+		{"d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAge(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestList_ModifiedSince(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Last week"})
+	Add([]string{"--title", "Yesterday"})
+	Add([]string{"--title", "Closed yesterday"})
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	now := time.Now().UTC()
+	updated := map[string]time.Time{
+		"Last week":        now.Add(-7 * 24 * time.Hour),
+		"Yesterday":        now.Add(-24 * time.Hour),
+		"Closed yesterday": now.Add(-23 * time.Hour),
+	}
+	for _, tk := range tickets {
+		tk.Updated = updated[tk.Title]
+		if tk.Title == "Closed yesterday" {
+			tk.Status = ticket.StatusClosed
+		}
+		if err := store.Update(tk); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+	store.Close()
+
+	cutoff := now.Add(-2 * 24 * time.Hour).Format(time.RFC3339)
+	got := listTitles(t, "--modified-since", cutoff)
+	if want := []string{"Yesterday", "Closed yesterday"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("list --modified-since %s = %v, want %v", cutoff, got, want)
+	}
+
+	got = listTitles(t, "--status", "closed", "--modified-since", cutoff)
+	if want := []string{"Closed yesterday"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("list --status closed --modified-since %s = %v, want %v", cutoff, got, want)
+	}
+
+	// The cutoff is inclusive.
+	exact := updated["Yesterday"].Format(time.RFC3339Nano)
+	if got := listTitles(t, "--status", "open", "--modified-since", exact); strings.Join(got, ",") != "Yesterday" {
+		t.Errorf("list --status open --modified-since %s = %v, want [Yesterday]", exact, got)
+	}
+
+	for _, args := range [][]string{
+		{"--modified-since", "last week"},
+		{"--modified-since", cutoff, "--stale", "30d"},
+		{"--modified-since", cutoff, "--include-deleted"},
+	} {
+		if err := List(args); err == nil {
+			t.Errorf("List(%v) expected error", args)
+		}
+	}
+}
+
+func TestList_Types(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Bug", "--type", "bug", "--priority", "0"})
+	Add([]string{"--title", "Cleanup", "--type", "cleanup", "--priority", "1", "--label", "tech-debt"})
+	Add([]string{"--title", "Feature", "--type", "feature", "--priority", "1"})
+	Add([]string{"--title", "Untyped", "--priority", "1"})
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--type", "bug,cleanup"}, []string{"Bug", "Cleanup"}},
+		{[]string{"--type", "cleanup, bug,bug"}, []string{"Bug", "Cleanup"}},
+		{[]string{"--type", "feature"}, []string{"Feature"}},
+		{[]string{"--type", "bug,cleanup", "--label", "tech-debt"}, []string{"Cleanup"}},
+		{[]string{"--type", "bug,cleanup", "--unassigned"}, []string{"Bug", "Cleanup"}},
+		{[]string{"--type", "epic"}, nil},
+	}
+	for _, tt := range tests {
+		if got := listTitles(t, tt.args...); strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("list %v = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"bug,story", "bug,"} {
+		if err := List([]string{"--type", value}); err == nil {
+			t.Errorf("List(--type %q) expected error", value)
+		}
+	}
+}
+
+func TestList_StaleWithReady(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := List([]string{"--stale", "30d", "--ready"}); err == nil {
+		t.Error("List(--stale --ready) expected error")
+	}
+}
+
+func TestList_Canonical(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Low", "--priority", "3"})
+	Add([]string{"--title", "High", "--priority", "0"})
+	Add([]string{"--title", "Middle", "--priority", "1"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--json", "--canonical"})
+	})
+	if err != nil {
+		t.Fatalf("List(--canonical) error = %v", err)
+	}
+
+	var tickets []*ticket.Ticket
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if !slices.IsSortedFunc(tickets, func(a, b *ticket.Ticket) int { return strings.Compare(a.ID, b.ID) }) {
+		t.Errorf("canonical list should be sorted by ID:\n%s", output)
+	}
+
+	if err := List([]string{"--canonical"}); err == nil {
+		t.Error("List(--canonical) without --json expected error")
+	}
+}
+
+func TestList_ExcludeLabel(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	setupBlockedTickets(t, dir)
+	Add([]string{"--title", "Won't fix", "--priority", "4", "--label", "backend", "--label", "wontfix"})
+	Add([]string{"--title", "Noise", "--priority", "4", "--label", "noise"})
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--exclude-label", "wontfix"}, []string{"Blocker", "Blocked", "Independent", "Noise"}},
+		{[]string{"--exclude-label", "wontfix", "--exclude-label", "noise"}, []string{"Blocker", "Blocked", "Independent"}},
+		{[]string{"--label", "backend", "--exclude-label", "wontfix"}, []string{"Blocked", "Independent"}},
+		{[]string{"--ready", "--exclude-label", "wontfix"}, []string{"Blocker", "Independent", "Noise"}},
+	}
+	for _, tt := range tests {
+		got := listTitles(t, tt.args...)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("list %v = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestList_LabelMatch(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Both", "--priority", "1", "--label", "backend", "--label", "urgent"})
+	Add([]string{"--title", "Backend", "--priority", "2", "--label", "backend"})
+	Add([]string{"--title", "Urgent", "--priority", "3", "--label", "urgent"})
+	Add([]string{"--title", "Docs", "--priority", "4", "--label", "docs"})
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--label", "backend", "--label", "urgent"}, []string{"Both", "Backend", "Urgent"}},
+		{[]string{"--label", "backend", "--label", "urgent", "--label-match", "any"}, []string{"Both", "Backend", "Urgent"}},
+		{[]string{"--label", "backend", "--label", "urgent", "--label-match", "all"}, []string{"Both"}},
+		{[]string{"--ready", "--label", "backend", "--label", "urgent", "--label-match", "all"}, []string{"Both"}},
+		{[]string{"--ready", "--label", "backend", "--label", "urgent"}, []string{"Both", "Backend", "Urgent"}},
+	}
+	for _, tt := range tests {
+		got := listTitles(t, tt.args...)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("list %v = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+
+	if err := List([]string{"--label", "backend", "--label-match", "some"}); err == nil {
+		t.Error("List(--label-match some) expected error")
+	}
+}
+
+func TestList_NoHeader(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "First"})
+	Add([]string{"--title", "Second"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--no-header"})
+	})
+	if err != nil {
+		t.Fatalf("List(--no-header) error = %v", err)
+	}
+	if strings.Contains(output, "TITLE") || strings.Contains(output, "---") {
+		t.Errorf("List output should not contain the header, got: %s", output)
+	}
+	if lines := strings.Split(strings.TrimSpace(output), "\n"); len(lines) != 2 {
+		t.Errorf("List output should have one row per ticket, got: %q", output)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return List([]string{})
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !strings.Contains(output, "TITLE") {
+		t.Errorf("List output should contain the header by default, got: %s", output)
+	}
+}
+
+func TestList_TSV(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	longTitle := "A title that is much longer than the fifty characters the table allows"
+	Add([]string{"--title", longTitle, "--priority", "1"})
+	Add([]string{"--title", "Tab\there", "--priority", "2"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--tsv"})
+	})
+	if err != nil {
+		t.Fatalf("List(--tsv) error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("List(--tsv) printed %d lines, want 2: %q", len(lines), output)
+	}
+	for _, line := range lines {
+		if fields := strings.Split(line, "\t"); len(fields) != 4 {
+			t.Errorf("line %q has %d fields, want 4", line, len(fields))
+		}
+	}
+	fields := strings.Split(lines[0], "\t")
+	if fields[1] != "1" || fields[2] != "open" || fields[3] != longTitle {
+		t.Errorf("first row = %q, want priority 1, open, and the full title", fields)
+	}
+}
+
+func TestList_TSVErrors(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := List([]string{"--tsv", "--json"}); err == nil {
+		t.Error("List(--tsv --json) expected error")
+	}
+	if err := List([]string{"--tsv", "--group-by", "status"}); err == nil {
+		t.Error("List(--tsv --group-by) expected error")
+	}
+}
+
+func TestList_Watching(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Watched", "--label", "bug"})
+	Add([]string{"--title", "Ignored"})
+	byTitle := ticketsByTitle(t, dir)
+	if err := Update([]string{"--watch", byTitle["Watched"].ID}); err != nil {
+		t.Fatalf("Update(--watch) error = %v", err)
+	}
+
+	for _, args := range [][]string{{"--watching"}, {"--watching", "--label", "bug"}, {"--watching", "--ready"}} {
+		output, err := captureStdout(t, func() error {
+			return List(append(args, "--json"))
+		})
+		if err != nil {
+			t.Fatalf("List(%v) error = %v", args, err)
+		}
+		var tickets []TicketJSON
+		if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+			t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+		}
+		if len(tickets) != 1 || tickets[0].Title != "Watched" {
+			t.Errorf("List(%v) = %+v, want only the watched ticket", args, tickets)
+		}
+	}
+}
+
+func TestList_Unassigned(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Nobody's", "--label", "bug"})
+	Add([]string{"--title", "Alice's", "--assignee", "Alice", "--label", "bug"})
+
+	for _, args := range [][]string{{"--unassigned"}, {"--unassigned", "--label", "bug"}, {"--unassigned", "--ready"}} {
+		output, err := captureStdout(t, func() error {
+			return List(append(args, "--json"))
+		})
+		if err != nil {
+			t.Fatalf("List(%v) error = %v", args, err)
+		}
+		var tickets []TicketJSON
+		if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+			t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+		}
+		if len(tickets) != 1 || tickets[0].Title != "Nobody's" {
+			t.Errorf("List(%v) = %+v, want only the unassigned ticket", args, tickets)
+		}
+	}
+
+	if err := List([]string{"--unassigned", "--assignee", "Alice"}); err == nil {
+		t.Error("List(--unassigned --assignee) expected error")
+	}
+}
+
+func TestList_Labels(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Labeled", "--label", "bug", "--label", "backend"})
+	Add([]string{"--title", "Plain"})
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{})
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if !strings.Contains(output, "LABELS") {
+		t.Errorf("List() table should have a LABELS column:\n%s", output)
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "Labeled") && !strings.Contains(line, "backend,bug") {
+			t.Errorf("Labeled row should show its labels: %q", line)
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return List([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("List(--json) error = %v", err)
+	}
+	var tickets []TicketJSON
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	for _, tk := range tickets {
+		if tk.Title == "Labeled" && !slices.Equal(tk.Labels, []string{"backend", "bug"}) {
+			t.Errorf("Labeled ticket labels = %v, want [backend bug]", tk.Labels)
+		}
+	}
+}
+
+func TestList_BlockedLast(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Blocker", "--priority", "3"})
+	blocker := ticketsByTitle(t, dir)["Blocker"].ID
+	Add([]string{"--title", "Urgent but blocked", "--priority", "0", "--blocked-by", blocker})
+	Add([]string{"--title", "Unblocked", "--priority", "2"})
+
+	titles := func(args ...string) []string {
+		t.Helper()
+		output, err := captureStdout(t, func() error {
+			return List(append(args, "--json"))
+		})
+		if err != nil {
+			t.Fatalf("List(%v) error = %v", args, err)
+		}
+		var tickets []TicketJSON
+		if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+			t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+		}
+		var got []string
+		for _, tk := range tickets {
+			got = append(got, tk.Title)
+		}
+		return got
+	}
+
+	want := []string{"Urgent but blocked", "Unblocked", "Blocker"}
+	if got := titles(); !slices.Equal(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+	want = []string{"Unblocked", "Blocker", "Urgent but blocked"}
+	if got := titles("--blocked-last"); !slices.Equal(got, want) {
+		t.Errorf("List(--blocked-last) = %v, want %v", got, want)
+	}
+}
+
+func TestList_Gzip(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "First", "--priority", "1"})
+	Add([]string{"--title", "Second", "--priority", "2"})
+
+	plain, err := captureStdout(t, func() error {
+		return List([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("List(--json) error = %v", err)
+	}
+	compressed, err := captureStdout(t, func() error {
+		return List([]string{"--json", "--gzip"})
+	})
+	if err != nil {
+		t.Fatalf("List(--json --gzip) error = %v", err)
+	}
+
+	zr, err := gzip.NewReader(strings.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("reading gzip output: %v", err)
+	}
+	if string(data) != plain {
+		t.Errorf("decompressed output =\n%s\nwant\n%s", data, plain)
+	}
+
+	var tickets []TicketJSON
+	if err := json.Unmarshal(data, &tickets); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if len(tickets) != 2 || tickets[0].Title != "First" || tickets[1].Title != "Second" {
+		t.Errorf("decompressed tickets = %+v", tickets)
+	}
+
+	if err := List([]string{"--gzip"}); err == nil {
+		t.Error("List(--gzip) without --json expected error")
+	}
+}
+
+func TestList_JSONCompact(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "First", "--priority", "1"})
+	Add([]string{"--title", "Second", "--priority", "2"})
+
+	plain, err := captureStdout(t, func() error {
+		return List([]string{"--json-compact", "--fields", "id,title"})
+	})
+	if err != nil {
+		t.Fatalf("List(--json-compact) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(plain, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("List(--json-compact) printed %d lines, want 2:\n%s", len(lines), plain)
+	}
+	for i, want := range []string{"First", "Second"} {
+		var got map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &got); err != nil {
+			t.Fatalf("line %d: json.Unmarshal() error = %v", i, err)
+		}
+		if got["title"] != want || len(got) != 2 {
+			t.Errorf("line %d = %s, want only the id and title of %s", i, lines[i], want)
+		}
+	}
+
+	compressed, err := captureStdout(t, func() error {
+		return List([]string{"--json-compact", "--fields", "id,title", "--gzip"})
+	})
+	if err != nil {
+		t.Fatalf("List(--json-compact --gzip) error = %v", err)
+	}
+	zr, err := gzip.NewReader(strings.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("reading gzip output: %v", err)
+	}
+	if string(data) != plain {
+		t.Errorf("decompressed output =\n%s\nwant\n%s", data, plain)
+	}
+
+	for _, args := range [][]string{{"--json-compact", "--envelope"}, {"--json-compact", "--group-by", "status"}, {"--json-compact", "--tsv"}} {
+		if err := List(args); err == nil {
+			t.Errorf("List(%v) expected error", args)
+		}
+	}
+}
+
+func TestList_IDsOnly(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Low", "--priority", "3"})
+	Add([]string{"--title", "High", "--priority", "1"})
+	tickets := ticketsByTitle(t, dir)
+
+	output, err := captureStdout(t, func() error { return List([]string{"--ids-only"}) })
+	if err != nil {
+		t.Fatalf("List(--ids-only) error = %v", err)
+	}
+	if want := tickets["High"].ID + "\n" + tickets["Low"].ID + "\n"; output != want {
+		t.Errorf("List(--ids-only) = %q, want %q", output, want)
+	}
+
+	if err := List([]string{"--ids-only", "--json"}); err == nil {
+		t.Error("List(--ids-only --json) expected error")
+	}
+}
+
+func TestList_DefaultListStatus(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Open"})
+	Add([]string{"--title", "Closed"})
+	Close([]string{ticketsByTitle(t, dir)["Closed"].ID})
+
+	if got := listTitles(t); len(got) != 2 {
+		t.Errorf("List() without default_list_status = %v, want both tickets", got)
+	}
+
+	paths := config.GetPaths(dir)
+	cfgData := []byte(`{"project_code": "TH", "default_list_status": "open"}`)
+	if err := os.WriteFile(paths.Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if got := listTitles(t); len(got) != 1 || got[0] != "Open" {
+		t.Errorf("List() = %v, want only the open ticket", got)
+	}
+	if got := listTitles(t, "--status", "closed"); len(got) != 1 || got[0] != "Closed" {
+		t.Errorf("List(--status closed) = %v, want only the closed ticket", got)
+	}
+	if got := listTitles(t, "--status", "all"); len(got) != 2 {
+		t.Errorf("List(--status all) = %v, want both tickets", got)
+	}
+
+	cfgData = []byte(`{"project_code": "TH", "default_list_status": "bogus"}`)
+	if err := os.WriteFile(paths.Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := List(nil); err == nil || !strings.Contains(err.Error(), "default_list_status") {
+		t.Errorf("List() with an invalid default_list_status error = %v, want one naming the setting", err)
+	}
+}
+
+func TestList_NoTruncate(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	title := "A very long title that goes well past the fifty characters the table allows"
+	Add([]string{"--title", title})
+
+	output, err := captureStdout(t, func() error { return List(nil) })
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if strings.Contains(output, title) || !strings.Contains(output, title[:47]+"...") {
+		t.Errorf("List() should truncate the title:\n%s", output)
+	}
+
+	output, err = captureStdout(t, func() error { return List([]string{"--no-truncate"}) })
+	if err != nil {
+		t.Fatalf("List(--no-truncate) error = %v", err)
+	}
+	if !strings.Contains(output, title) {
+		t.Errorf("List(--no-truncate) output missing the full title:\n%s", output)
+	}
+}
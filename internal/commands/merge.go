@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// MergeResponse is the JSON output of the merge command.
+type MergeResponse struct {
+	SuccessResponse
+	Into string `json:"into"`
+	storage.MergeResult
+}
+
+// Merge folds a duplicate ticket into another one.
+func Merge(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("merge")
+	deleteSrc := fs.Bool("delete", false, "Delete the duplicate instead of closing it")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket merge <DUPLICATE-ID> <TICKET-ID> [--delete] [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nMove the comments and dependencies of a duplicate ticket to another ticket,")
+		fmt.Fprintln(os.Stderr, "then close the duplicate as a duplicate of that ticket.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 2 {
+		return thickerr.WithHint("Two ticket IDs are required", "Usage: thicket merge <DUPLICATE-ID> <TICKET-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	var tickets [2]*ticket.Ticket
+	for i := range tickets {
+		id, err := resolveTicketID(store, fs.Arg(i))
+		if err != nil {
+			return err
+		}
+		t, err := store.Get(id)
+		if err != nil {
+			return err
+		}
+		if t == nil {
+			return thickerr.TicketNotFound(id)
+		}
+		if t.Status == ticket.StatusDeleted {
+			return thickerr.WithHint(
+				fmt.Sprintf("Ticket %s is deleted", t.ID),
+				"Restore it with 'thicket restore' first",
+			)
+		}
+		tickets[i] = t
+	}
+	src, dst := tickets[0], tickets[1]
+	if src.ID == dst.ID {
+		return thickerr.New("A ticket cannot be merged into itself")
+	}
+
+	wasClosed := src.Status == ticket.StatusClosed
+	if *deleteSrc {
+		src.Delete()
+	} else if err := src.CloseWithReason(ticket.CloseReasonDuplicate); err != nil {
+		return err
+	}
+	src.UpdatedBy = config.ResolveIdentity()
+
+	link, err := ticket.NewDependency(src.ID, dst.ID, ticket.DependencyRelatedTo)
+	if err != nil {
+		return err
+	}
+
+	result, err := store.Merge(src, dst.ID, link)
+	if err != nil {
+		return err
+	}
+
+	if !*deleteSrc && !wasClosed {
+		runHook(root, cfg, config.HookOnClose, src)
+	}
+
+	message := fmt.Sprintf("Merged %s into %s: moved %d comments and %d dependencies", src.ID, dst.ID, result.Comments, result.Dependencies)
+
+	if *jsonOutput {
+		return printJSON(MergeResponse{
+			SuccessResponse: SuccessResponse{
+				Success: true,
+				ID:      src.ID,
+				Message: message,
+			},
+			Into:        dst.ID,
+			MergeResult: result,
+		})
+	}
+
+	fmt.Println(message)
+	if result.Dropped > 0 {
+		fmt.Printf("Dropped %d dependencies that were redundant or would have created a cycle\n", result.Dropped)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func ticketIDs(tickets []*ticket.Ticket) map[string]bool {
+	ids := make(map[string]bool)
+	for _, t := range tickets {
+		ids[t.ID] = true
+	}
+	return ids
+}
+
+func TestMerge(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, title := range []string{"Duplicate", "Original", "Blocker", "Dependent", "Middle"} {
+		Add([]string{"--title", title})
+	}
+	byTitle := ticketsByTitle(t, dir)
+	id := func(title string) string { return byTitle[title].ID }
+
+	// Duplicate is blocked by Blocker and blocks Dependent. Original is
+	// blocked by Middle, which is blocked by Duplicate: moving that last
+	// link to Original would create a cycle.
+	links := [][2]string{{"Duplicate", "Blocker"}, {"Dependent", "Duplicate"}, {"Original", "Middle"}, {"Middle", "Duplicate"}}
+	for _, l := range links {
+		if err := Link([]string{"--blocked-by", id(l[1]), id(l[0])}); err != nil {
+			t.Fatalf("Link() error = %v", err)
+		}
+	}
+	Comment([]string{id("Duplicate"), "Seen on the duplicate"})
+
+	output, err := captureStdout(t, func() error {
+		return Merge([]string{"--json", id("Duplicate"), id("Original")})
+	})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	var resp MergeResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if resp.Comments != 1 || resp.Dependencies != 2 || resp.Dropped != 1 {
+		t.Errorf("result = %+v, want 1 comment and 2 dependencies moved, 1 dropped", resp.MergeResult)
+	}
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	comments, _ := store.GetComments(id("Original"))
+	if len(comments) != 1 || comments[0].Content != "Seen on the duplicate" {
+		t.Errorf("Original comments = %v, want the moved comment", comments)
+	}
+	if comments, _ := store.GetComments(id("Duplicate")); len(comments) != 0 {
+		t.Errorf("Duplicate still has %d comments", len(comments))
+	}
+
+	blockers, _ := store.GetBlockers(id("Original"))
+	if ids := ticketIDs(blockers); !ids[id("Blocker")] || !ids[id("Middle")] || len(ids) != 2 {
+		t.Errorf("Original blockers = %v, want Blocker and Middle", ids)
+	}
+	blocking, _ := store.GetBlocking(id("Original"))
+	if ids := ticketIDs(blocking); !ids[id("Dependent")] || len(ids) != 1 {
+		t.Errorf("Original blocks %v, want only Dependent", ids)
+	}
+	if blockers, _ := store.GetBlockers(id("Middle")); len(blockers) != 0 {
+		t.Errorf("Middle blockers = %v, want none (the cycle should be dropped)", ticketIDs(blockers))
+	}
+
+	dup, _ := store.Get(id("Duplicate"))
+	if dup.Status != ticket.StatusClosed || dup.CloseReason != ticket.CloseReasonDuplicate {
+		t.Errorf("Duplicate status = %q, reason = %q, want closed as duplicate", dup.Status, dup.CloseReason)
+	}
+	deps, _ := store.GetDependenciesFrom(id("Duplicate"))
+	if len(deps) != 1 || deps[0].Type != ticket.DependencyRelatedTo || deps[0].ToTicketID != id("Original") {
+		t.Errorf("Duplicate dependencies = %v, want only related_to Original", deps)
+	}
+
+	// The cache must agree with tickets.jsonl after a rebuild.
+	if err := store.ForceRebuild(); err != nil {
+		t.Fatalf("ForceRebuild() error = %v", err)
+	}
+	if blockers, _ := store.GetBlockers(id("Original")); len(blockers) != 2 {
+		t.Errorf("after rebuild, Original has %d blockers, want 2", len(blockers))
+	}
+}
+
+func TestMerge_Delete(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Duplicate"})
+	Add([]string{"--title", "Original"})
+	byTitle := ticketsByTitle(t, dir)
+
+	if err := Merge([]string{"--delete", byTitle["Duplicate"].ID, byTitle["Original"].ID}); err != nil {
+		t.Fatalf("Merge(--delete) error = %v", err)
+	}
+
+	if status := ticketsByTitle(t, dir)["Duplicate"].Status; status != ticket.StatusDeleted {
+		t.Errorf("Duplicate status = %q, want deleted", status)
+	}
+}
+
+func TestMerge_Errors(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Only"})
+	id := firstTicketID(t, dir)
+
+	if err := Merge([]string{id}); err == nil {
+		t.Error("Merge() with one ID expected error")
+	}
+	if err := Merge([]string{id, id}); err == nil {
+		t.Error("Merge() into itself expected error")
+	}
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Move reorders a ticket relative to another ticket of the same priority.
+func Move(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("move")
+	before := fs.String("before", "", "Move the ticket just before this ticket")
+	after := fs.String("after", "", "Move the ticket just after this ticket")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket move (--before <ID> | --after <ID>) <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nReorder a ticket among the tickets of the same priority.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket move --before <ID> <TICKET-ID>")
+	}
+	if (*before == "") == (*after == "") {
+		return thickerr.WithHint("Exactly one of --before or --after is required", "Usage: thicket move --before <ID> <TICKET-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	targetArg := *before
+	if *after != "" {
+		targetArg = *after
+	}
+	targetID, err := resolveTicketID(store, targetArg)
+	if err != nil {
+		return err
+	}
+	if ticketID == targetID {
+		return thickerr.New("A ticket cannot be moved relative to itself")
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+	target, err := store.Get(targetID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return thickerr.TicketNotFound(targetID)
+	}
+
+	if t.Priority != target.Priority {
+		return thickerr.WithHint(
+			fmt.Sprintf("Tickets %s and %s have different priorities (%d and %d)", t.ID, target.ID, t.Priority, target.Priority),
+			fmt.Sprintf("Tickets are ordered by priority first. Use 'thicket update --priority %d %s' to change its priority", target.Priority, t.ID),
+		)
+	}
+
+	all, err := store.ListAll()
+	if err != nil {
+		return err
+	}
+	var peers []*ticket.Ticket
+	for _, p := range all {
+		if p.Priority == t.Priority {
+			peers = append(peers, p)
+		}
+	}
+
+	changed := reorder(peers, t.ID, target.ID, *after != "")
+	if err := store.UpdateTickets(changed); err != nil {
+		return err
+	}
+
+	position := "before"
+	if *after != "" {
+		position = "after"
+	}
+	message := fmt.Sprintf("Moved ticket %s %s %s", t.ID, position, target.ID)
+
+	if *jsonOutput {
+		return printJSON(SuccessResponse{
+			Success: true,
+			ID:      t.ID,
+			Message: message,
+		})
+	}
+
+	fmt.Println(message)
+	return nil
+}
+
+// reorder moves the ticket with ID id just before or after the ticket with
+// ID targetID, then renumbers the ranks of peers from 1, ranking any that
+// were unranked. Peers must all have the same priority. It returns the
+// tickets whose rank changed.
+func reorder(peers []*ticket.Ticket, id, targetID string, after bool) []*ticket.Ticket {
+	sort.SliceStable(peers, func(i, j int) bool {
+		if ri, rj := peers[i].Rank, peers[j].Rank; ri != rj {
+			// Unranked tickets come after ranked ones, as in the cache.
+			return rj == 0 || (ri != 0 && ri < rj)
+		}
+		return peers[i].Created.Before(peers[j].Created)
+	})
+
+	var moving *ticket.Ticket
+	ordered := make([]*ticket.Ticket, 0, len(peers))
+	for _, p := range peers {
+		if p.ID == id {
+			moving = p
+			continue
+		}
+		ordered = append(ordered, p)
+	}
+
+	var result []*ticket.Ticket
+	for _, p := range ordered {
+		if p.ID == targetID && !after {
+			result = append(result, moving)
+		}
+		result = append(result, p)
+		if p.ID == targetID && after {
+			result = append(result, moving)
+		}
+	}
+
+	var changed []*ticket.Ticket
+	for i, p := range result {
+		if p.Rank != i+1 {
+			p.Rank = i + 1
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
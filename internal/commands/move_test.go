@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+func TestMove(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Urgent", "--priority", "0"})
+	Add([]string{"--title", "First", "--priority", "1"})
+	Add([]string{"--title", "Second", "--priority", "1"})
+	Add([]string{"--title", "Third", "--priority", "1"})
+
+	ids := make(map[string]string)
+	store, _ := storage.Open(config.GetPaths(dir))
+	tickets, _ := store.List(nil)
+	store.Close()
+	for _, tk := range tickets {
+		ids[tk.Title] = tk.ID
+	}
+
+	if _, err := captureStdout(t, func() error {
+		return Move([]string{"--before", ids["First"], ids["Third"]})
+	}); err != nil {
+		t.Fatalf("Move(--before) error = %v", err)
+	}
+	want := []string{"Urgent", "Third", "First", "Second"}
+	if got := listTitles(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("after --before, titles = %v, want %v", got, want)
+	}
+
+	if _, err := captureStdout(t, func() error {
+		return Move([]string{"--after", ids["Second"], ids["Third"]})
+	}); err != nil {
+		t.Fatalf("Move(--after) error = %v", err)
+	}
+	want = []string{"Urgent", "First", "Second", "Third"}
+	if got := listTitles(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("after --after, titles = %v, want %v", got, want)
+	}
+
+	store, _ = storage.Open(config.GetPaths(dir))
+	moved, _ := store.Get(ids["Third"])
+	store.Close()
+	if moved.Priority != 1 {
+		t.Errorf("Priority = %d after move, want 1", moved.Priority)
+	}
+}
+
+func TestMove_DifferentPriority(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Urgent", "--priority", "0"})
+	urgentID := firstTicketID(t, dir)
+	Add([]string{"--title", "Later", "--priority", "3"})
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	tickets, _ := store.List(nil)
+	store.Close()
+	laterID := tickets[1].ID
+
+	err := Move([]string{"--before", urgentID, laterID})
+	if err == nil || !strings.Contains(err.Error(), "different priorities") {
+		t.Errorf("Move() error = %v, want different priorities error", err)
+	}
+
+	if err := Move([]string{laterID}); err == nil {
+		t.Error("Move() without --before or --after should fail")
+	}
+}
+
+func TestMove_UnrankedLast(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "A", "--priority", "1"})
+	Add([]string{"--title", "B", "--priority", "1"})
+	Add([]string{"--title", "Elsewhere", "--priority", "2"})
+	ids := ticketsByTitle(t, dir)
+
+	if _, err := captureStdout(t, func() error {
+		return Move([]string{"--before", ids["A"].ID, ids["B"].ID})
+	}); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+
+	// A new ticket comes after the ones that were ordered by hand.
+	Add([]string{"--title", "C", "--priority", "1"})
+	want := []string{"B", "A", "C", "Elsewhere"}
+	if got := listTitles(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("after add, titles = %v, want %v", got, want)
+	}
+
+	// A ticket that changes priority loses the rank it had among its old
+	// peers, so it comes after the ranked tickets of its new priority.
+	if err := Update([]string{"--priority", "2", ids["B"].ID}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := captureStdout(t, func() error {
+		return Move([]string{"--before", ids["Elsewhere"].ID, ids["B"].ID})
+	}); err != nil {
+		t.Fatalf("Move() error = %v", err)
+	}
+	if err := Update([]string{"--priority", "1", ids["B"].ID}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want = []string{"A", "B", "C", "Elsewhere"}
+	if got := listTitles(t); !reflect.DeepEqual(got, want) {
+		t.Errorf("after priority change, titles = %v, want %v", got, want)
+	}
+	if rank := ticketsByTitle(t, dir)["B"].Rank; rank != 0 {
+		t.Errorf("B rank = %d after priority change, want 0", rank)
+	}
+}
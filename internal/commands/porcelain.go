@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Porcelain output is a line-oriented format for scripts that, unlike the
+// table and detail views, is guaranteed not to change across versions.
+//
+// Each line is one record: its kind (ticket, link, or comment) followed by
+// tab-separated key=value fields. Keys are never renamed or removed, but new
+// keys may be added at the end of a record, so parsers should look fields
+// up by key. Backslashes, tabs, newlines, and carriage returns in values are
+// escaped as \\, \t, \n, and \r. Times are RFC 3339 in UTC.
+
+// porcelainEscaper escapes the characters that would break a porcelain line.
+var porcelainEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// porcelainField is a key=value pair in a porcelain record.
+type porcelainField struct {
+	key   string
+	value string
+}
+
+// printPorcelainRecord writes one porcelain record of the given kind.
+func printPorcelainRecord(w io.Writer, kind string, fields ...porcelainField) {
+	var b strings.Builder
+	b.WriteString(kind)
+	for _, f := range fields {
+		b.WriteByte('\t')
+		b.WriteString(f.key)
+		b.WriteByte('=')
+		b.WriteString(porcelainEscaper.Replace(f.value))
+	}
+	b.WriteByte('\n')
+	io.WriteString(w, b.String())
+}
+
+// porcelainTime formats t for porcelain output.
+func porcelainTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// porcelainAcked returns when c was acknowledged, or "" if it hasn't been.
+func porcelainAcked(c *ticket.Comment) string {
+	if !c.IsAcked() {
+		return ""
+	}
+	return porcelainTime(c.Acked)
+}
+
+// printTicketPorcelain writes a ticket record.
+func printTicketPorcelain(w io.Writer, t *ticket.Ticket) {
+	printPorcelainRecord(w, "ticket",
+		porcelainField{"id", t.ID},
+		porcelainField{"status", string(t.Status)},
+		porcelainField{"priority", strconv.Itoa(t.Priority)},
+		porcelainField{"type", string(t.Type)},
+		porcelainField{"assignee", t.Assignee},
+		porcelainField{"estimate", strconv.Itoa(t.Estimate)},
+		porcelainField{"labels", strings.Join(t.Labels, ",")},
+		porcelainField{"close_reason", string(t.CloseReason)},
+		porcelainField{"created", porcelainTime(t.Created)},
+		porcelainField{"updated", porcelainTime(t.Updated)},
+		porcelainField{"title", t.Title},
+		porcelainField{"description", t.Description},
+	)
+}
+
+// printTicketsPorcelain writes a ticket record for each ticket.
+func printTicketsPorcelain(w io.Writer, tickets []*ticket.Ticket) {
+	for _, t := range tickets {
+		printTicketPorcelain(w, t)
+	}
+}
+
+// printDetailsPorcelain writes the ticket record, then a link record for each
+// related ticket, then a comment record for each comment.
+func printDetailsPorcelain(w io.Writer, details *TicketDetails) {
+	printTicketPorcelain(w, details.Ticket)
+
+	link := func(linkType string, t *ticket.Ticket) {
+		printPorcelainRecord(w, "link",
+			porcelainField{"type", linkType},
+			porcelainField{"id", t.ID},
+			porcelainField{"status", string(t.Status)},
+		)
+	}
+	for _, t := range details.BlockedBy {
+		link("blocked_by", t)
+	}
+	for _, t := range details.Blocking {
+		link("blocks", t)
+	}
+	if details.CreatedFrom != nil {
+		link("created_from", details.CreatedFrom)
+	}
+	for _, t := range details.CreatedChildren {
+		link("created_child", t)
+	}
+	for _, g := range otherDependencyGroups(details.Dependencies) {
+		for _, l := range g.Links {
+			link(string(l.Type), l.Ticket)
+		}
+	}
+
+	for _, c := range details.Comments {
+		printPorcelainRecord(w, "comment",
+			porcelainField{"id", c.ID},
+			porcelainField{"author", c.Author},
+			porcelainField{"created", porcelainTime(c.Created)},
+			porcelainField{"content", c.Content},
+			porcelainField{"acked_by", c.AckedBy},
+			porcelainField{"acked", porcelainAcked(c)},
+		)
+	}
+}
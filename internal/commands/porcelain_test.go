@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestPrintTicketPorcelain(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 30, 0, 0, time.FixedZone("PST", -8*60*60))
+	tk := &ticket.Ticket{
+		ID:          "TH-abc123",
+		Title:       "Fix\tthe parser",
+		Description: "Line one\nLine two with a \\ backslash",
+		Type:        ticket.TypeBug,
+		Status:      ticket.StatusOpen,
+		Priority:    1,
+		Labels:      []string{"backend", "urgent"},
+		Assignee:    "alice",
+		Estimate:    3,
+		Created:     created,
+		Updated:     created.Add(time.Hour),
+	}
+
+	var buf bytes.Buffer
+	printTicketPorcelain(&buf, tk)
+
+	want := "ticket\tid=TH-abc123\tstatus=open\tpriority=1\ttype=bug\tassignee=alice\testimate=3\tlabels=backend,urgent\tclose_reason=" +
+		"\tcreated=2025-03-01T17:30:00Z\tupdated=2025-03-01T18:30:00Z\ttitle=Fix\\tthe parser" +
+		"\tdescription=Line one\\nLine two with a \\\\ backslash\n"
+	if buf.String() != want {
+		t.Errorf("printTicketPorcelain() =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestList_Porcelain(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "First", "--priority", "1", "--label", "ui"})
+	Add([]string{"--title", "Second", "--priority", "2"})
+	byTitle := ticketsByTitle(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return List([]string{"--porcelain"})
+	})
+	if err != nil {
+		t.Fatalf("List(--porcelain) error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("List(--porcelain) printed %d lines, want 2:\n%s", len(lines), output)
+	}
+	if !strings.HasPrefix(lines[0], "ticket\tid="+byTitle["First"].ID+"\tstatus=open\tpriority=1\t") {
+		t.Errorf("first line = %q", lines[0])
+	}
+	if !strings.Contains(lines[0], "\tlabels=ui\t") || !strings.Contains(lines[0], "\ttitle=First\t") {
+		t.Errorf("first line is missing labels or title: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "ticket\tid="+byTitle["Second"].ID+"\t") {
+		t.Errorf("second line = %q", lines[1])
+	}
+
+	if err := List([]string{"--porcelain", "--json"}); err == nil {
+		t.Error("List(--porcelain --json) expected error")
+	}
+}
+
+func TestShow_Porcelain(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Blocker"})
+	blocker := ticketsByTitle(t, dir)["Blocker"].ID
+	Add([]string{"--title", "Blocked", "--blocked-by", blocker})
+	blocked := ticketsByTitle(t, dir)["Blocked"].ID
+	Comment([]string{blocked, "Waiting\non the blocker"})
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--porcelain", blocked})
+	})
+	if err != nil {
+		t.Fatalf("Show(--porcelain) error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Show(--porcelain) printed %d lines, want 3:\n%s", len(lines), output)
+	}
+	if !strings.HasPrefix(lines[0], "ticket\tid="+blocked+"\t") {
+		t.Errorf("ticket line = %q", lines[0])
+	}
+	if want := "link\ttype=blocked_by\tid=" + blocker + "\tstatus=open"; lines[1] != want {
+		t.Errorf("link line = %q, want %q", lines[1], want)
+	}
+	if !strings.HasPrefix(lines[2], "comment\tid=") || !strings.HasSuffix(lines[2], "\tcontent=Waiting\\non the blocker\tacked_by=\tacked=") {
+		t.Errorf("comment line = %q", lines[2])
+	}
+
+	if err := Show([]string{"--porcelain", "--history", blocked}); err == nil {
+		t.Error("Show(--porcelain --history) expected error")
+	}
+}
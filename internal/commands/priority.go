@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+// PrioritySetResponse is the JSON output of the priority-set command.
+type PrioritySetResponse struct {
+	Success  bool   `json:"success"`
+	ID       string `json:"id"`
+	Priority int    `json:"priority"` // The ticket's priority after the change
+}
+
+// PrioritySet sets the priority of a ticket. Unlike update --priority, every
+// valid priority, including 0, is given explicitly as an argument.
+func PrioritySet(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("priority-set")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket priority-set <TICKET-ID> <PRIORITY> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nSet the priority of a ticket.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket priority-set <TICKET-ID> <PRIORITY>")
+	}
+	if fs.NArg() < 2 {
+		return thickerr.WithHint("Priority is required", "Usage: thicket priority-set <TICKET-ID> <PRIORITY>")
+	}
+	if fs.NArg() > 2 {
+		return thickerr.WithHint("Too many arguments", "Usage: thicket priority-set <TICKET-ID> <PRIORITY>")
+	}
+
+	priority, err := strconv.Atoi(fs.Arg(1))
+	if err != nil {
+		return thickerr.WithHint(fmt.Sprintf("Invalid priority %q", fs.Arg(1)), "Priority must be a whole number, e.g. 0 for the highest priority")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	if err := t.Update(nil, nil, nil, &priority, nil, nil, nil, nil, nil); err != nil {
+		return wrapTicketError(err)
+	}
+	t.UpdatedBy = config.ResolveIdentity()
+
+	if err := store.Update(t); err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return printJSON(PrioritySetResponse{Success: true, ID: t.ID, Priority: t.Priority})
+	}
+
+	fmt.Printf("Set priority of %s to %d\n", t.ID, t.Priority)
+	return nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPrioritySet(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Task", "--priority", "3"})
+	ticketID := firstTicketID(t, dir)
+
+	if err := PrioritySet([]string{ticketID, "0"}); err != nil {
+		t.Fatalf("PrioritySet(0) error = %v", err)
+	}
+	if got := ticketsByTitle(t, dir)["Task"].Priority; got != 0 {
+		t.Errorf("Priority = %d, want 0", got)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return PrioritySet([]string{"--json", ticketID, "2"})
+	})
+	if err != nil {
+		t.Fatalf("PrioritySet(2) error = %v", err)
+	}
+	var resp PrioritySetResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v\n%s", err, output)
+	}
+	if !resp.Success || resp.ID != ticketID || resp.Priority != 2 {
+		t.Errorf("response = %+v", resp)
+	}
+	if got := ticketsByTitle(t, dir)["Task"].Priority; got != 2 {
+		t.Errorf("Priority = %d, want 2", got)
+	}
+}
+
+func TestPrioritySet_Errors(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Task", "--priority", "3"})
+	ticketID := firstTicketID(t, dir)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"missing ID", nil},
+		{"missing priority", []string{ticketID}},
+		{"not a number", []string{ticketID, "high"}},
+		{"negative", []string{ticketID, "-1"}},
+		{"above max", []string{ticketID, "6"}},
+		{"unknown ticket", []string{"TH-zzzzzz", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := PrioritySet(tt.args); err == nil {
+				t.Errorf("PrioritySet(%v) expected error", tt.args)
+			}
+		})
+	}
+
+	if got := ticketsByTitle(t, dir)["Task"].Priority; got != 3 {
+		t.Errorf("Priority = %d, want unchanged 3", got)
+	}
+}
@@ -1,31 +1,98 @@
 package commands
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"strings"
+)
 
-// Quickstart prints guidance for coding agents on using Thicket.
-func Quickstart(args []string) error {
-	fmt.Print(`# Thicket Quickstart for Coding Agents
+// QuickstartJSON is the JSON output of the quickstart command.
+type QuickstartJSON struct {
+	Title         string              `json:"title"`
+	Goal          string              `json:"goal"`
+	Steps         []QuickstartStep    `json:"steps"`
+	Commands      []QuickstartCommand `json:"commands"`
+	BestPractices []string            `json:"best_practices"`
+}
 
-Your goal is to improve the project by resolving tickets and discovering additional work for future agents.
+// QuickstartStep is one step of the agent workflow. Command, if set, is an
+// example command for the step.
+type QuickstartStep struct {
+	Text    string `json:"text"`
+	Command string `json:"command,omitempty"`
+}
 
-## Workflow
+// QuickstartCommand is a subcommand agents commonly need.
+type QuickstartCommand struct {
+	Name  string `json:"name"`
+	Usage string `json:"usage"`
+}
 
-1. Work on the ticket described by "thicket ready".
-2. When resolved, run "thicket close <CURRENT_TICKET_ID>".
-3. Think of additional work and create tickets for future agents:
-   thicket add --title "Brief descriptive title" --description "Detailed context" --priority=<N> --type=<TYPE> --created-from <CURRENT_TICKET_ID>
-4. Commit your changes.
+// quickstart is the guidance printed by the quickstart command.
+var quickstart = QuickstartJSON{
+	Title: "Thicket Quickstart for Coding Agents",
+	Goal:  "Your goal is to improve the project by resolving tickets and discovering additional work for future agents.",
+	Steps: []QuickstartStep{
+		{Text: `Work on the ticket described by "thicket ready".`},
+		{Text: `When resolved, run "thicket close <CURRENT_TICKET_ID>".`},
+		{
+			Text:    "Think of additional work and create tickets for future agents:",
+			Command: `thicket add --title "Brief descriptive title" --description "Detailed context" --priority=<N> --type=<TYPE> --created-from <CURRENT_TICKET_ID>`,
+		},
+		{Text: "Commit your changes."},
+	},
+	Commands: []QuickstartCommand{
+		{Name: "ready", Usage: "thicket ready"},
+		{Name: "close", Usage: "thicket close <TICKET_ID>"},
+		{Name: "add", Usage: "thicket add --title <TITLE> --description <DESC> --type <TYPE> --priority <N> [--label <LABEL>...] [--blocks <ID>] [--blocked-by <ID>] [--created-from <ID>]"},
+		{Name: "show", Usage: "thicket show <TICKET_ID>"},
+		{Name: "comment", Usage: `thicket comment <TICKET_ID> "Comment text"`},
+		{Name: "list", Usage: "thicket list [--status <STATUS>] [--label <LABEL>]"},
+	},
+	BestPractices: []string{
+		"NEVER edit .thicket/tickets.jsonl directly. Always use the thicket CLI.",
+	},
+}
 
-## Commands
+// Quickstart prints guidance for coding agents on using Thicket.
+func Quickstart(args []string) error {
+	fs, jsonOutput, _ := newFlagSet("quickstart")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket quickstart [--json]")
+		fmt.Fprintln(os.Stderr, "\nShow a guide for coding agents on using Thicket.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
-- thicket ready
-- thicket close <TICKET_ID>
-- thicket add --title <TITLE> --description <DESC> --type <TYPE> --priority <N> [--label <LABEL>...] [--blocks <ID>] [--blocked-by <ID>] [--created-from <ID>]
-- thicket show <TICKET_ID>
-- thicket comment <TICKET_ID> "Comment text"
-- thicket list [--status <STATUS>] [--label <LABEL>]
+	if *jsonOutput {
+		return printJSON(quickstart)
+	}
 
-**CRITICAL**: NEVER edit .thicket/tickets.jsonl directly. Always use the thicket CLI.
-`)
+	fmt.Print(formatQuickstart(quickstart))
 	return nil
 }
+
+// formatQuickstart renders the guidance as Markdown.
+func formatQuickstart(q QuickstartJSON) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n%s\n\n## Workflow\n\n", q.Title, q.Goal)
+	for i, step := range q.Steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step.Text)
+		if step.Command != "" {
+			fmt.Fprintf(&b, "   %s\n", step.Command)
+		}
+	}
+	b.WriteString("\n## Commands\n\n")
+	for _, c := range q.Commands {
+		fmt.Fprintf(&b, "- %s\n", c.Usage)
+	}
+	b.WriteString("\n")
+	for _, p := range q.BestPractices {
+		fmt.Fprintf(&b, "**CRITICAL**: %s\n", p)
+	}
+	return b.String()
+}
@@ -1,6 +1,10 @@
 package commands
 
-import "testing"
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
 
 func TestQuickstart(t *testing.T) {
 	err := Quickstart([]string{})
@@ -8,3 +12,41 @@ func TestQuickstart(t *testing.T) {
 		t.Fatalf("Quickstart() error = %v", err)
 	}
 }
+
+func TestQuickstart_JSON(t *testing.T) {
+	output, err := captureStdout(t, func() error {
+		return Quickstart([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("Quickstart(--json) error = %v", err)
+	}
+
+	var got struct {
+		Title    string           `json:"title"`
+		Steps    []QuickstartStep `json:"steps"`
+		Commands []struct {
+			Name  string `json:"name"`
+			Usage string `json:"usage"`
+		} `json:"commands"`
+		BestPractices []string `json:"best_practices"`
+	}
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v\n%s", err, output)
+	}
+
+	if got.Title == "" || len(got.Steps) == 0 || len(got.BestPractices) == 0 {
+		t.Errorf("Quickstart(--json) is missing sections: %+v", got)
+	}
+	names := make(map[string]bool)
+	for _, c := range got.Commands {
+		names[c.Name] = true
+		if !strings.HasPrefix(c.Usage, "thicket "+c.Name) {
+			t.Errorf("usage for %s = %q", c.Name, c.Usage)
+		}
+	}
+	for _, want := range []string{"ready", "close", "add", "show", "comment", "list"} {
+		if !names[want] {
+			t.Errorf("commands section is missing %q: %+v", want, got.Commands)
+		}
+	}
+}
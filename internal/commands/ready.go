@@ -1,18 +1,25 @@
 package commands
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/abarth/thicket/internal/config"
-	"github.com/abarth/thicket/internal/storage"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/ticket"
 )
 
 // Ready displays the highest priority open ticket that is not blocked by other open tickets.
 func Ready(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("ready")
+	ref := addRefFlag(fs)
+	assigneeFilter := fs.String("assignee", "", "Only consider tickets assigned to this person (\"me\" for yourself)")
+	includeUnassigned := fs.Bool("include-unassigned", false, "With --assignee, also consider unassigned tickets")
+	limit := fs.Int("limit", 0, "List the N highest priority ready tickets instead of showing the first in full")
+	idsOnly := fs.Bool("ids-only", false, "Print only the IDs of the ready tickets, one per line, highest priority first")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: thicket ready [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "Usage: thicket ready [--assignee <NAME> [--include-unassigned]] [--limit <N>] [--ids-only | --json] [--ref <REV>] [--data-dir <DIR>]")
 		fmt.Fprintln(os.Stderr, "\nShow the highest priority actionable ticket (not blocked by others).")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
@@ -24,22 +31,73 @@ func Ready(args []string) error {
 
 	handleGlobalFlags(*dataDir)
 
+	if *includeUnassigned && *assigneeFilter == "" {
+		return thickerr.WithHint("--include-unassigned requires --assignee", "Usage: thicket ready --assignee me --include-unassigned")
+	}
+	limitSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "limit" {
+			limitSet = true
+		}
+	})
+	if limitSet && *limit < 1 {
+		return thickerr.WithHint("--limit must be at least 1", "Usage: thicket ready --limit <N>")
+	}
+	if *idsOnly && *jsonOutput {
+		return thickerr.WithHint("Cannot combine --ids-only and --json", "Use one of --ids-only or --json")
+	}
+	assignee, err := resolveAssignee(*assigneeFilter)
+	if err != nil {
+		return err
+	}
+
 	root, err := config.FindRoot()
 	if err != nil {
 		return wrapConfigError(err)
 	}
 
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
 	paths := config.GetPaths(root)
-	store, err := storage.Open(paths)
+	store, closeStore, err := openStore(paths, *ref)
 	if err != nil {
 		return err
 	}
-	defer store.Close()
+	defer closeStore()
 
 	tickets, err := store.ListReady()
 	if err != nil {
 		return err
 	}
+	if assignee != "" {
+		tickets = filterReadyForAssignee(tickets, assignee, *includeUnassigned)
+	}
+
+	if limitSet {
+		tickets = tickets[:min(*limit, len(tickets))]
+	}
+	if *idsOnly {
+		printTicketIDs(os.Stdout, tickets)
+		return nil
+	}
+
+	if limitSet {
+		if *jsonOutput {
+			if tickets == nil {
+				tickets = []*ticket.Ticket{}
+			}
+			return printJSON(newTicketsJSON(tickets, cfg))
+		}
+		if len(tickets) == 0 {
+			fmt.Println("No ready tickets found.")
+			return nil
+		}
+		printTicketTable(os.Stdout, tickets, displayOptions{Config: cfg})
+		return nil
+	}
 
 	if len(tickets) == 0 {
 		if *jsonOutput {
@@ -61,33 +119,27 @@ func Ready(args []string) error {
 		return err
 	}
 
-	blockedBy, err := store.GetBlockers(t.ID)
-	if err != nil {
-		return err
-	}
-
-	blocking, err := store.GetBlocking(t.ID)
-	if err != nil {
-		return err
-	}
-
-	createdFrom, err := store.GetCreatedFrom(t.ID)
+	details, err := loadTicketDetails(store, t, comments)
 	if err != nil {
 		return err
 	}
 
-	details := &TicketDetails{
-		Ticket:      t,
-		Comments:    comments,
-		BlockedBy:   blockedBy,
-		Blocking:    blocking,
-		CreatedFrom: createdFrom,
-	}
-
 	if *jsonOutput {
-		return printJSON(details)
+		return printDetailsJSON(details, cfg, nil)
 	}
 
-	printTicketDetail(os.Stdout, details)
+	printTicketDetail(os.Stdout, details, displayOptions{Config: cfg, Width: wrapWidth(0, false, cfg), TimeFormat: cfg.TimeFormat})
 	return nil
 }
+
+// filterReadyForAssignee keeps the tickets assigned to assignee and, if
+// includeUnassigned is set, the tickets assigned to nobody.
+func filterReadyForAssignee(tickets []*ticket.Ticket, assignee string, includeUnassigned bool) []*ticket.Ticket {
+	var filtered []*ticket.Ticket
+	for _, t := range tickets {
+		if t.Assignee == assignee || (includeUnassigned && t.Assignee == "") {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
@@ -155,3 +156,147 @@ func TestReadyNoTickets(t *testing.T) {
 		t.Error("Ready output should indicate no tickets found")
 	}
 }
+
+func TestReady_Assignee(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Bob's urgent", "--priority", "0", "--assignee", "Bob"})
+	Add([]string{"--title", "Unclaimed", "--priority", "1"})
+	Add([]string{"--title", "Alice's", "--priority", "2", "--assignee", "Alice"})
+
+	readyTitle := func(args ...string) string {
+		t.Helper()
+		output, err := captureStdout(t, func() error {
+			return Ready(append(args, "--json"))
+		})
+		if err != nil {
+			t.Fatalf("Ready(%v) error = %v", args, err)
+		}
+		var resp struct {
+			Ticket *struct {
+				Title string `json:"title"`
+			} `json:"ticket"`
+		}
+		if err := json.Unmarshal([]byte(output), &resp); err != nil {
+			t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+		}
+		if resp.Ticket == nil {
+			return ""
+		}
+		return resp.Ticket.Title
+	}
+
+	if got := readyTitle(); got != "Bob's urgent" {
+		t.Errorf("Ready() = %q, want Bob's urgent", got)
+	}
+	if got := readyTitle("--assignee", "me"); got != "Alice's" {
+		t.Errorf("Ready(--assignee me) = %q, want Alice's", got)
+	}
+	if got := readyTitle("--assignee", "me", "--include-unassigned"); got != "Unclaimed" {
+		t.Errorf("Ready(--assignee me --include-unassigned) = %q, want Unclaimed", got)
+	}
+	if got := readyTitle("--assignee", "Carol"); got != "" {
+		t.Errorf("Ready(--assignee Carol) = %q, want no ticket", got)
+	}
+
+	if err := Ready([]string{"--include-unassigned"}); err == nil {
+		t.Error("Ready(--include-unassigned) without --assignee should fail")
+	}
+}
+
+func TestReady_Limit(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Third", "--priority", "3"})
+	Add([]string{"--title", "First", "--priority", "0"})
+	Add([]string{"--title", "Fourth", "--priority", "4"})
+	Add([]string{"--title", "Second", "--priority", "1"})
+	Add([]string{"--title", "Blocked", "--priority", "0", "--blocked-by", ticketsByTitle(t, dir)["Fourth"].ID})
+
+	output, err := captureStdout(t, func() error {
+		return Ready([]string{"--limit", "3", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("Ready(--limit 3) error = %v", err)
+	}
+	var tickets []struct {
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	var titles []string
+	for _, tk := range tickets {
+		titles = append(titles, tk.Title)
+	}
+	if strings.Join(titles, ",") != "First,Second,Third" {
+		t.Errorf("Ready(--limit 3) = %v, want First, Second, Third", titles)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Ready([]string{"--limit", "10"})
+	})
+	if err != nil {
+		t.Fatalf("Ready(--limit 10) error = %v", err)
+	}
+	if !strings.Contains(output, "TITLE") || strings.Count(output, "TH-") != 4 {
+		t.Errorf("Ready(--limit 10) output should be a table of the 4 ready tickets, got:\n%s", output)
+	}
+
+	if err := Ready([]string{"--limit", "0"}); err == nil {
+		t.Error("Ready(--limit 0) expected error")
+	}
+}
+
+func TestReady_IDsOnly(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	output, err := captureStdout(t, func() error { return Ready([]string{"--ids-only"}) })
+	if err != nil {
+		t.Fatalf("Ready(--ids-only) error = %v", err)
+	}
+	if output != "" {
+		t.Errorf("Ready(--ids-only) with no tickets = %q, want no output", output)
+	}
+
+	Add([]string{"--title", "Second", "--priority", "2"})
+	Add([]string{"--title", "First", "--priority", "1"})
+	Add([]string{"--title", "Blocked", "--priority", "0", "--blocked-by", ticketsByTitle(t, dir)["Second"].ID})
+	tickets := ticketsByTitle(t, dir)
+
+	output, err = captureStdout(t, func() error { return Ready([]string{"--ids-only"}) })
+	if err != nil {
+		t.Fatalf("Ready(--ids-only) error = %v", err)
+	}
+	if want := tickets["First"].ID + "\n" + tickets["Second"].ID + "\n"; output != want {
+		t.Errorf("Ready(--ids-only) = %q, want %q", output, want)
+	}
+
+	output, err = captureStdout(t, func() error { return Ready([]string{"--ids-only", "--limit", "1"}) })
+	if err != nil {
+		t.Fatalf("Ready(--ids-only --limit 1) error = %v", err)
+	}
+	if want := tickets["First"].ID + "\n"; output != want {
+		t.Errorf("Ready(--ids-only --limit 1) = %q, want %q", output, want)
+	}
+
+	if err := Ready([]string{"--ids-only", "--json"}); err == nil {
+		t.Error("Ready(--ids-only --json) expected error")
+	}
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+// revisionReader reads files as they were at a git revision.
+type revisionReader interface {
+	// ReadAt returns the contents of file as of revision rev. If rev exists
+	// but file doesn't exist in it, the error wraps fs.ErrNotExist.
+	ReadAt(file, rev string) ([]byte, error)
+}
+
+// gitRevisions reads revisions with git show, from the repository containing
+// the file.
+type gitRevisions struct{}
+
+func (gitRevisions) ReadAt(file, rev string) ([]byte, error) {
+	// git would take such a revision for an option.
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid revision %q", rev)
+	}
+	object := rev + ":./" + filepath.Base(file)
+	out, err := runGit(filepath.Dir(file), "show", object)
+	if err == nil {
+		return out, nil
+	}
+	// Tell a file that is missing at the revision from a bad revision or
+	// any other failure.
+	if _, revErr := runGit(filepath.Dir(file), "rev-parse", "--verify", "--quiet", rev+"^{tree}"); revErr == nil {
+		if _, existsErr := runGit(filepath.Dir(file), "cat-file", "-e", object); existsErr != nil {
+			return nil, fmt.Errorf("%s does not exist at %s: %w", filepath.Base(file), rev, fs.ErrNotExist)
+		}
+	}
+	return nil, err
+}
+
+// runGit runs git with args in dir and returns its output. If git fails, the
+// error is git's message, if it printed one.
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// revisions reads the data files at a revision for diff and --ref. It is a
+// variable so tests can supply snapshots without a git repository.
+var revisions revisionReader = gitRevisions{}
+
+// addRefFlag adds the --ref flag of read-only commands to fs.
+func addRefFlag(fs *flag.FlagSet) *string {
+	return fs.String("ref", "", "Read tickets as of this git revision (e.g., a branch) instead of the working tree")
+}
+
+// openStore opens the project's store, or with a ref, a store of the
+// project's data as of that git revision. Such a store is read from a
+// temporary copy of the data files with an in-memory cache, so it leaves the
+// project alone; close it with the returned function rather than
+// store.Close.
+func openStore(paths config.Paths, ref string) (*storage.Store, func(), error) {
+	if ref == "" {
+		store, err := storage.Open(paths)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, func() { store.Close() }, nil
+	}
+
+	dir, err := os.MkdirTemp("", "thicket-ref-")
+	if err != nil {
+		return nil, nil, err
+	}
+	refPaths := paths
+	refPaths.Dir = dir
+	refPaths.Cache = filepath.Join(dir, config.CacheFile)
+	for _, file := range []*string{&refPaths.Tickets, &refPaths.Comments, &refPaths.Dependencies} {
+		if *file == "" {
+			continue
+		}
+		data, err := revisions.ReadAt(*file, ref)
+		// A missing comments or dependencies file means they weren't split
+		// out of tickets.jsonl yet at ref, so there are none to read from it.
+		if err != nil && (file == &refPaths.Tickets || !errors.Is(err, fs.ErrNotExist)) {
+			os.RemoveAll(dir)
+			return nil, nil, thickerr.WithHint(
+				fmt.Sprintf("Cannot read %s at %s: %v", filepath.Base(*file), ref, err),
+				"Use a git revision, such as a branch name, in which tickets.jsonl exists",
+			)
+		}
+		*file = filepath.Join(dir, filepath.Base(*file))
+		if err := os.WriteFile(*file, data, 0644); err != nil {
+			os.RemoveAll(dir)
+			return nil, nil, err
+		}
+	}
+
+	db, err := storage.OpenDB(storage.MemoryPath)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, err
+	}
+	store, err := storage.OpenBackend(refPaths, db)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, fmt.Errorf("reading tickets at %s: %w", ref, err)
+	}
+	return store, func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}, nil
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestRef(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := Add([]string{"--title", "Current"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	proposed, _ := ticket.New("TH", "Proposed", "On the review branch", ticket.TypeFeature, 1, nil, "", 0)
+	stubRevisions(t, map[string][]*ticket.Ticket{"review": {proposed}})
+
+	if got := listTitles(t, "--ref", "review"); len(got) != 1 || got[0] != "Proposed" {
+		t.Errorf("List(--ref review) = %v, want [Proposed]", got)
+	}
+	out, err := captureStdout(t, func() error { return Show([]string{"--ref", "review", proposed.ID}) })
+	if err != nil {
+		t.Fatalf("Show(--ref review) error = %v", err)
+	}
+	if !strings.Contains(out, "On the review branch") {
+		t.Errorf("Show(--ref review) output missing the description:\n%s", out)
+	}
+
+	// The working tree is untouched.
+	if got := listTitles(t); len(got) != 1 || got[0] != "Current" {
+		t.Errorf("List() = %v, want [Current]", got)
+	}
+	if _, ok := ticketsByTitle(t, dir)["Proposed"]; ok {
+		t.Error("reading --ref review added its ticket to the project")
+	}
+
+	if err := List([]string{"--ref", "missing"}); err == nil {
+		t.Error("List(--ref missing) succeeded, want an error")
+	}
+	if err := Show([]string{"--ref", "review", "--edit", proposed.ID}); err == nil {
+		t.Error("Show(--ref --edit) succeeded, want an error")
+	}
+}
+
+func TestGitRevisions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v error = %v: %s", args, err, out)
+		}
+	}
+	tickets := filepath.Join(dir, "tickets.jsonl")
+	git("init", "-q")
+	if err := os.WriteFile(tickets, []byte(`{"id":"TH-abc123"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "tickets.jsonl")
+	git("commit", "-q", "-m", "Add tickets")
+
+	if data, err := (gitRevisions{}).ReadAt(tickets, "HEAD"); err != nil || !strings.Contains(string(data), "TH-abc123") {
+		t.Errorf("ReadAt(HEAD) = %q, %v; want the committed tickets", data, err)
+	}
+
+	if _, err := (gitRevisions{}).ReadAt(filepath.Join(dir, "comments.jsonl"), "HEAD"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadAt() of a missing file error = %v, want fs.ErrNotExist", err)
+	}
+	if _, err := (gitRevisions{}).ReadAt(tickets, "no-such-branch"); err == nil || errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadAt() of a missing revision error = %v, want an error other than fs.ErrNotExist", err)
+	}
+
+	out := filepath.Join(dir, "out")
+	if _, err := (gitRevisions{}).ReadAt(tickets, "--output="+out); err == nil {
+		t.Error("ReadAt() of an option-like revision succeeded, want an error")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("ReadAt() passed the revision to git as an option (stat error = %v)", err)
+	}
+}
+
+func TestRef_SplitFiles(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH", "--split-files"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	proposed, _ := ticket.New("TH", "Proposed", "", ticket.TypeTask, 1, nil, "", 0)
+	var buf bytes.Buffer
+	if err := storage.EncodeAllJSONL(&buf, []*ticket.Ticket{proposed}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	splitErr := fs.ErrNotExist
+	old := revisions
+	revisions = revisionsFunc(func(file, rev string) ([]byte, error) {
+		if filepath.Base(file) == config.TicketsFile {
+			return buf.Bytes(), nil
+		}
+		return nil, splitErr
+	})
+	t.Cleanup(func() { revisions = old })
+
+	// Comments and dependencies may not have been split out yet at the ref.
+	if got := listTitles(t, "--ref", "old"); len(got) != 1 || got[0] != "Proposed" {
+		t.Errorf("List(--ref old) = %v, want [Proposed]", got)
+	}
+
+	// But other failures to read them are reported.
+	splitErr = errors.New("git is broken")
+	if err := List([]string{"--ref", "old"}); err == nil || !strings.Contains(err.Error(), "git is broken") {
+		t.Errorf("List(--ref old) error = %v, want the read error", err)
+	}
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+// Rename changes the title of a ticket.
+func Rename(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("rename")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket rename <TICKET-ID> \"New title\" [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nChange the title of a ticket.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket rename <TICKET-ID> \"New title\"")
+	}
+	if fs.NArg() < 2 {
+		return thickerr.WithHint("New title is required", "Usage: thicket rename <TICKET-ID> \"New title\"")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	title := fs.Arg(1)
+	if err := t.Update(&title, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
+		return wrapTicketError(err)
+	}
+	t.UpdatedBy = config.ResolveIdentity()
+
+	if err := store.Update(t); err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return printJSON(SuccessResponse{
+			Success: true,
+			ID:      t.ID,
+			Message: fmt.Sprintf("Renamed ticket %s", t.ID),
+		})
+	}
+
+	fmt.Printf("Renamed ticket %s\n", t.ID)
+	return nil
+}
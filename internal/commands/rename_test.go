@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+func TestRename(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Original"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	ticketID := tickets[0].ID
+	store.Close()
+
+	if err := Rename([]string{ticketID, "Renamed"}); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+
+	store, _ = storage.Open(paths)
+	tk, _ := store.Get(ticketID)
+	store.Close()
+
+	if tk.Title != "Renamed" {
+		t.Errorf("Title = %q, want 'Renamed'", tk.Title)
+	}
+}
+
+func TestRename_EmptyTitle(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Original"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	ticketID := tickets[0].ID
+	store.Close()
+
+	if err := Rename([]string{ticketID, "   "}); err == nil {
+		t.Error("Rename() expected error for empty title")
+	}
+
+	store, _ = storage.Open(paths)
+	tk, _ := store.Get(ticketID)
+	store.Close()
+
+	if tk.Title != "Original" {
+		t.Errorf("Title = %q, want unchanged 'Original'", tk.Title)
+	}
+}
+
+func TestRename_MissingTitle(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Rename([]string{"TH-abc123"}); err == nil {
+		t.Error("Rename() expected error for missing title")
+	}
+}
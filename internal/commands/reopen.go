@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// ReopenResponse is the JSON output of the reopen command.
+type ReopenResponse struct {
+	Success  bool     `json:"success"`
+	Reopened []string `json:"reopened"`
+}
+
+// Reopen marks a closed ticket as open again.
+func Reopen(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("reopen")
+	cascade := fs.Bool("cascade", false, "Also reopen closed tickets that transitively block this one")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket reopen <TICKET-ID> [--cascade] [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nReopen a closed ticket.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket reopen <TICKET-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	// With --cascade an open ticket is fine: its closed blockers still
+	// need reopening.
+	if t.Status != ticket.StatusClosed && !(*cascade && t.Status == ticket.StatusOpen) {
+		return thickerr.WithHint(
+			fmt.Sprintf("Ticket %s is not closed", t.ID),
+			"Use 'thicket restore' for deleted tickets or 'thicket update --status' for other changes",
+		)
+	}
+
+	toReopen := []*ticket.Ticket{t}
+	if *cascade {
+		blockers, err := closedBlockers(store, t.ID)
+		if err != nil {
+			return err
+		}
+		toReopen = append(toReopen, blockers...)
+	}
+
+	identity := config.ResolveIdentity()
+	var changed []*ticket.Ticket
+	reopened := []string{}
+	for _, tk := range toReopen {
+		if tk.Status != ticket.StatusClosed {
+			continue
+		}
+		tk.Reopen()
+		tk.UpdatedBy = identity
+		changed = append(changed, tk)
+		reopened = append(reopened, tk.ID)
+	}
+
+	if len(changed) > 0 {
+		if err := store.UpdateTickets(changed); err != nil {
+			return err
+		}
+	}
+
+	if *jsonOutput {
+		return printJSON(ReopenResponse{Success: true, Reopened: reopened})
+	}
+
+	if len(reopened) == 0 {
+		fmt.Printf("No closed tickets block %s\n", t.ID)
+		return nil
+	}
+	for _, id := range reopened {
+		fmt.Printf("Reopened ticket %s\n", id)
+	}
+	return nil
+}
+
+// closedBlockers walks the blocked_by graph from id and returns every
+// closed ticket that transitively blocks it, nearest first. The walk passes
+// through open blockers, whose own blockers may be closed, but stops at
+// tickets that are deleted or in the icebox.
+func closedBlockers(store *storage.Store, id string) ([]*ticket.Ticket, error) {
+	var closed []*ticket.Ticket
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		blockers, err := store.GetBlockers(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range blockers {
+			if seen[b.ID] {
+				continue
+			}
+			seen[b.ID] = true
+			switch b.Status {
+			case ticket.StatusClosed:
+				closed = append(closed, b)
+			case ticket.StatusOpen:
+			default:
+				continue
+			}
+			queue = append(queue, b.ID)
+		}
+	}
+	return closed, nil
+}
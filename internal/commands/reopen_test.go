@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// ticketsByTitle returns every ticket in the project, keyed by title.
+func ticketsByTitle(t *testing.T, dir string) map[string]*ticket.Ticket {
+	t.Helper()
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	tickets, err := store.ListAll()
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	byTitle := make(map[string]*ticket.Ticket)
+	for _, tk := range tickets {
+		byTitle[tk.Title] = tk
+	}
+	return byTitle
+}
+
+func TestReopen(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Finished"})
+	id := firstTicketID(t, dir)
+	Close([]string{"--reason", "wontfix", id})
+
+	if err := Reopen([]string{id}); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+
+	tk := ticketsByTitle(t, dir)["Finished"]
+	if tk.Status != ticket.StatusOpen || tk.CloseReason != "" {
+		t.Errorf("status = %q, reason = %q, want open with no reason", tk.Status, tk.CloseReason)
+	}
+
+	if err := Reopen([]string{id}); err == nil {
+		t.Error("Reopen() expected error for a ticket that is not closed")
+	}
+}
+
+func TestReopen_Cascade(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, title := range []string{"Top", "Middle", "Base", "Side", "Deep", "Unrelated"} {
+		Add([]string{"--title", title})
+	}
+	byTitle := ticketsByTitle(t, dir)
+	id := func(title string) string { return byTitle[title].ID }
+
+	// Top <- Middle <- Base, and Top <- Side (open) <- Deep.
+	links := [][2]string{{"Top", "Middle"}, {"Middle", "Base"}, {"Top", "Side"}, {"Side", "Deep"}}
+	for _, l := range links {
+		if err := Link([]string{"--blocked-by", id(l[1]), id(l[0])}); err != nil {
+			t.Fatalf("Link() error = %v", err)
+		}
+	}
+	for _, title := range []string{"Top", "Middle", "Base", "Deep", "Unrelated"} {
+		if err := Close([]string{id(title)}); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Reopen([]string{"--cascade", "--json", id("Top")})
+	})
+	if err != nil {
+		t.Fatalf("Reopen(--cascade) error = %v", err)
+	}
+
+	var resp ReopenResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	want := []string{id("Top"), id("Middle"), id("Base"), id("Deep")}
+	for _, w := range want {
+		if !slices.Contains(resp.Reopened, w) {
+			t.Errorf("reopened = %v, want it to include %s", resp.Reopened, w)
+		}
+	}
+	if len(resp.Reopened) != len(want) {
+		t.Errorf("reopened = %v, want %d tickets", resp.Reopened, len(want))
+	}
+
+	byTitle = ticketsByTitle(t, dir)
+	for _, title := range []string{"Top", "Middle", "Base", "Side", "Deep"} {
+		if byTitle[title].Status != ticket.StatusOpen {
+			t.Errorf("%s status = %q, want open", title, byTitle[title].Status)
+		}
+	}
+	if byTitle["Unrelated"].Status != ticket.StatusClosed {
+		t.Errorf("Unrelated status = %q, want closed", byTitle["Unrelated"].Status)
+	}
+}
+
+func TestReopen_MissingID(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := Reopen([]string{}); err == nil {
+		t.Error("Reopen() expected error for missing ticket ID")
+	}
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Restore reopens a deleted ticket.
+func Restore(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("restore")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket restore <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nRestore a deleted ticket. The ticket is reopened.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 1 {
+		return thickerr.WithHint("Ticket ID is required", "Usage: thicket restore <TICKET-ID>")
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	if t.Status != ticket.StatusDeleted {
+		return thickerr.WithHint(
+			fmt.Sprintf("Ticket %s is not deleted", t.ID),
+			"Use 'thicket update --status' to change the status of a ticket that is not deleted",
+		)
+	}
+
+	t.Restore()
+	t.UpdatedBy = config.ResolveIdentity()
+
+	if err := store.Update(t); err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return printJSON(SuccessResponse{
+			Success: true,
+			ID:      t.ID,
+			Message: fmt.Sprintf("Restored ticket %s", t.ID),
+		})
+	}
+
+	fmt.Printf("Restored ticket %s\n", t.ID)
+	return nil
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestRestore(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+	ticketID := tickets[0].ID
+
+	if err := Delete([]string{ticketID}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := Restore([]string{ticketID}); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	store, _ = storage.Open(paths)
+	tk, _ := store.Get(ticketID)
+	store.Close()
+	if tk.Status != ticket.StatusOpen {
+		t.Errorf("Status = %q, want open", tk.Status)
+	}
+
+	titles := listTitles(t)
+	if len(titles) != 1 {
+		t.Errorf("Restored ticket should be listed, got %v", titles)
+	}
+}
+
+func TestRestore_NotDeleted(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	if err := Restore([]string{tickets[0].ID}); err == nil {
+		t.Error("Restore() expected error for a ticket that is not deleted")
+	}
+}
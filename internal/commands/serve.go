@@ -0,0 +1,545 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// defaultServeAddr is where serve listens unless --addr is given. It is on
+// the loopback interface so the API isn't exposed to the network by default.
+const defaultServeAddr = "127.0.0.1:7420"
+
+// Server limits, so that a slow or misbehaving client can't tie up the API,
+// which handles one request at a time.
+const (
+	serveReadHeaderTimeout = 10 * time.Second
+	serveReadTimeout       = 30 * time.Second
+	serveWriteTimeout      = 30 * time.Second
+	maxRequestBody         = 1 << 20
+	serveShutdownTimeout   = 10 * time.Second
+)
+
+// Serve runs an HTTP server with a JSON API over the project's tickets.
+func Serve(args []string) error {
+	fs, _, dataDir := newFlagSet("serve")
+	addr := fs.String("addr", defaultServeAddr, "Address to listen on, as host:port")
+	webhookURL := fs.String("webhook", "", "URL to post ticket events to (default: webhook_url from config.json)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket serve [--addr <HOST:PORT>] [--webhook <URL>] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nServe a JSON HTTP API over the project's tickets.")
+		fmt.Fprintln(os.Stderr, "\nEndpoints:")
+		fmt.Fprintln(os.Stderr, "  GET  /tickets                List tickets (?status=, ?label=)")
+		fmt.Fprintln(os.Stderr, "  GET  /tickets/{id}           Show a ticket with its comments and links")
+		fmt.Fprintln(os.Stderr, "  POST /tickets                Create a ticket")
+		fmt.Fprintln(os.Stderr, "  PATCH /tickets/{id}          Update a ticket")
+		fmt.Fprintln(os.Stderr, "  POST /tickets/{id}/close     Close a ticket")
+		fmt.Fprintln(os.Stderr, "  POST /tickets/{id}/comments  Comment on a ticket")
+		fmt.Fprintln(os.Stderr, "  GET  /ready                  List tickets that are ready to work on")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	srv := newServer(root, store, cfg)
+	if *webhookURL == "" {
+		*webhookURL = cfg.WebhookURL
+	}
+	if *webhookURL != "" {
+		srv.hook = newWebhook(*webhookURL)
+		fmt.Fprintf(os.Stderr, "Posting ticket events to %s\n", *webhookURL)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+		ReadTimeout:       serveReadTimeout,
+		WriteTimeout:      serveWriteTimeout,
+	}
+	fmt.Fprintf(os.Stderr, "Serving Thicket API for %s on http://%s\n", root, *addr)
+
+	errs := make(chan error, 1)
+	go func() { errs <- httpServer.ListenAndServe() }()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-errs:
+		signal.Stop(signals)
+		return err
+	case <-signals:
+	}
+	// Stop catching signals so that a second Ctrl+C exits immediately,
+	// abandoning any webhook events still waiting to be delivered.
+	signal.Stop(signals)
+
+	fmt.Fprintln(os.Stderr, "Shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	err = httpServer.Shutdown(ctx)
+	if n := srv.hook.pending(); n > 0 {
+		fmt.Fprintf(os.Stderr, "Delivering %d pending webhook event(s)\n", n)
+	}
+	srv.hook.wait()
+	return err
+}
+
+// server handles the serve command's API. All requests share one store, so
+// mu serializes them.
+type server struct {
+	mu    sync.Mutex
+	root  string // Project root, where hooks run
+	store *storage.Store
+	cfg   *config.Config
+	mux   *http.ServeMux
+	hook  *webhook // Receives ticket events, if configured
+}
+
+// newServer returns a server for the API over store, the store of the project
+// at root.
+func newServer(root string, store *storage.Store, cfg *config.Config) *server {
+	s := &server{root: root, store: store, cfg: cfg, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /tickets", s.listTickets)
+	s.mux.HandleFunc("GET /tickets/{id}", s.showTicket)
+	s.mux.HandleFunc("POST /tickets", s.createTicket)
+	s.mux.HandleFunc("PATCH /tickets/{id}", s.updateTicket)
+	s.mux.HandleFunc("POST /tickets/{id}/close", s.closeTicket)
+	s.mux.HandleFunc("POST /tickets/{id}/comments", s.addComment)
+	s.mux.HandleFunc("GET /ready", s.listReady)
+	return s
+}
+
+// ServeHTTP serves a request with the store locked, after bringing the cache
+// up to date with changes made by the CLI since the last request. Requests
+// that may come from a web page rather than a local tool are refused first.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if status, err := checkRequestOrigin(r); err != nil {
+		writeAPIJSON(w, status, apiError{Error: err.Message, Hint: err.Hint})
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBody)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.store.SyncFromJSONL(); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// checkRequestOrigin refuses requests that a web page in the user's browser
+// could have made, since the API has no authentication:
+//
+//   - A Host other than localhost or an IP address means the request came
+//     through a DNS name that may have been rebound to the loopback address.
+//   - Browsers send Origin with cross-origin requests; local tools don't.
+//   - A mutating request must be JSON. Browsers can send plain text or form
+//     bodies cross-origin without asking the server first, but not JSON.
+//
+// It returns the status to respond with and the error, or nil if the request
+// is allowed.
+func checkRequestOrigin(r *http.Request) (int, *thickerr.UserError) {
+	if !isLocalHost(r.Host) {
+		return http.StatusForbidden, thickerr.WithHint(
+			fmt.Sprintf("Host %q is not allowed", r.Host),
+			"Connect to the API as localhost or by IP address",
+		)
+	}
+	if r.Header.Get("Origin") != "" {
+		return http.StatusForbidden, thickerr.WithHint(
+			"Cross-origin requests are not allowed",
+			"The API is for local tools, not web pages",
+		)
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "application/json" {
+			return http.StatusUnsupportedMediaType, thickerr.WithHint(
+				"Content-Type must be application/json",
+				"Send the request with 'Content-Type: application/json'",
+			)
+		}
+	}
+	return 0, nil
+}
+
+// isLocalHost reports whether host, from a request's Host header, names
+// localhost or is an IP address. IP addresses can't be rebound, so they're
+// allowed even when serve listens on other interfaces.
+func isLocalHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return true
+	}
+	return net.ParseIP(host) != nil
+}
+
+// apiError is the body of an API error response.
+type apiError struct {
+	Error string `json:"error"`
+	Hint  string `json:"hint,omitempty"`
+}
+
+// writeAPIJSON writes v as the JSON body of a response with the given status.
+func writeAPIJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	writeJSON(w, v)
+}
+
+// writeAPIError writes err as an error response, with a status that matches
+// the kind of error.
+func writeAPIError(w http.ResponseWriter, err error) {
+	var userErr *thickerr.UserError
+	if !errors.As(err, &userErr) {
+		writeAPIJSON(w, http.StatusInternalServerError, apiError{Error: err.Error()})
+		return
+	}
+
+	status := http.StatusBadRequest
+	switch userErr.Kind {
+	case thickerr.KindNotFound:
+		status = http.StatusNotFound
+	case thickerr.KindConflict:
+		status = http.StatusConflict
+	}
+	writeAPIJSON(w, status, apiError{Error: userErr.Message, Hint: userErr.Hint})
+}
+
+// requestError returns err as a UserError, so it is reported as a problem
+// with the request rather than as an internal error.
+func requestError(err error) error {
+	var userErr *thickerr.UserError
+	if errors.As(err, &userErr) {
+		return err
+	}
+	return thickerr.New(err.Error())
+}
+
+// listTickets handles GET /tickets. The optional status and label query
+// parameters filter the tickets as list's --status and --label do.
+func (s *server) listTickets(w http.ResponseWriter, r *http.Request) {
+	var status *ticket.Status
+	if v := r.URL.Query().Get("status"); v != "" {
+		st := ticket.Status(v)
+		if err := ticket.ValidateStatus(st); err != nil {
+			writeAPIError(w, thickerr.InvalidStatus(v))
+			return
+		}
+		status = &st
+	}
+
+	tickets, err := s.store.List(status)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if label := r.URL.Query().Get("label"); label != "" {
+		tickets = slices.DeleteFunc(tickets, func(t *ticket.Ticket) bool {
+			return !slices.Contains(t.Labels, label)
+		})
+	}
+	if tickets == nil {
+		tickets = []*ticket.Ticket{}
+	}
+	writeAPIJSON(w, http.StatusOK, newTicketsJSON(tickets, s.cfg))
+}
+
+// showTicket handles GET /tickets/{id}, responding with the same details as
+// show --json.
+func (s *server) showTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := s.getTicket(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	comments, err := s.store.GetComments(t.ID)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	details, err := loadTicketDetails(s.store, t, comments)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, newTicketDetailsJSON(details, s.cfg, nil))
+}
+
+// createTicket handles POST /tickets. The body is a ticket object like the
+// one add --stdin reads.
+func (s *server) createTicket(w http.ResponseWriter, r *http.Request) {
+	spec, err := readAddSpec(r.Body)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if spec.Blocks != "" || spec.BlockedBy != "" || spec.CreatedFrom != "" {
+		writeAPIError(w, thickerr.WithHint(
+			"Links are not supported when creating a ticket over the API",
+			"Create the ticket, then run 'thicket link'",
+		))
+		return
+	}
+
+	priority := 2
+	if spec.Priority != nil {
+		priority = *spec.Priority
+	}
+	t, err := ticket.New(s.cfg.ProjectCode, spec.Title, spec.Description, ticket.Type(spec.Type), priority, spec.Labels, spec.Assignee, spec.Estimate)
+	if err != nil {
+		writeAPIError(w, requestError(wrapTicketError(err)))
+		return
+	}
+	t.CreatedBy = config.ResolveIdentity()
+
+	if err := s.store.Add(t); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	runHook(s.root, s.cfg, config.HookOnCreate, t)
+	s.notify(webhookCreate, t, nil)
+	writeAPIJSON(w, http.StatusCreated, newTicketJSON(t, s.cfg))
+}
+
+// updateRequest is the body of PATCH /tickets/{id}. Only the fields present
+// are changed.
+type updateRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Type        *string `json:"type"`
+	Priority    *int    `json:"priority"`
+	Status      *string `json:"status"`
+	Assignee    *string `json:"assignee"`
+	Estimate    *int    `json:"estimate"`
+}
+
+// updateTicket handles PATCH /tickets/{id}.
+func (s *server) updateTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := s.getTicket(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var req updateRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeAPIError(w, thickerr.WithHint(
+			fmt.Sprintf("Invalid update JSON: %v", err),
+			`Send an object with the fields to change, e.g. {"priority": 1}`,
+		))
+		return
+	}
+	if req == (updateRequest{}) {
+		writeAPIError(w, thickerr.WithHint("No fields to update", "Send title, description, type, priority, status, assignee, or estimate"))
+		return
+	}
+
+	var issueType *ticket.Type
+	if req.Type != nil {
+		tt := ticket.Type(*req.Type)
+		issueType = &tt
+	}
+	var status *ticket.Status
+	if req.Status != nil {
+		st := ticket.Status(*req.Status)
+		if err := ticket.ValidateStatus(st); err != nil {
+			writeAPIError(w, thickerr.InvalidStatus(*req.Status))
+			return
+		}
+		status = &st
+	}
+
+	wasClosed := t.Status == ticket.StatusClosed
+	if err := t.Update(req.Title, req.Description, issueType, req.Priority, status, nil, nil, req.Assignee, req.Estimate); err != nil {
+		writeAPIError(w, requestError(wrapTicketError(err)))
+		return
+	}
+	t.UpdatedBy = config.ResolveIdentity()
+
+	if err := s.store.Update(t); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if !wasClosed && t.Status == ticket.StatusClosed {
+		runHook(s.root, s.cfg, config.HookOnClose, t)
+		s.notify(webhookClose, t, nil)
+	} else {
+		s.notify(webhookUpdate, t, nil)
+	}
+	writeAPIJSON(w, http.StatusOK, newTicketJSON(t, s.cfg))
+}
+
+// closeRequest is the optional body of POST /tickets/{id}/close.
+type closeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// closeTicket handles POST /tickets/{id}/close. Closing a closed ticket
+// succeeds without changing it.
+func (s *server) closeTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := s.getTicket(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var req closeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeAPIError(w, thickerr.WithHint(
+			fmt.Sprintf("Invalid close JSON: %v", err),
+			`Send an empty body, or an object like {"reason": "wontfix"}`,
+		))
+		return
+	}
+	if err := ticket.ValidateCloseReason(ticket.CloseReason(req.Reason)); err != nil {
+		writeAPIError(w, thickerr.InvalidCloseReason(req.Reason))
+		return
+	}
+
+	if t.Status != ticket.StatusClosed {
+		if err := t.CloseWithReason(ticket.CloseReason(req.Reason)); err != nil {
+			writeAPIError(w, requestError(wrapTicketError(err)))
+			return
+		}
+		t.UpdatedBy = config.ResolveIdentity()
+		if err := s.store.Update(t); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		runHook(s.root, s.cfg, config.HookOnClose, t)
+		s.notify(webhookClose, t, nil)
+	}
+	writeAPIJSON(w, http.StatusOK, newTicketJSON(t, s.cfg))
+}
+
+// notify sends event to the webhook, if one is configured.
+func (s *server) notify(event string, t *ticket.Ticket, c *ticket.Comment) {
+	if s.hook == nil {
+		return
+	}
+	s.hook.send(webhookPayload{Event: event, Time: time.Now().UTC(), Ticket: newTicketJSON(t, s.cfg), Comment: c})
+}
+
+// commentRequest is the body of POST /tickets/{id}/comments.
+type commentRequest struct {
+	Content string `json:"content"`
+	Author  string `json:"author"` // Defaults to the server's identity
+}
+
+// addComment handles POST /tickets/{id}/comments.
+func (s *server) addComment(w http.ResponseWriter, r *http.Request) {
+	t, err := s.getTicket(r.PathValue("id"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var req commentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, thickerr.WithHint(
+			fmt.Sprintf("Invalid comment JSON: %v", err),
+			`Send an object like {"content": "Working on this now"}`,
+		))
+		return
+	}
+
+	c, err := ticket.NewComment(t.ID, req.Content)
+	if err != nil {
+		if errors.Is(err, ticket.ErrEmptyComment) {
+			err = thickerr.EmptyComment()
+		}
+		writeAPIError(w, requestError(wrapTicketError(err)))
+		return
+	}
+	c.Author = req.Author
+	if c.Author == "" {
+		c.Author = config.ResolveIdentity()
+	}
+
+	if err := s.store.AddComment(c); err != nil {
+		writeAPIError(w, wrapTicketError(err))
+		return
+	}
+	s.notify(webhookComment, t, c)
+	writeAPIJSON(w, http.StatusCreated, c)
+}
+
+// listReady handles GET /ready, responding with every ready ticket in the
+// order ready --limit uses.
+func (s *server) listReady(w http.ResponseWriter, r *http.Request) {
+	tickets, err := s.store.ListReady()
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	if tickets == nil {
+		tickets = []*ticket.Ticket{}
+	}
+	writeAPIJSON(w, http.StatusOK, newTicketsJSON(tickets, s.cfg))
+}
+
+// getTicket returns the ticket with the given full or partial ID.
+func (s *server) getTicket(id string) (*ticket.Ticket, error) {
+	ticketID, err := resolveTicketID(s.store, id)
+	if err != nil {
+		return nil, err
+	}
+	t, err := s.store.Get(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, thickerr.TicketNotFound(ticketID)
+	}
+	return t, nil
+}
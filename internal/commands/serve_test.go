@@ -0,0 +1,283 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// newTestServer returns a test HTTP server for the API over the project in dir.
+func newTestServer(t *testing.T, dir string) *httptest.Server {
+	t.Helper()
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load() error = %v", err)
+	}
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("storage.Open() error = %v", err)
+	}
+	srv := httptest.NewServer(newServer(dir, store, cfg))
+	t.Cleanup(func() {
+		srv.Close()
+		store.Close()
+	})
+	return srv
+}
+
+// doJSON sends a request with a JSON body to srv and decodes the JSON
+// response into out.
+func doJSON(t *testing.T, srv *httptest.Server, method, path, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if method != "GET" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doRequest(t, srv, req, out)
+}
+
+// doRequest sends req to srv and decodes the JSON response into out.
+func doRequest(t *testing.T, srv *httptest.Server, req *http.Request, out any) int {
+	t.Helper()
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s Content-Type = %q, want application/json", req.Method, req.URL.Path, ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("%s %s: decoding response: %v", req.Method, req.URL.Path, err)
+	}
+	return resp.StatusCode
+}
+
+func TestServe_Tickets(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Existing", "--priority", "1", "--label", "ui"})
+	srv := newTestServer(t, dir)
+
+	var created TicketJSON
+	status := doJSON(t, srv, "POST", "/tickets", `{"title": "From the API", "priority": 0, "labels": ["api"]}`, &created)
+	if status != http.StatusCreated {
+		t.Fatalf("POST /tickets status = %d, want 201", status)
+	}
+	if created.Title != "From the API" || created.Priority != 0 || !strings.HasPrefix(created.ID, "TH-") {
+		t.Errorf("POST /tickets = %+v", created.Ticket)
+	}
+
+	var tickets []TicketJSON
+	if status := doJSON(t, srv, "GET", "/tickets", "", &tickets); status != http.StatusOK {
+		t.Fatalf("GET /tickets status = %d, want 200", status)
+	}
+	if len(tickets) != 2 || tickets[0].ID != created.ID || tickets[1].Title != "Existing" {
+		t.Errorf("GET /tickets = %v", tickets)
+	}
+
+	tickets = nil
+	doJSON(t, srv, "GET", "/tickets?label=ui", "", &tickets)
+	if len(tickets) != 1 || tickets[0].Title != "Existing" {
+		t.Errorf("GET /tickets?label=ui = %v", tickets)
+	}
+
+	var comment struct {
+		ID      string `json:"id"`
+		Content string `json:"content"`
+		Author  string `json:"author"`
+	}
+	status = doJSON(t, srv, "POST", "/tickets/"+created.ID+"/comments", `{"content": "Posted remotely", "author": "dashboard"}`, &comment)
+	if status != http.StatusCreated {
+		t.Fatalf("POST comments status = %d, want 201", status)
+	}
+	if comment.Content != "Posted remotely" || comment.Author != "dashboard" {
+		t.Errorf("POST comments = %+v", comment)
+	}
+
+	var details struct {
+		Ticket   TicketJSON `json:"ticket"`
+		Comments []struct {
+			Content string `json:"content"`
+		} `json:"comments"`
+		IsReady bool `json:"is_ready"`
+	}
+	if status := doJSON(t, srv, "GET", "/tickets/"+created.ID, "", &details); status != http.StatusOK {
+		t.Fatalf("GET /tickets/{id} status = %d, want 200", status)
+	}
+	if details.Ticket.ID != created.ID || len(details.Comments) != 1 || !details.IsReady {
+		t.Errorf("GET /tickets/{id} = %+v", details)
+	}
+
+	var ready []TicketJSON
+	if status := doJSON(t, srv, "GET", "/ready", "", &ready); status != http.StatusOK {
+		t.Fatalf("GET /ready status = %d, want 200", status)
+	}
+	if len(ready) != 2 || ready[0].ID != created.ID {
+		t.Errorf("GET /ready = %v", ready)
+	}
+}
+
+func TestServe_SeesCLIChanges(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	srv := newTestServer(t, dir)
+
+	Add([]string{"--title", "Added by the CLI"})
+
+	var tickets []TicketJSON
+	doJSON(t, srv, "GET", "/tickets", "", &tickets)
+	if len(tickets) != 1 || tickets[0].Title != "Added by the CLI" {
+		t.Errorf("GET /tickets after a CLI change = %v", tickets)
+	}
+}
+
+func TestServe_Errors(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Existing"})
+	existing := firstTicketID(t, dir)
+	srv := newTestServer(t, dir)
+
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"GET", "/tickets/TH-zzzzzz", "", http.StatusNotFound},
+		{"GET", "/tickets/not-an-id", "", http.StatusBadRequest},
+		{"GET", "/tickets?status=bogus", "", http.StatusBadRequest},
+		{"POST", "/tickets", `{"title": ""}`, http.StatusBadRequest},
+		{"POST", "/tickets", `{"title": "Bad", "priority": 99}`, http.StatusBadRequest},
+		{"POST", "/tickets", `{"title": "Bad", "type": "nonsense"}`, http.StatusBadRequest},
+		{"POST", "/tickets", `not json`, http.StatusBadRequest},
+		{"POST", "/tickets/" + existing + "/comments", `{"content": "  "}`, http.StatusBadRequest},
+		{"POST", "/tickets/TH-zzzzzz/comments", `{"content": "Hi"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var resp apiError
+		if status := doJSON(t, srv, tt.method, tt.path, tt.body, &resp); status != tt.want {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, status, tt.want)
+		}
+		if resp.Error == "" {
+			t.Errorf("%s %s response has no error message", tt.method, tt.path)
+		}
+	}
+}
+
+func TestServe_RejectsBrowserRequests(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Existing"})
+	existing := firstTicketID(t, dir)
+	srv := newTestServer(t, dir)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		contentType string
+		host        string
+		origin      string
+		want        int
+	}{
+		{"plain text body", "POST", "/tickets", `{"title": "CSRF"}`, "text/plain", "", "", http.StatusUnsupportedMediaType},
+		{"form body", "POST", "/tickets/" + existing + "/close", "", "application/x-www-form-urlencoded", "", "", http.StatusUnsupportedMediaType},
+		{"no content type", "POST", "/tickets/" + existing + "/comments", `{"content": "Hi"}`, "", "", "", http.StatusUnsupportedMediaType},
+		{"cross-origin", "POST", "/tickets", `{"title": "CSRF"}`, "application/json", "", "http://evil.example", http.StatusForbidden},
+		{"cross-origin read", "GET", "/tickets", "", "", "", "http://evil.example", http.StatusForbidden},
+		{"rebound host", "POST", "/tickets/" + existing + "/close", "", "application/json", "evil.example", "", http.StatusForbidden},
+		{"rebound host read", "GET", "/tickets", "", "", "evil.example:7420", "", http.StatusForbidden},
+		{"too large", "POST", "/tickets", `{"title": "Big", "description": "` + strings.Repeat("x", maxRequestBody) + `"}`, "application/json", "", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("NewRequest() error = %v", err)
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			var resp apiError
+			if status := doRequest(t, srv, req, &resp); status != tt.want {
+				t.Errorf("status = %d, want %d (%+v)", status, tt.want, resp)
+			}
+		})
+	}
+
+	tickets := ticketsByTitle(t, dir)
+	if len(tickets) != 1 || tickets["Existing"].Status != ticket.StatusOpen {
+		t.Errorf("tickets = %v, want only the existing ticket, still open", tickets)
+	}
+
+	// Local tools can reach the API as localhost or by IP address.
+	for _, host := range []string{"localhost:7420", "127.0.0.1", "[::1]:7420"} {
+		req, _ := http.NewRequest("GET", srv.URL+"/tickets", nil)
+		req.Host = host
+		var listed []TicketJSON
+		if status := doRequest(t, srv, req, &listed); status != http.StatusOK {
+			t.Errorf("GET /tickets with Host %s status = %d, want 200", host, status)
+		}
+	}
+}
+
+func TestServe_Hooks(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	writeHooksConfig(t, dir, `{"on_create": "touch \"$THICKET_TICKET_ID.created\"", "on_close": "touch \"$THICKET_TICKET_ID.closed\""}`)
+	srv := newTestServer(t, dir)
+
+	var created TicketJSON
+	if status := doJSON(t, srv, "POST", "/tickets", `{"title": "From the API"}`, &created); status != http.StatusCreated {
+		t.Fatalf("POST /tickets status = %d, want 201", status)
+	}
+	if _, err := os.Stat(filepath.Join(dir, created.ID+".created")); err != nil {
+		t.Errorf("on_create hook didn't run: %v", err)
+	}
+
+	var closed TicketJSON
+	if status := doJSON(t, srv, "POST", "/tickets/"+created.ID+"/close", "", &closed); status != http.StatusOK {
+		t.Fatalf("POST /tickets/{id}/close status = %d, want 200", status)
+	}
+	if _, err := os.Stat(filepath.Join(dir, created.ID+".closed")); err != nil {
+		t.Errorf("on_close hook didn't run: %v", err)
+	}
+}
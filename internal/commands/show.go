@@ -1,20 +1,35 @@
 package commands
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/abarth/thicket/internal/config"
 	thickerr "github.com/abarth/thicket/internal/errors"
-	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
 // Show displays a single ticket.
 func Show(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("show")
+	ref := addRefFlag(fs)
+	priorityLabels := fs.Bool("priority-labels", false, "Show the priority label (e.g., High) next to the priority number")
+	format := fs.String("format", "text", "Output format (text, html)")
+	history := fs.Bool("history", false, "Show the ticket's change history instead of its details")
+	expandList := fs.String("expand", "", "Comma-separated related collections to include in --json output (comments, blockers, blocking, created_from, children, dependencies)")
+	fieldList := fs.String("fields", "", "Comma-separated ticket fields to include in --json output (e.g., id,title,status)")
+	raw := fs.Bool("raw", false, "Print the ticket exactly as it is stored in tickets.jsonl")
+	timeFormat := fs.String("time-format", "", "Timestamp format: rfc3339, date, relative, or a Go layout (default: time_format from config.json)")
+	porcelain := fs.Bool("porcelain", false, "Print key=value records for the ticket, its links, and its comments in a format that is stable across versions")
+	edit := fs.Bool("edit", false, "Edit the description in $EDITOR, save it, then show the ticket")
+	depth := fs.Int("depth", 1, "Expand blockers of blockers down to this many levels")
+	width := fs.Int("width", 0, "Wrap the description and comments to this many columns (0 for no wrapping; default: terminal width)")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: thicket show <TICKET-ID> [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "Usage: thicket show <TICKET-ID> [--priority-labels] [--format <FORMAT>] [--history] [--raw] [--porcelain] [--edit] [--depth <N>] [--width <N>] [--time-format <FORMAT>] [--json [--fields <FIELDS>] [--expand <COLLECTIONS>]] [--ref <REV>] [--data-dir <DIR>]")
 		fmt.Fprintln(os.Stderr, "\nDisplay details of a specific ticket.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
@@ -30,9 +45,67 @@ func Show(args []string) error {
 		return thickerr.WithHint("Ticket ID is required", "Usage: thicket show <TICKET-ID>")
 	}
 
-	ticketID := normalizeTicketID(fs.Arg(0))
-	if err := ticket.ValidateID(ticketID); err != nil {
-		return thickerr.InvalidTicketID(ticketID)
+	if *format != "text" && *format != "html" {
+		return thickerr.WithHint(
+			fmt.Sprintf("Invalid format: %s", *format),
+			"Valid formats are: text, html",
+		)
+	}
+
+	if *raw && (*jsonOutput || *history || *format != "text") {
+		return thickerr.WithHint(
+			"--raw cannot be combined with --json, --history, or --format",
+			"--raw already prints the ticket's stored JSON",
+		)
+	}
+
+	if *porcelain && (*jsonOutput || *history || *raw || *format != "text") {
+		return thickerr.WithHint(
+			"--porcelain cannot be combined with --json, --history, --raw, or --format",
+			"--porcelain is already a machine-readable format",
+		)
+	}
+
+	if *depth < 1 {
+		return thickerr.WithHint(
+			fmt.Sprintf("Invalid depth: %d", *depth),
+			"Use 1 for direct blockers only, or more to expand their blockers too",
+		)
+	}
+	if *depth > 1 && (*history || *raw || *porcelain) {
+		return thickerr.WithHint(
+			"--depth cannot be combined with --history, --raw, or --porcelain",
+			"--depth only expands blockers in the ticket's details",
+		)
+	}
+
+	if *edit && *ref != "" {
+		return thickerr.WithHint("Cannot combine --edit and --ref", "Tickets at another revision are read-only")
+	}
+
+	if *width < 0 {
+		return thickerr.WithHint(
+			fmt.Sprintf("Invalid width: %d", *width),
+			"Use a positive number of columns, or 0 to turn off wrapping",
+		)
+	}
+	widthSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "width" {
+			widthSet = true
+		}
+	})
+
+	fields, err := parseJSONFields(*fieldList, *jsonOutput)
+	if err != nil {
+		return err
+	}
+	expand, err := parseExpand(*expandList, *jsonOutput)
+	if err != nil {
+		return err
+	}
+	if expand != nil && *history {
+		return thickerr.WithHint("Cannot combine --expand and --history", "--expand only selects collections in the ticket's details")
 	}
 
 	root, err := config.FindRoot()
@@ -40,12 +113,22 @@ func Show(args []string) error {
 		return wrapConfigError(err)
 	}
 
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
 	paths := config.GetPaths(root)
-	store, err := storage.Open(paths)
+	store, closeStore, err := openStore(paths, *ref)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
 	if err != nil {
 		return err
 	}
-	defer store.Close()
 
 	t, err := store.Get(ticketID)
 	if err != nil {
@@ -55,38 +138,117 @@ func Show(args []string) error {
 		return thickerr.TicketNotFound(ticketID)
 	}
 
-	comments, err := store.GetComments(ticketID)
-	if err != nil {
-		return err
+	if *edit {
+		applyConfig(cfg)
+		edited, changed, err := editDescription(t)
+		if err != nil {
+			return err
+		}
+		if changed {
+			if err := t.Update(nil, &edited, nil, nil, nil, nil, nil, nil, nil); err != nil {
+				return wrapTicketError(err)
+			}
+			t.UpdatedBy = config.ResolveIdentity()
+			if err := store.Update(t); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Updated description of %s\n", t.ID)
+		} else {
+			fmt.Fprintf(os.Stderr, "Description of %s unchanged\n", t.ID)
+		}
 	}
 
-	blockedBy, err := store.GetBlockers(ticketID)
-	if err != nil {
-		return err
+	if *raw {
+		// Marshal the ticket the same way storage writes it so the output
+		// matches its line in tickets.jsonl.
+		data, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Errorf("encoding ticket %s: %w", t.ID, err)
+		}
+		fmt.Println(string(data))
+		return nil
 	}
 
-	blocking, err := store.GetBlocking(ticketID)
-	if err != nil {
-		return err
+	var comments []*ticket.Comment
+	if expand == nil || expand["comments"] {
+		if comments, err = store.GetComments(ticketID); err != nil {
+			return err
+		}
+	}
+
+	if *history {
+		entries, err := buildHistory(store, t, comments)
+		if err != nil {
+			return err
+		}
+		if *jsonOutput {
+			return printJSON(entries)
+		}
+		printHistory(os.Stdout, t.ID, entries, showTimeFormat(*timeFormat, cfg))
+		return nil
 	}
 
-	createdFrom, err := store.GetCreatedFrom(ticketID)
+	details, err := loadExpandedDetails(store, t, expand)
 	if err != nil {
 		return err
 	}
-
-	details := &TicketDetails{
-		Ticket:      t,
-		Comments:    comments,
-		BlockedBy:   blockedBy,
-		Blocking:    blocking,
-		CreatedFrom: createdFrom,
+	details.Comments = comments
+	if *depth > 1 {
+		if details.BlockerTree, err = loadBlockerTree(store, t.ID, *depth); err != nil {
+			return err
+		}
 	}
 
 	if *jsonOutput {
-		return printJSON(details)
+		return printDetailsJSON(details, cfg, fields)
+	}
+
+	if *porcelain {
+		printDetailsPorcelain(os.Stdout, details)
+		return nil
+	}
+
+	if *format == "html" {
+		return printTicketHTML(os.Stdout, details)
 	}
 
-	printTicketDetail(os.Stdout, details)
+	opts := displayOptions{Config: cfg, PriorityLabels: *priorityLabels, Width: wrapWidth(*width, widthSet, cfg), TimeFormat: showTimeFormat(*timeFormat, cfg)}
+	printTicketDetail(os.Stdout, details, opts)
 	return nil
 }
+
+// parseExpand parses the --expand flag, which only applies to --json output.
+// It returns nil if spec is empty, meaning every collection.
+func parseExpand(spec string, jsonOutput bool) (map[string]bool, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	if !jsonOutput {
+		return nil, thickerr.WithHint("--expand requires --json", "Add --json to choose the collections in the JSON output")
+	}
+	var names []string
+	for _, e := range detailExpansions {
+		names = append(names, e.Name)
+	}
+	expand := map[string]bool{}
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(names, name) {
+			return nil, thickerr.WithHint(
+				fmt.Sprintf("Unknown collection for --expand: %s", name),
+				"Valid collections are: "+strings.Join(names, ", "),
+			)
+		}
+		expand[name] = true
+	}
+	return expand, nil
+}
+
+// showTimeFormat returns the --time-format flag if given, or else the
+// project's configured time_format.
+func showTimeFormat(flagValue string, cfg *config.Config) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	return cfg.TimeFormat
+}
@@ -1,11 +1,15 @@
 package commands
 
 import (
+	"encoding/json"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
 )
 
 func TestShow(t *testing.T) {
@@ -121,3 +125,671 @@ func TestShow_WithComments(t *testing.T) {
 		t.Fatalf("Show() error = %v", err)
 	}
 }
+
+func TestShow_PriorityLabels(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket", "--priority", "0"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--priority-labels", tickets[0].ID})
+	})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, "Priority:    0 (Critical)") {
+		t.Errorf("Show output should contain priority label, got: %s", output)
+	}
+}
+
+func TestShow_FormatHTML(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "<script>alert(1)</script>"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--format", "html", tickets[0].ID})
+	})
+	if err != nil {
+		t.Fatalf("Show(--format html) error = %v", err)
+	}
+	if !strings.HasPrefix(output, "<!DOCTYPE html>") {
+		t.Errorf("Show output should be an HTML document, got: %s", output)
+	}
+	if strings.Contains(output, "<script>") {
+		t.Error("Show HTML output should escape the title")
+	}
+}
+
+func TestShow_InvalidFormat(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	err := Show([]string{"--format", "pdf", "TH-111111"})
+	if err == nil || !strings.Contains(err.Error(), "Invalid format") {
+		t.Errorf("Show() error = %v, want invalid format error", err)
+	}
+}
+
+func TestShow_PartialID(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Partial match"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+	ticketID := tickets[0].ID
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{ticketID[3:6]})
+	})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, ticketID) {
+		t.Errorf("Show() output should contain %s, got: %s", ticketID, output)
+	}
+}
+
+func TestShow_AmbiguousID(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	for _, id := range []string{"TH-abc123", "TH-abd456"} {
+		tk, _ := ticket.New("TH", "Ticket", "", ticket.TypeTask, 2, nil, "", 0)
+		tk.ID = id
+		store.Add(tk)
+	}
+	store.Close()
+
+	err := Show([]string{"ab"})
+	if err == nil || !strings.Contains(err.Error(), "TH-abc123, TH-abd456") {
+		t.Errorf("Show() error = %v, want ambiguity error listing candidates", err)
+	}
+}
+
+func TestShow_History(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+
+	paths := config.GetPaths(dir)
+	store, _ := storage.Open(paths)
+	tickets, _ := store.List(nil)
+	store.Close()
+	ticketID := tickets[0].ID
+
+	Comment([]string{ticketID, "Looking into it"})
+	if err := Close([]string{ticketID}); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--history", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Show(--history) error = %v", err)
+	}
+	for _, want := range []string{"created: Test ticket", "commented: ", "changed status: open -> closed"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("History should contain %q, got: %s", want, output)
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Show([]string{"--history", "--json", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Show(--history --json) error = %v", err)
+	}
+
+	var history []HistoryEntry
+	if err := json.Unmarshal([]byte(output), &history); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(history) != 3 {
+		t.Fatalf("History has %d entries, want 3: %+v", len(history), history)
+	}
+	last := history[len(history)-1]
+	if last.Field != "status" || last.From != "open" || last.To != "closed" {
+		t.Errorf("Last history entry = %+v, want status change from open to closed", last)
+	}
+}
+
+func TestShow_Fields(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Parent"})
+	parentID := firstTicketID(t, dir)
+	Add([]string{"--title", "Child", "--priority", "0", "--blocked-by", parentID})
+	childID := firstTicketID(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--json", "--fields", "status,id", childID})
+	})
+	if err != nil {
+		t.Fatalf("Show(--fields) error = %v", err)
+	}
+
+	var details struct {
+		Ticket    map[string]interface{}   `json:"ticket"`
+		BlockedBy []map[string]interface{} `json:"blocked_by"`
+	}
+	if err := json.Unmarshal([]byte(output), &details); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(details.Ticket) != 2 || details.Ticket["id"] != childID || details.Ticket["status"] != "open" {
+		t.Errorf("ticket = %v, want only id and status", details.Ticket)
+	}
+	if len(details.BlockedBy) != 1 || len(details.BlockedBy[0]) != 2 {
+		t.Errorf("blocked_by = %v, want one ticket with only id and status", details.BlockedBy)
+	}
+	if !strings.Contains(output, `"status": "open",`) {
+		t.Errorf("fields should be in the requested order, got %s", output)
+	}
+}
+
+func TestShow_Raw(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Raw ticket", "--description", "Line one\nLine \"two\"", "--label", "bug"})
+	id := firstTicketID(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--raw", id})
+	})
+	if err != nil {
+		t.Fatalf("Show(--raw) error = %v", err)
+	}
+	if strings.Count(output, "\n") != 1 {
+		t.Errorf("raw output should be a single line, got %q", output)
+	}
+
+	var got ticket.Ticket
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+
+	store, err := storage.Open(config.GetPaths(dir))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	want, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got.ID != want.ID || got.Title != want.Title || got.Description != want.Description ||
+		got.Status != want.Status || got.Priority != want.Priority || got.Type != want.Type ||
+		strings.Join(got.Labels, ",") != strings.Join(want.Labels, ",") ||
+		!got.Created.Equal(want.Created) || !got.Updated.Equal(want.Updated) {
+		t.Errorf("raw ticket = %+v, want %+v", got, *want)
+	}
+}
+
+func TestShow_RawWithJSON(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Raw ticket"})
+	id := firstTicketID(t, dir)
+
+	if err := Show([]string{"--raw", "--json", id}); err == nil {
+		t.Error("Show(--raw --json) expected error")
+	}
+}
+
+func TestShow_CreatedChildren(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Parent"})
+	parentID := firstTicketID(t, dir)
+	Add([]string{"--title", "First child", "--created-from", parentID})
+	Add([]string{"--title", "Second child", "--created-from", parentID})
+	byTitle := ticketsByTitle(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{parentID})
+	})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, "Created from this ticket:") {
+		t.Errorf("output should list created children:\n%s", output)
+	}
+	for _, title := range []string{"First child", "Second child"} {
+		if !strings.Contains(output, byTitle[title].ID) {
+			t.Errorf("output should include %s (%s):\n%s", title, byTitle[title].ID, output)
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Show([]string{"--json", parentID})
+	})
+	if err != nil {
+		t.Fatalf("Show(--json) error = %v", err)
+	}
+	var details struct {
+		CreatedChildren []TicketJSON `json:"created_children"`
+	}
+	if err := json.Unmarshal([]byte(output), &details); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(details.CreatedChildren) != 2 {
+		t.Errorf("created_children = %d tickets, want 2", len(details.CreatedChildren))
+	}
+}
+
+func TestShow_JSONBlockedState(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	setupBlockedTickets(t, dir)
+	byTitle := ticketsByTitle(t, dir)
+
+	state := func(title string) (isBlocked, isReady bool) {
+		t.Helper()
+		output, err := captureStdout(t, func() error {
+			return Show([]string{"--json", byTitle[title].ID})
+		})
+		if err != nil {
+			t.Fatalf("Show(--json) error = %v", err)
+		}
+		var details struct {
+			IsBlocked bool `json:"is_blocked"`
+			IsReady   bool `json:"is_ready"`
+		}
+		if err := json.Unmarshal([]byte(output), &details); err != nil {
+			t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+		}
+		return details.IsBlocked, details.IsReady
+	}
+
+	if isBlocked, isReady := state("Blocked"); !isBlocked || isReady {
+		t.Errorf("Blocked: is_blocked = %v, is_ready = %v, want true and false", isBlocked, isReady)
+	}
+	if isBlocked, isReady := state("Blocker"); isBlocked || !isReady {
+		t.Errorf("Blocker: is_blocked = %v, is_ready = %v, want false and true", isBlocked, isReady)
+	}
+
+	Close([]string{byTitle["Blocker"].ID})
+	if isBlocked, isReady := state("Blocked"); isBlocked || !isReady {
+		t.Errorf("Blocked after closing blocker: is_blocked = %v, is_ready = %v, want false and true", isBlocked, isReady)
+	}
+	if _, isReady := state("Blocker"); isReady {
+		t.Error("a closed ticket should not be ready")
+	}
+}
+
+func TestShow_Width(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Wordy", "--description", strings.TrimSpace(strings.Repeat("word ", 30))})
+	id := firstTicketID(t, dir)
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--width", "40", id})
+	})
+	if err != nil {
+		t.Fatalf("Show(--width 40) error = %v", err)
+	}
+	description := output[strings.Index(output, "Description:\n")+len("Description:\n"):]
+	lines := strings.Split(strings.TrimSpace(description), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("description should wrap onto several lines:\n%s", output)
+	}
+	for _, line := range lines {
+		if len(line) > 40 {
+			t.Errorf("line %q is longer than 40 columns", line)
+		}
+	}
+	if len(lines[0]) != 39 {
+		t.Errorf("first line %q should fill the width up to a word boundary", lines[0])
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Show([]string{"--width", "0", id})
+	})
+	if err != nil {
+		t.Fatalf("Show(--width 0) error = %v", err)
+	}
+	if !strings.Contains(output, strings.Repeat("word ", 29)+"word\n") {
+		t.Errorf("--width 0 should not wrap:\n%s", output)
+	}
+
+	if err := Show([]string{"--width", "-1", id}); err == nil {
+		t.Error("Show(--width -1) expected error")
+	}
+}
+
+func TestShow_TimeFormat(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Timed"})
+	id := firstTicketID(t, dir)
+	Comment([]string{id, "A comment"})
+	today := ticketsByTitle(t, dir)["Timed"].Created.Format(time.DateOnly)
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--time-format", "date", id})
+	})
+	if err != nil {
+		t.Fatalf("Show(--time-format date) error = %v", err)
+	}
+	for _, want := range []string{"Created:     " + today + "\n", "  [" + today + "] "} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Show() output should contain %q:\n%s", want, output)
+		}
+	}
+
+	// The configured format applies when the flag is not given.
+	cfgData := []byte(`{"project_code": "TH", "time_format": "relative"}`)
+	if err := os.WriteFile(config.GetPaths(dir).Config, cfgData, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	output, err = captureStdout(t, func() error {
+		return Show([]string{id})
+	})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, "Updated:     just now\n") {
+		t.Errorf("Show() should use time_format from config.json:\n%s", output)
+	}
+}
+
+func TestShow_RelatedTo(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Original"})
+	Add([]string{"--title", "Copy"})
+	byTitle := ticketsByTitle(t, dir)
+	original, dup := byTitle["Original"].ID, byTitle["Copy"].ID
+	if err := Close([]string{"--duplicate-of", original, dup}); err != nil {
+		t.Fatalf("Close(--duplicate-of) error = %v", err)
+	}
+
+	// The link shows under its own header from both ends.
+	for id, other := range map[string]string{dup: original, original: dup} {
+		output, err := captureStdout(t, func() error {
+			return Show([]string{id})
+		})
+		if err != nil {
+			t.Fatalf("Show(%s) error = %v", id, err)
+		}
+		if !strings.Contains(output, "\nRelated to:\n  - "+other+": ") {
+			t.Errorf("Show(%s) should list %s under Related to:\n%s", id, other, output)
+		}
+		if strings.Contains(output, "Blocked by:") {
+			t.Errorf("Show(%s) should not list a related ticket as a blocker:\n%s", id, output)
+		}
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--json", dup})
+	})
+	if err != nil {
+		t.Fatalf("Show(--json) error = %v", err)
+	}
+	var details struct {
+		Dependencies []struct {
+			Type      string `json:"type"`
+			Direction string `json:"direction"`
+			Ticket    struct {
+				ID string `json:"id"`
+			} `json:"ticket"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal([]byte(output), &details); err != nil {
+		t.Fatalf("Unmarshal() error = %v\n%s", err, output)
+	}
+	if len(details.Dependencies) != 1 {
+		t.Fatalf("dependencies = %+v, want one", details.Dependencies)
+	}
+	d := details.Dependencies[0]
+	if d.Type != "related_to" || d.Direction != "outgoing" || d.Ticket.ID != original {
+		t.Errorf("dependency = %+v, want related_to, outgoing, %s", d, original)
+	}
+}
+
+func TestShow_Edit(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Task", "--description", "Old description"})
+	ticketID := firstTicketID(t, dir)
+
+	stubEditor(t, "Edited in place")
+	output, err := captureStdout(t, func() error {
+		return Show([]string{"--edit", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Show(--edit) error = %v", err)
+	}
+	if !strings.Contains(output, "Edited in place") {
+		t.Errorf("Show(--edit) should display the new description, got:\n%s", output)
+	}
+	if got := ticketsByTitle(t, dir)["Task"].Description; got != "Edited in place" {
+		t.Errorf("Description = %q, want 'Edited in place'", got)
+	}
+}
+
+func TestShow_Depth(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, title := range []string{"Goal", "Step", "Prerequisite"} {
+		if err := Add([]string{"--title", title}); err != nil {
+			t.Fatalf("Add(%s) error = %v", title, err)
+		}
+	}
+	tickets := ticketsByTitle(t, dir)
+	goal, step, prereq := tickets["Goal"].ID, tickets["Step"].ID, tickets["Prerequisite"].ID
+	if err := Link([]string{"--blocked-by", step, goal}); err != nil {
+		t.Fatalf("Link(goal) error = %v", err)
+	}
+	if err := Link([]string{"--blocked-by", prereq, step}); err != nil {
+		t.Fatalf("Link(step) error = %v", err)
+	}
+
+	out, err := captureStdout(t, func() error { return Show([]string{goal}) })
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if strings.Contains(out, prereq) {
+		t.Errorf("Show() without --depth lists the indirect blocker %s:\n%s", prereq, out)
+	}
+
+	out, err = captureStdout(t, func() error { return Show([]string{"--depth", "2", goal}) })
+	if err != nil {
+		t.Fatalf("Show(--depth 2) error = %v", err)
+	}
+	want := "  - " + step + ": Step\n    - " + prereq + ": Prerequisite\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("Show(--depth 2) output missing\n%s\ngot:\n%s", want, out)
+	}
+
+	out, err = captureStdout(t, func() error { return Show([]string{"--depth", "2", "--json", goal}) })
+	if err != nil {
+		t.Fatalf("Show(--depth 2 --json) error = %v", err)
+	}
+	var resp struct {
+		BlockerTree []struct {
+			Ticket    struct{ ID string }
+			BlockedBy []struct {
+				Ticket struct{ ID string }
+			} `json:"blocked_by"`
+		} `json:"blocker_tree"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v\n%s", err, out)
+	}
+	if len(resp.BlockerTree) != 1 || resp.BlockerTree[0].Ticket.ID != step ||
+		len(resp.BlockerTree[0].BlockedBy) != 1 || resp.BlockerTree[0].BlockedBy[0].Ticket.ID != prereq {
+		t.Errorf("blocker_tree = %+v, want %s blocked by %s", resp.BlockerTree, step, prereq)
+	}
+
+	if err := Show([]string{"--depth", "0", goal}); err == nil {
+		t.Error("Show(--depth 0) succeeded, want an error")
+	}
+}
+
+func TestShow_Expand(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	for _, title := range []string{"Main", "Blocker"} {
+		if err := Add([]string{"--title", title}); err != nil {
+			t.Fatalf("Add(%s) error = %v", title, err)
+		}
+	}
+	tickets := ticketsByTitle(t, dir)
+	mainID, blockerID := tickets["Main"].ID, tickets["Blocker"].ID
+	if err := Link([]string{"--blocked-by", blockerID, mainID}); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	if err := Comment([]string{mainID, "A note"}); err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+
+	showKeys := func(args ...string) map[string]json.RawMessage {
+		t.Helper()
+		out, err := captureStdout(t, func() error { return Show(append(args, mainID)) })
+		if err != nil {
+			t.Fatalf("Show(%v) error = %v", args, err)
+		}
+		var resp map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(out), &resp); err != nil {
+			t.Fatalf("Unmarshal() error = %v\n%s", err, out)
+		}
+		return resp
+	}
+
+	all := showKeys("--json")
+	for _, key := range []string{"comments", "blocked_by", "blocking", "created_from", "created_children", "dependencies"} {
+		if _, ok := all[key]; !ok {
+			t.Errorf("Show(--json) is missing %q", key)
+		}
+	}
+
+	resp := showKeys("--json", "--expand", "blockers", "--fields", "id,title")
+	for _, key := range []string{"comments", "blocking", "created_from", "created_children", "dependencies"} {
+		if _, ok := resp[key]; ok {
+			t.Errorf("Show(--expand blockers) includes %q", key)
+		}
+	}
+	var blockers []map[string]interface{}
+	if err := json.Unmarshal(resp["blocked_by"], &blockers); err != nil {
+		t.Fatalf("Unmarshal(blocked_by) error = %v", err)
+	}
+	if len(blockers) != 1 || blockers[0]["id"] != blockerID || len(blockers[0]) != 2 {
+		t.Errorf("blocked_by = %v, want %s with only id and title", blockers, blockerID)
+	}
+	if string(resp["is_blocked"]) != "true" {
+		t.Errorf("is_blocked = %s, want true", resp["is_blocked"])
+	}
+
+	resp = showKeys("--json", "--expand", "comments")
+	if !strings.Contains(string(resp["comments"]), "A note") {
+		t.Errorf("comments = %s, want the comment", resp["comments"])
+	}
+	if _, ok := resp["blocked_by"]; ok {
+		t.Error("Show(--expand comments) includes blocked_by")
+	}
+
+	if err := Show([]string{"--json", "--expand", "bogus", mainID}); err == nil {
+		t.Error("Show(--expand bogus) succeeded, want an error")
+	}
+	if err := Show([]string{"--expand", "comments", mainID}); err == nil {
+		t.Error("Show(--expand) without --json succeeded, want an error")
+	}
+}
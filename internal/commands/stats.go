@@ -0,0 +1,245 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// StatsResponse is the JSON output of the stats command.
+type StatsResponse struct {
+	ByStatus []storage.StatusStats `json:"by_status"`
+	Count    int                   `json:"count"`
+	Estimate int                   `json:"estimate"`
+}
+
+// AssigneeStatsResponse is the JSON output of stats --assignee-stats.
+type AssigneeStatsResponse struct {
+	ByAssignee []storage.AssigneeStats `json:"by_assignee"`
+}
+
+// BurndownBucket counts the tickets created and closed in one period, and
+// the tickets still open at its end.
+type BurndownBucket struct {
+	Start   string `json:"start"` // First day of the period, as YYYY-MM-DD
+	Created int    `json:"created"`
+	Closed  int    `json:"closed"`
+	Open    int    `json:"open"`
+}
+
+// BurndownResponse is the JSON output of stats --burndown.
+type BurndownResponse struct {
+	Interval string           `json:"interval"` // day or week
+	Buckets  []BurndownBucket `json:"buckets"`
+}
+
+// Stats summarizes the project's tickets.
+func Stats(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("stats")
+	ref := addRefFlag(fs)
+	burndownMode := fs.Bool("burndown", false, "Show tickets created, closed, and still open in each period instead")
+	interval := fs.String("interval", "day", "Period of each --burndown row: day or week")
+	byAssignee := fs.Bool("assignee-stats", false, "Count open and closed tickets per assignee instead")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket stats [--burndown [--interval day|week] | --assignee-stats] [--json] [--ref <REV>] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nCount the tickets with each status and total their estimates. Deleted tickets are not counted.")
+		fmt.Fprintln(os.Stderr, "With --burndown, count the tickets created and closed in each day or week, and those still open.")
+		fmt.Fprintln(os.Stderr, "With --assignee-stats, count each assignee's open and closed tickets.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	intervalSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "interval" {
+			intervalSet = true
+		}
+	})
+	if *burndownMode && *byAssignee {
+		return thickerr.WithHint("Cannot combine --burndown and --assignee-stats", "Use one of --burndown or --assignee-stats")
+	}
+	if intervalSet && !*burndownMode {
+		return thickerr.WithHint("--interval requires --burndown", "Usage: thicket stats --burndown --interval week")
+	}
+	if *interval != "day" && *interval != "week" {
+		return thickerr.WithHint(
+			fmt.Sprintf("Invalid interval: %s", *interval),
+			"Valid intervals are: day, week",
+		)
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, closeStore, err := openStore(paths, *ref)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	if *burndownMode {
+		tickets, err := store.ListAll()
+		if err != nil {
+			return err
+		}
+		resp := BurndownResponse{Interval: *interval, Buckets: burndown(tickets, *interval, time.Now())}
+		if *jsonOutput {
+			return printJSON(resp)
+		}
+		if len(resp.Buckets) == 0 {
+			fmt.Println("No tickets found.")
+			return nil
+		}
+		printBurndown(os.Stdout, resp.Buckets)
+		return nil
+	}
+
+	if *byAssignee {
+		stats, err := store.StatsByAssignee()
+		if err != nil {
+			return err
+		}
+		if stats == nil {
+			stats = []storage.AssigneeStats{}
+		}
+		if *jsonOutput {
+			return printJSON(AssigneeStatsResponse{ByAssignee: stats})
+		}
+		if len(stats) == 0 {
+			fmt.Println("No tickets found.")
+			return nil
+		}
+		printAssigneeStats(os.Stdout, stats)
+		return nil
+	}
+
+	stats, err := store.StatsByStatus()
+	if err != nil {
+		return err
+	}
+
+	resp := StatsResponse{ByStatus: stats}
+	for _, s := range stats {
+		resp.Count += s.Count
+		resp.Estimate += s.Estimate
+	}
+
+	if *jsonOutput {
+		if resp.ByStatus == nil {
+			resp.ByStatus = []storage.StatusStats{}
+		}
+		return printJSON(resp)
+	}
+
+	printStats(os.Stdout, resp)
+	return nil
+}
+
+// printStats prints a table with a row per status and a total row.
+func printStats(w io.Writer, resp StatsResponse) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tTICKETS\tESTIMATE")
+	fmt.Fprintln(tw, "------\t-------\t--------")
+	for _, s := range resp.ByStatus {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", s.Status, s.Count, s.Estimate)
+	}
+	fmt.Fprintf(tw, "total\t%d\t%d\n", resp.Count, resp.Estimate)
+	tw.Flush()
+}
+
+// printAssigneeStats prints a table with a row per assignee.
+func printAssigneeStats(w io.Writer, stats []storage.AssigneeStats) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ASSIGNEE\tOPEN\tCLOSED")
+	fmt.Fprintln(tw, "--------\t----\t------")
+	for _, s := range stats {
+		assignee := ticket.SanitizeLine(s.Assignee)
+		if assignee == "" {
+			assignee = "(unassigned)"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", assignee, s.Open, s.Closed)
+	}
+	tw.Flush()
+}
+
+// burndown buckets the creation and closure of tickets by day or week, in
+// UTC, from the period of the first ticket through the one containing now.
+// A closed ticket counts as closed at its last update, since Thicket doesn't
+// record when it was closed. Deleted tickets are not counted.
+func burndown(tickets []*ticket.Ticket, interval string, now time.Time) []BurndownBucket {
+	start := func(t time.Time) time.Time {
+		t = t.UTC().Truncate(24 * time.Hour)
+		if interval == "week" {
+			// Weeks start on Monday.
+			t = t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+		}
+		return t
+	}
+	next := func(t time.Time) time.Time {
+		if interval == "week" {
+			return t.AddDate(0, 0, 7)
+		}
+		return t.AddDate(0, 0, 1)
+	}
+
+	created := map[time.Time]int{}
+	closed := map[time.Time]int{}
+	var first time.Time
+	for _, t := range tickets {
+		if t.Status == ticket.StatusDeleted {
+			continue
+		}
+		c := start(t.Created)
+		created[c]++
+		if first.IsZero() || c.Before(first) {
+			first = c
+		}
+		if t.Status == ticket.StatusClosed {
+			closed[start(t.Updated)]++
+		}
+	}
+	if first.IsZero() {
+		return []BurndownBucket{}
+	}
+
+	buckets := []BurndownBucket{}
+	open := 0
+	for b := first; !b.After(start(now)); b = next(b) {
+		open += created[b] - closed[b]
+		buckets = append(buckets, BurndownBucket{
+			Start:   b.Format(time.DateOnly),
+			Created: created[b],
+			Closed:  closed[b],
+			Open:    open,
+		})
+	}
+	return buckets
+}
+
+// printBurndown prints a table with a row per period.
+func printBurndown(w io.Writer, buckets []BurndownBucket) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PERIOD\tCREATED\tCLOSED\tOPEN")
+	fmt.Fprintln(tw, "------\t-------\t------\t----")
+	for _, b := range buckets {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", b.Start, b.Created, b.Closed, b.Open)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestStats_EstimateSum(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Small", "--estimate", "1"})
+	Add([]string{"--title", "Large", "--estimate", "5"})
+	Add([]string{"--title", "Unestimated"})
+	Add([]string{"--title", "Done", "--estimate", "3"})
+	Add([]string{"--title", "Gone", "--estimate", "8"})
+	byTitle := ticketsByTitle(t, dir)
+	Close([]string{byTitle["Done"].ID})
+	Delete([]string{byTitle["Gone"].ID})
+
+	output, err := captureStdout(t, func() error {
+		return Stats([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("Stats(--json) error = %v", err)
+	}
+
+	var resp StatsResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(resp.ByStatus) != 2 {
+		t.Fatalf("by_status = %+v, want open and closed", resp.ByStatus)
+	}
+	open, closed := resp.ByStatus[0], resp.ByStatus[1]
+	if open.Status != ticket.StatusOpen || open.Count != 3 || open.Estimate != 6 {
+		t.Errorf("open stats = %+v, want 3 tickets estimated at 6", open)
+	}
+	if closed.Status != ticket.StatusClosed || closed.Count != 1 || closed.Estimate != 3 {
+		t.Errorf("closed stats = %+v, want 1 ticket estimated at 3", closed)
+	}
+	if resp.Count != 4 || resp.Estimate != 9 {
+		t.Errorf("totals = %d tickets, %d points, want 4 and 9", resp.Count, resp.Estimate)
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Stats([]string{})
+	})
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if !strings.Contains(output, "ESTIMATE") || !strings.Contains(output, "total") {
+		t.Errorf("Stats() output = %q, want a table with a total row", output)
+	}
+}
+
+func TestBurndown(t *testing.T) {
+	day := func(d int, hour int) time.Time {
+		return time.Date(2026, time.March, d, hour, 0, 0, 0, time.UTC)
+	}
+	seed := func(status ticket.Status, created, updated time.Time) *ticket.Ticket {
+		return &ticket.Ticket{Status: status, Created: created, Updated: updated}
+	}
+	// March 2, 2026 is a Monday.
+	tickets := []*ticket.Ticket{
+		seed(ticket.StatusClosed, day(2, 9), day(3, 17)),
+		seed(ticket.StatusOpen, day(2, 23), day(2, 23)),
+		seed(ticket.StatusIcebox, day(4, 8), day(4, 8)),
+		seed(ticket.StatusClosed, day(4, 10), day(10, 12)),
+		seed(ticket.StatusDeleted, day(3, 10), day(3, 11)),
+	}
+
+	daily := burndown(tickets, "day", day(5, 12))
+	wantDaily := []BurndownBucket{
+		{Start: "2026-03-02", Created: 2, Closed: 0, Open: 2},
+		{Start: "2026-03-03", Created: 0, Closed: 1, Open: 1},
+		{Start: "2026-03-04", Created: 2, Closed: 0, Open: 3},
+		{Start: "2026-03-05", Created: 0, Closed: 0, Open: 3},
+	}
+	if len(daily) != len(wantDaily) {
+		t.Fatalf("daily burndown = %+v, want %+v", daily, wantDaily)
+	}
+	for i := range wantDaily {
+		if daily[i] != wantDaily[i] {
+			t.Errorf("daily bucket %d = %+v, want %+v", i, daily[i], wantDaily[i])
+		}
+	}
+
+	weekly := burndown(tickets, "week", day(11, 12))
+	wantWeekly := []BurndownBucket{
+		{Start: "2026-03-02", Created: 4, Closed: 1, Open: 3},
+		{Start: "2026-03-09", Created: 0, Closed: 1, Open: 2},
+	}
+	if len(weekly) != len(wantWeekly) {
+		t.Fatalf("weekly burndown = %+v, want %+v", weekly, wantWeekly)
+	}
+	for i := range wantWeekly {
+		if weekly[i] != wantWeekly[i] {
+			t.Errorf("weekly bucket %d = %+v, want %+v", i, weekly[i], wantWeekly[i])
+		}
+	}
+
+	if got := burndown(nil, "day", day(5, 12)); len(got) != 0 {
+		t.Errorf("burndown(nil) = %+v, want no buckets", got)
+	}
+}
+
+func TestStats_Burndown(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Today"})
+
+	output, err := captureStdout(t, func() error {
+		return Stats([]string{"--burndown", "--interval", "week", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("Stats(--burndown --json) error = %v", err)
+	}
+	var resp BurndownResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if resp.Interval != "week" || len(resp.Buckets) != 1 || resp.Buckets[0].Created != 1 || resp.Buckets[0].Open != 1 {
+		t.Errorf("burndown = %+v, want one week with 1 ticket created and open", resp)
+	}
+
+	if err := Stats([]string{"--interval", "week"}); err == nil {
+		t.Error("Stats(--interval) without --burndown succeeded, want an error")
+	}
+	if err := Stats([]string{"--burndown", "--interval", "month"}); err == nil {
+		t.Error("Stats(--interval month) succeeded, want an error")
+	}
+}
+
+func TestStats_AssigneeStats(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Alice open 1", "--assignee", "alice"})
+	Add([]string{"--title", "Alice open 2", "--assignee", "alice"})
+	Add([]string{"--title", "Alice done", "--assignee", "alice"})
+	Add([]string{"--title", "Bob done", "--assignee", "bob"})
+	Add([]string{"--title", "Bob iced", "--assignee", "bob"})
+	Add([]string{"--title", "Nobody's"})
+	byTitle := ticketsByTitle(t, dir)
+	Close([]string{byTitle["Alice done"].ID})
+	Close([]string{byTitle["Bob done"].ID})
+	Update([]string{"--status", "icebox", byTitle["Bob iced"].ID})
+
+	output, err := captureStdout(t, func() error {
+		return Stats([]string{"--assignee-stats", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("Stats(--assignee-stats --json) error = %v", err)
+	}
+	var resp AssigneeStatsResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	want := []storage.AssigneeStats{
+		{Assignee: "alice", Open: 2, Closed: 1},
+		{Assignee: "bob", Open: 0, Closed: 1},
+		{Assignee: "", Open: 1, Closed: 0},
+	}
+	if len(resp.ByAssignee) != len(want) {
+		t.Fatalf("by_assignee = %+v, want %+v", resp.ByAssignee, want)
+	}
+	for i := range want {
+		if resp.ByAssignee[i] != want[i] {
+			t.Errorf("by_assignee[%d] = %+v, want %+v", i, resp.ByAssignee[i], want[i])
+		}
+	}
+
+	output, err = captureStdout(t, func() error {
+		return Stats([]string{"--assignee-stats"})
+	})
+	if err != nil {
+		t.Fatalf("Stats(--assignee-stats) error = %v", err)
+	}
+	if !strings.Contains(output, "ASSIGNEE") || !strings.Contains(output, "(unassigned)") {
+		t.Errorf("Stats(--assignee-stats) output = %q, want a table with an unassigned row", output)
+	}
+
+	if err := Stats([]string{"--assignee-stats", "--burndown"}); err == nil {
+		t.Error("Stats(--assignee-stats --burndown) succeeded, want an error")
+	}
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+)
+
+// SyncResponse is the JSON output of the sync command.
+type SyncResponse struct {
+	Success bool                 `json:"success"`
+	Rebuilt bool                 `json:"rebuilt"`
+	Counts  storage.RecordCounts `json:"counts"`
+}
+
+// Sync brings the SQLite cache up to date with tickets.jsonl.
+func Sync(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("sync")
+	rebuild := fs.Bool("rebuild", false, "Delete the cache and rebuild it from tickets.jsonl")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket sync [--rebuild] [--json] [--data-dir <DIR>]")
+		fmt.Fprintln(os.Stderr, "\nBring the cache up to date with tickets.jsonl and report whether it was rebuilt.")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil && *rebuild {
+		// The cache may be too damaged to open. It holds nothing that
+		// isn't in tickets.jsonl, so start again from an empty file.
+		if removeErr := storage.RemoveCache(paths); removeErr != nil {
+			return removeErr
+		}
+		store, err = storage.Open(paths)
+	}
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if *rebuild {
+		if err := store.ForceRebuild(); err != nil {
+			return err
+		}
+	} else if err := store.SyncFromJSONL(); err != nil {
+		return err
+	}
+
+	counts, err := store.CacheCounts()
+	if err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		return printJSON(SyncResponse{
+			Success: true,
+			Rebuilt: store.Rebuilt(),
+			Counts:  counts,
+		})
+	}
+
+	if store.Rebuilt() {
+		fmt.Println("Rebuilt cache from tickets.jsonl")
+	} else {
+		fmt.Println("Cache is up to date")
+	}
+	fmt.Printf("%d tickets, %d comments, %d dependencies\n", counts.Tickets, counts.Comments, counts.Dependencies)
+	return nil
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestSync_Rebuild(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Survives rebuild", "--type", "bug"})
+
+	// Replace the cache with garbage that SQLite cannot open.
+	paths := config.GetPaths(dir)
+	if err := os.WriteFile(paths.Cache, []byte("not a database"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	out, err := captureStdout(t, func() error {
+		return Sync([]string{"--rebuild"})
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !strings.Contains(out, "Rebuilt cache from tickets.jsonl\n1 tickets") {
+		t.Errorf("Sync() output = %q, want rebuild summary", out)
+	}
+
+	store, err := storage.Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].Title != "Survives rebuild" || tickets[0].Type != "bug" {
+		t.Errorf("List() after rebuild = %+v, want the bug ticket", tickets)
+	}
+}
+
+func TestSync_ExternalChange(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Existing"})
+
+	// Another process appends a ticket to tickets.jsonl.
+	paths := config.GetPaths(dir)
+	external, err := ticket.New("TH", "Added elsewhere", "", "", 1, nil, "", 0)
+	if err != nil {
+		t.Fatalf("ticket.New() error = %v", err)
+	}
+	if err := storage.AppendJSONL(paths, external); err != nil {
+		t.Fatalf("AppendJSONL() error = %v", err)
+	}
+
+	out, err := captureStdout(t, func() error {
+		return Sync([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	var resp SyncResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output = %q", err, out)
+	}
+	if !resp.Success || !resp.Rebuilt {
+		t.Errorf("Sync() = %+v, want success and rebuilt", resp)
+	}
+	if resp.Counts.Tickets != 2 {
+		t.Errorf("Counts.Tickets = %d, want 2", resp.Counts.Tickets)
+	}
+
+	store, err := storage.Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	got, err := store.Get(external.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.Title != "Added elsewhere" {
+		t.Errorf("Get() = %+v, want the externally added ticket", got)
+	}
+}
+
+func TestSync_UpToDate(t *testing.T) {
+	_, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Existing"})
+
+	out, err := captureStdout(t, func() error {
+		return Sync(nil)
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !strings.HasPrefix(out, "Cache is up to date\n1 tickets") {
+		t.Errorf("Sync() output = %q, want up-to-date report", out)
+	}
+}
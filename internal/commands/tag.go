@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// TagResponse is the JSON output of the tag and untag commands.
+type TagResponse struct {
+	Success bool     `json:"success"`
+	ID      string   `json:"id"`
+	Labels  []string `json:"labels"` // The ticket's labels after the change
+}
+
+// Tag adds labels to a ticket.
+func Tag(args []string) error {
+	return changeLabels("tag", args, true)
+}
+
+// Untag removes labels from a ticket.
+func Untag(args []string) error {
+	return changeLabels("untag", args, false)
+}
+
+// changeLabels implements tag and untag, which add or remove the labels
+// given after the ticket ID.
+func changeLabels(name string, args []string, add bool) error {
+	fs, jsonOutput, dataDir := newFlagSet(name)
+	usage := fmt.Sprintf("Usage: thicket %s <TICKET-ID> <LABEL>...", name)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage+" [--json] [--data-dir <DIR>]")
+		if add {
+			fmt.Fprintln(os.Stderr, "\nAdd labels to a ticket. Labels it already has are left alone.")
+		} else {
+			fmt.Fprintln(os.Stderr, "\nRemove labels from a ticket. Labels it does not have are ignored.")
+		}
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	if fs.NArg() < 2 {
+		return thickerr.WithHint("A ticket ID and at least one label are required", usage)
+	}
+
+	labels := fs.Args()[1:]
+	for _, l := range labels {
+		if err := ticket.ValidateLabel(l); err != nil {
+			return thickerr.WithHint(fmt.Sprintf("Invalid label: %s", l), "Labels must be 1-30 alphanumeric characters, hyphens, or underscores")
+		}
+	}
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	t, err := store.Get(ticketID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return thickerr.TicketNotFound(ticketID)
+	}
+
+	var addLabels, removeLabels []string
+	if add {
+		addLabels = labels
+	} else {
+		removeLabels = labels
+	}
+	if err := t.Update(nil, nil, nil, nil, nil, addLabels, removeLabels, nil, nil); err != nil {
+		return wrapTicketError(err)
+	}
+	t.UpdatedBy = config.ResolveIdentity()
+
+	if err := store.Update(t); err != nil {
+		return err
+	}
+
+	if *jsonOutput {
+		resp := TagResponse{Success: true, ID: t.ID, Labels: t.Labels}
+		if resp.Labels == nil {
+			resp.Labels = []string{}
+		}
+		return printJSON(resp)
+	}
+
+	current := strings.Join(t.Labels, ", ")
+	if current == "" {
+		current = "(none)"
+	}
+	fmt.Printf("Labels of %s: %s\n", t.ID, current)
+	return nil
+}
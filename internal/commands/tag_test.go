@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTagAndUntag(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Tagged", "--label", "backend"})
+	id := firstTicketID(t, dir)
+
+	if err := Tag([]string{id, "bug", "urgent", "backend"}); err != nil {
+		t.Fatalf("Tag() error = %v", err)
+	}
+	if got := strings.Join(ticketsByTitle(t, dir)["Tagged"].Labels, ","); got != "backend,bug,urgent" {
+		t.Errorf("Labels after tag = %s, want backend,bug,urgent", got)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Untag([]string{"--json", id, "urgent", "backend", "missing"})
+	})
+	if err != nil {
+		t.Fatalf("Untag() error = %v", err)
+	}
+	var resp TagResponse
+	if err := json.Unmarshal([]byte(output), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if !resp.Success || resp.ID != id || strings.Join(resp.Labels, ",") != "bug" {
+		t.Errorf("Untag() response = %+v, want only the bug label", resp)
+	}
+	if got := strings.Join(ticketsByTitle(t, dir)["Tagged"].Labels, ","); got != "bug" {
+		t.Errorf("Labels after untag = %s, want bug", got)
+	}
+}
+
+func TestTag_Errors(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Tagged"})
+	id := firstTicketID(t, dir)
+
+	if err := Tag([]string{id}); err == nil {
+		t.Error("Tag() without labels expected error")
+	}
+	if err := Tag([]string{id, "ok", "not valid"}); err == nil {
+		t.Error("Tag() with an invalid label expected error")
+	}
+	if got := ticketsByTitle(t, dir)["Tagged"].Labels; len(got) != 0 {
+		t.Errorf("Labels = %v, want none after a rejected tag", got)
+	}
+	if err := Untag([]string{"TH-ffffff", "bug"}); err == nil {
+		t.Error("Untag() of a missing ticket expected error")
+	}
+}
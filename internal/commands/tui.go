@@ -5,16 +5,27 @@ import (
 	"os"
 
 	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
 	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/tui"
+	"github.com/charmbracelet/x/term"
 )
 
-// TUI launches the interactive terminal UI.
+// isInteractive reports whether stdin and stdout are both terminals, which
+// the TUI needs. It is a variable so tests can simulate either case.
+var isInteractive = func() bool {
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
+
+// TUI launches the interactive terminal UI, or with --json and no terminal
+// prints the open tickets as 'list --json' does.
 func TUI(args []string) error {
-	fs, _, dataDir := newFlagSet("tui")
+	fs, jsonOutput, dataDir := newFlagSet("tui")
+	noTruncate := fs.Bool("no-truncate", false, "Show full titles in the list instead of cutting them to fit")
 	fs.Usage = func() {
 		fmt.Fprintln(os.Stderr, "Usage: thicket tui [flags]")
 		fmt.Fprintln(os.Stderr, "\nLaunch interactive terminal UI for managing tickets.")
+		fmt.Fprintln(os.Stderr, "Without a terminal, --json prints the open tickets instead.")
 		fmt.Fprintln(os.Stderr, "\nFlags:")
 		fs.PrintDefaults()
 		fmt.Fprintln(os.Stderr, "\nKey bindings:")
@@ -26,6 +37,7 @@ func TUI(args []string) error {
 		fmt.Fprintln(os.Stderr, "    n             Create new ticket")
 		fmt.Fprintln(os.Stderr, "    e             Edit selected ticket")
 		fmt.Fprintln(os.Stderr, "    c             Close selected ticket")
+		fmt.Fprintln(os.Stderr, "    y             Copy selected ticket's ID to the clipboard")
 		fmt.Fprintln(os.Stderr, "    +/=           Lower priority (increment priority value)")
 		fmt.Fprintln(os.Stderr, "    -/_           Higher priority (decrement priority value)")
 		fmt.Fprintln(os.Stderr, "    o/x/i/a       Filter: open/closed/icebox/all")
@@ -40,6 +52,7 @@ func TUI(args []string) error {
 		fmt.Fprintln(os.Stderr, "    +/=           Lower priority (increment priority value)")
 		fmt.Fprintln(os.Stderr, "    -/_           Higher priority (decrement priority value)")
 		fmt.Fprintln(os.Stderr, "    m             Add comment")
+		fmt.Fprintln(os.Stderr, "    y             Copy ticket ID to the clipboard")
 		fmt.Fprintln(os.Stderr, "    j/k, arrows   Scroll description/comments")
 		fmt.Fprintln(os.Stderr, "")
 		fmt.Fprintln(os.Stderr, "  Form view:")
@@ -55,6 +68,16 @@ func TUI(args []string) error {
 
 	handleGlobalFlags(*dataDir)
 
+	if !isInteractive() {
+		if *jsonOutput {
+			return List([]string{"--json", "--status", "open"})
+		}
+		return thickerr.WithHint(
+			"The TUI needs an interactive terminal, but stdin or stdout isn't one",
+			"Use 'thicket list' or 'thicket ready' in scripts and pipes, or 'thicket tui --json' to print the open tickets as JSON",
+		)
+	}
+
 	root, err := config.FindRoot()
 	if err != nil {
 		return wrapConfigError(err)
@@ -64,6 +87,7 @@ func TUI(args []string) error {
 	if err != nil {
 		return wrapConfigError(err)
 	}
+	applyConfig(cfg)
 
 	paths := config.GetPaths(root)
 	store, err := storage.Open(paths)
@@ -72,5 +96,5 @@ func TUI(args []string) error {
 	}
 	defer store.Close()
 
-	return tui.Run(store, cfg, paths.Tickets)
+	return tui.Run(store, cfg, paths.DataFiles(), tui.Options{NoTruncate: *noTruncate})
 }
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	thickerr "github.com/abarth/thicket/internal/errors"
+)
+
+func TestTUI_NotInteractive(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	old := isInteractive
+	isInteractive = func() bool { return false }
+	t.Cleanup(func() { isInteractive = old })
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Open ticket"})
+	Add([]string{"--title", "Closed ticket"})
+	if err := Close([]string{ticketsByTitle(t, dir)["Closed ticket"].ID}); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	err := TUI(nil)
+	var userErr *thickerr.UserError
+	if !errors.As(err, &userErr) {
+		t.Fatalf("TUI() error = %v, want a UserError", err)
+	}
+	if !strings.Contains(userErr.Message, "interactive terminal") || !strings.Contains(userErr.Hint, "thicket list") {
+		t.Errorf("TUI() error = %q (hint %q), want it to explain the terminal and suggest list", userErr.Message, userErr.Hint)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return TUI([]string{"--json"})
+	})
+	if err != nil {
+		t.Fatalf("TUI(--json) error = %v", err)
+	}
+	var tickets []TicketJSON
+	if err := json.Unmarshal([]byte(output), &tickets); err != nil {
+		t.Fatalf("Unmarshal() error = %v, output: %s", err, output)
+	}
+	if len(tickets) != 1 || tickets[0].Title != "Open ticket" {
+		t.Errorf("TUI(--json) = %+v, want only the open ticket", tickets)
+	}
+}
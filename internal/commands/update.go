@@ -3,7 +3,10 @@ package commands
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/abarth/thicket/internal/config"
 	thickerr "github.com/abarth/thicket/internal/errors"
@@ -11,19 +14,35 @@ import (
 	"github.com/abarth/thicket/internal/ticket"
 )
 
+// ValueChange is the old and new value of a field changed by update.
+type ValueChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// UpdateResponse is the JSON output of the update command.
+type UpdateResponse struct {
+	SuccessResponse
+	Changes map[string]ValueChange `json:"changes"` // Changed fields, keyed by name
+}
+
 // Update modifies an existing ticket.
 func Update(args []string) error {
 	fs, jsonOutput, dataDir := newFlagSet("update")
 	title := fs.String("title", "", "New title")
 	description := fs.String("description", "", "New description")
+	edit := fs.Bool("edit", false, "Edit the current description in $EDITOR")
 	issueType := fs.String("type", "", "New type")
 	priority := fs.Int("priority", -1, "New priority")
-	status := fs.String("status", "", "New status (open, closed)")
-	assignee := fs.String("assignee", "", "Assign ticket to person (use empty string to clear)")
+	status := fs.String("status", "", "New status (open, closed, icebox, deleted)")
+	assignee := fs.String("assignee", "", "Assign ticket to person (\"me\" for yourself, empty string to clear)")
+	estimate := fs.Int("estimate", 0, "New estimate in points (0 to clear)")
 	var addLabels labelSlice
 	var removeLabels labelSlice
 	fs.Var(&addLabels, "add-label", "Add a label (can be specified multiple times)")
 	fs.Var(&removeLabels, "remove-label", "Remove a label (can be specified multiple times)")
+	watch := fs.Bool("watch", false, "Add yourself to the ticket's watchers")
+	unwatch := fs.Bool("unwatch", false, "Remove yourself from the ticket's watchers")
 	fs.Usage = func() {
 		fmt.Fprintln(os.Stderr, "Usage: thicket update [flags] <TICKET-ID>")
 		fmt.Fprintln(os.Stderr, "\nUpdate an existing ticket. Only specified fields are changed.")
@@ -41,9 +60,8 @@ func Update(args []string) error {
 		return thickerr.WithHint("Ticket ID is required", "Usage: thicket update [flags] <TICKET-ID>")
 	}
 
-	ticketID := normalizeTicketID(fs.Arg(0))
-	if err := ticket.ValidateID(ticketID); err != nil {
-		return thickerr.InvalidTicketID(ticketID)
+	if *edit && *description != "" {
+		return thickerr.WithHint("Cannot combine --edit and --description", "Use one of --edit or --description")
 	}
 
 	root, err := config.FindRoot()
@@ -51,6 +69,12 @@ func Update(args []string) error {
 		return wrapConfigError(err)
 	}
 
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
 	paths := config.GetPaths(root)
 	store, err := storage.Open(paths)
 	if err != nil {
@@ -58,6 +82,11 @@ func Update(args []string) error {
 	}
 	defer store.Close()
 
+	ticketID, err := resolveTicketID(store, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
 	t, err := store.Get(ticketID)
 	if err != nil {
 		return err
@@ -72,6 +101,7 @@ func Update(args []string) error {
 	var priorityPtr *int
 	var statusPtr *ticket.Status
 	var assigneePtr *string
+	var estimatePtr *int
 
 	if *title != "" {
 		titlePtr = title
@@ -94,40 +124,129 @@ func Update(args []string) error {
 		statusPtr = &s
 	}
 
-	// Check if --assignee was explicitly provided (even if empty, to clear it)
-	assigneeSet := false
+	// Check if --assignee or --estimate was explicitly provided (even if
+	// empty or zero, to clear it)
+	assigneeSet, estimateSet := false, false
 	fs.Visit(func(f *flag.Flag) {
-		if f.Name == "assignee" {
+		switch f.Name {
+		case "assignee":
 			assigneeSet = true
+		case "estimate":
+			estimateSet = true
 		}
 	})
 	if assigneeSet {
-		assigneePtr = assignee
+		name, err := resolveAssignee(*assignee)
+		if err != nil {
+			return err
+		}
+		assigneePtr = &name
+	}
+	if estimateSet {
+		estimatePtr = estimate
+	}
+
+	if *watch && *unwatch {
+		return thickerr.New("Cannot use --watch and --unwatch together")
+	}
+	var watcher string
+	if *watch || *unwatch {
+		watcher, err = resolveAssignee("me")
+		if err != nil {
+			return err
+		}
 	}
 
-	if titlePtr == nil && descPtr == nil && typePtr == nil && priorityPtr == nil && statusPtr == nil && assigneePtr == nil && len(addLabels) == 0 && len(removeLabels) == 0 {
+	hasChanges := titlePtr != nil || descPtr != nil || typePtr != nil || priorityPtr != nil || statusPtr != nil || assigneePtr != nil || estimatePtr != nil || len(addLabels) > 0 || len(removeLabels) > 0 || watcher != ""
+	if !hasChanges && !*edit {
 		return thickerr.WithHint(
 			"No fields to update",
-			"Use --title, --description, --type, --priority, --status, --assignee, --add-label, or --remove-label to specify changes",
+			"Use --title, --description, --edit, --type, --priority, --status, --assignee, --estimate, --add-label, --remove-label, --watch, or --unwatch to specify changes",
 		)
 	}
 
-	if err := t.Update(titlePtr, descPtr, typePtr, priorityPtr, statusPtr, addLabels, removeLabels, assigneePtr); err != nil {
-		return err
+	if *edit {
+		edited, changed, err := editDescription(t)
+		if err != nil {
+			return err
+		}
+		if changed {
+			descPtr = &edited
+		} else if !hasChanges {
+			// Nothing else to change, so leave the ticket and its update
+			// time alone.
+			msg := fmt.Sprintf("Description of %s unchanged", t.ID)
+			if *jsonOutput {
+				return printJSON(SuccessResponse{Success: true, ID: t.ID, Message: msg})
+			}
+			fmt.Println(msg)
+			return nil
+		}
 	}
 
+	before := *t
+	before.Labels = slices.Clone(t.Labels)
+	wasClosed := t.Status == ticket.StatusClosed
+	if err := t.Update(titlePtr, descPtr, typePtr, priorityPtr, statusPtr, addLabels, removeLabels, assigneePtr, estimatePtr); err != nil {
+		return wrapTicketError(err)
+	}
+	if *watch {
+		t.Watch(watcher)
+	}
+	if *unwatch {
+		t.Unwatch(watcher)
+	}
+	t.UpdatedBy = config.ResolveIdentity()
+
 	if err := store.Update(t); err != nil {
 		return err
 	}
 
+	if !wasClosed && t.Status == ticket.StatusClosed {
+		runHook(root, cfg, config.HookOnClose, t)
+	}
+
+	changes := diffFields(&before, t)
 	if *jsonOutput {
-		return printJSON(SuccessResponse{
-			Success: true,
-			ID:      t.ID,
-			Message: fmt.Sprintf("Updated ticket %s", t.ID),
-		})
+		resp := UpdateResponse{
+			SuccessResponse: SuccessResponse{
+				Success: true,
+				ID:      t.ID,
+				Message: fmt.Sprintf("Updated ticket %s", t.ID),
+			},
+			Changes: make(map[string]ValueChange, len(changes)),
+		}
+		for _, c := range changes {
+			resp.Changes[c.Field] = ValueChange{Old: c.Old, New: c.New}
+		}
+		return printJSON(resp)
 	}
 
 	fmt.Printf("Updated ticket %s\n", t.ID)
+	printFieldChanges(os.Stdout, changes, useColor())
 	return nil
 }
+
+// printFieldChanges prints one aligned "field: old → new" line per change,
+// with the old value in red and the new one in green if color is set. Like
+// diff, it notes that the description changed without printing it.
+func printFieldChanges(w io.Writer, changes []FieldChange, color bool) {
+	width := 0
+	for _, c := range changes {
+		width = max(width, len(c.Field))
+	}
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return "\x1b[" + code + "m" + s + "\x1b[0m"
+	}
+	for _, c := range changes {
+		label := c.Field + ":" + strings.Repeat(" ", width-len(c.Field))
+		if c.Field == "description" {
+			fmt.Fprintf(w, "  %s changed\n", label)
+			continue
+		}
+		fmt.Fprintf(w, "  %s %s → %s\n", label, paint("31", formatDiffValue(c.Old)), paint("32", formatDiffValue(c.New)))
+	}
+}
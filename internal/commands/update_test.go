@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -108,3 +109,193 @@ func TestUpdate_NotFound(t *testing.T) {
 		t.Errorf("Update() error = %v, want error containing 'not found'", err)
 	}
 }
+
+func TestUpdate_AssigneeMe(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Test ticket"})
+	ticketID := firstTicketID(t, dir)
+
+	if err := Update([]string{"--assignee", "@me", ticketID}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	store, _ := storage.Open(config.GetPaths(dir))
+	defer store.Close()
+	tk, _ := store.Get(ticketID)
+	if tk.Assignee != "Alice" {
+		t.Errorf("Assignee = %q, want Alice", tk.Assignee)
+	}
+}
+
+func TestUpdate_Estimate(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Sized", "--estimate", "2"})
+	id := firstTicketID(t, dir)
+
+	if err := Update([]string{"--estimate", "5", id}); err != nil {
+		t.Fatalf("Update(--estimate 5) error = %v", err)
+	}
+	if got := ticketsByTitle(t, dir)["Sized"].Estimate; got != 5 {
+		t.Errorf("Estimate = %d, want 5", got)
+	}
+
+	if err := Update([]string{"--estimate", "0", id}); err != nil {
+		t.Fatalf("Update(--estimate 0) error = %v", err)
+	}
+	if got := ticketsByTitle(t, dir)["Sized"].Estimate; got != 0 {
+		t.Errorf("Estimate = %d, want 0 after clearing", got)
+	}
+
+	if err := Update([]string{"--estimate", "-1", id}); err == nil {
+		t.Error("Update(--estimate -1) expected error")
+	}
+}
+
+func TestUpdate_Watch(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+	t.Setenv(config.IdentityEnv, "Alice")
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	Add([]string{"--title", "Watched"})
+	id := firstTicketID(t, dir)
+
+	if err := Update([]string{"--watch", id}); err != nil {
+		t.Fatalf("Update(--watch) error = %v", err)
+	}
+	if got := ticketsByTitle(t, dir)["Watched"].Watchers; len(got) != 1 || got[0] != "Alice" {
+		t.Errorf("Watchers = %v, want [Alice]", got)
+	}
+
+	output, err := captureStdout(t, func() error {
+		return Show([]string{id})
+	})
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if !strings.Contains(output, "Watchers:    Alice\n") {
+		t.Errorf("Show() output should list watchers:\n%s", output)
+	}
+
+	if err := Update([]string{"--unwatch", id}); err != nil {
+		t.Fatalf("Update(--unwatch) error = %v", err)
+	}
+	if got := ticketsByTitle(t, dir)["Watched"].Watchers; len(got) != 0 {
+		t.Errorf("Watchers = %v, want none after --unwatch", got)
+	}
+
+	if err := Update([]string{"--watch", "--unwatch", id}); err == nil {
+		t.Error("Update(--watch --unwatch) expected error")
+	}
+}
+
+func TestUpdate_Edit(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	Add([]string{"--title", "Task", "--description", "Old description"})
+	ticketID := firstTicketID(t, dir)
+
+	stubEditor(t, "New description")
+	if err := Update([]string{"--edit", "--priority", "1", ticketID}); err != nil {
+		t.Fatalf("Update(--edit) error = %v", err)
+	}
+	tk := ticketsByTitle(t, dir)["Task"]
+	if tk.Description != "New description" {
+		t.Errorf("Description = %q, want 'New description'", tk.Description)
+	}
+	if tk.Priority != 1 {
+		t.Errorf("Priority = %d, want 1", tk.Priority)
+	}
+
+	// Saving the description unchanged leaves the ticket alone.
+	t.Setenv("EDITOR", "true")
+	output, err := captureStdout(t, func() error {
+		return Update([]string{"--edit", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Update(--edit) unchanged error = %v", err)
+	}
+	if !strings.Contains(output, "unchanged") {
+		t.Errorf("Update(--edit) unchanged output = %q", output)
+	}
+	if got := ticketsByTitle(t, dir)["Task"]; !got.Updated.Equal(tk.Updated) {
+		t.Errorf("Updated = %v, want unchanged %v", got.Updated, tk.Updated)
+	}
+
+	// An empty save is rejected.
+	stubEditor(t, "")
+	if err := Update([]string{"--edit", ticketID}); err == nil {
+		t.Error("Update(--edit) expected error for empty description")
+	}
+	if got := ticketsByTitle(t, dir)["Task"].Description; got != "New description" {
+		t.Errorf("Description = %q, want unchanged 'New description'", got)
+	}
+
+	if err := Update([]string{"--edit", "--description", "x", ticketID}); err == nil {
+		t.Error("Update(--edit --description) expected error")
+	}
+}
+
+func TestUpdate_ChangeSummary(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := Add([]string{"--title", "Summary", "--priority", "2"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	ticketID := firstTicketID(t, dir)
+
+	out, err := captureStdout(t, func() error {
+		return Update([]string{"--priority", "1", "--status", "icebox", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	want := "Updated ticket " + ticketID + "\n  status:   open → icebox\n  priority: 2 → 1\n"
+	if out != want {
+		t.Errorf("Update() output = %q, want %q", out, want)
+	}
+
+	out, err = captureStdout(t, func() error {
+		return Update([]string{"--json", "--title", "Renamed", ticketID})
+	})
+	if err != nil {
+		t.Fatalf("Update(--json) error = %v", err)
+	}
+	var resp UpdateResponse
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v\n%s", err, out)
+	}
+	if len(resp.Changes) != 1 || resp.Changes["title"] != (ValueChange{Old: "Summary", New: "Renamed"}) {
+		t.Errorf("changes = %v, want only title Summary → Renamed", resp.Changes)
+	}
+
+	var buf strings.Builder
+	printFieldChanges(&buf, []FieldChange{{Field: "priority", Old: "2", New: "1"}}, true)
+	if got := buf.String(); got != "  priority: \x1b[31m2\x1b[0m → \x1b[32m1\x1b[0m\n" {
+		t.Errorf("colored output = %q", got)
+	}
+}
@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	thickerr "github.com/abarth/thicket/internal/errors"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+	"github.com/abarth/thicket/internal/tui"
+)
+
+// defaultWatchDebounce is how long watch waits after a change for further
+// changes before reacting, so that a command that rewrites several files is
+// reported once.
+const defaultWatchDebounce = 500 * time.Millisecond
+
+// WatchChange describes one debounced change to the project's tickets.
+type WatchChange struct {
+	Changed []string `json:"changed"` // IDs of tickets added, edited, or removed
+	Tickets int      `json:"tickets"` // Tickets in the project after the change
+}
+
+// Watch waits for the project's data files to change and reports each change,
+// or runs a command for it with --exec, until interrupted.
+func Watch(args []string) error {
+	fs, jsonOutput, dataDir := newFlagSet("watch")
+	command := fs.String("exec", "", "Shell command to run after each change")
+	debounce := fs.Duration("debounce", defaultWatchDebounce, "How long to wait for further changes before reacting")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: thicket watch [--exec <CMD>] [--debounce <DURATION>] [--json]")
+		fmt.Fprintln(os.Stderr, "\nWatch the project's tickets and report each change until interrupted.")
+		fmt.Fprintln(os.Stderr, "With --exec, run a shell command in the project root after each change")
+		fmt.Fprintln(os.Stderr, "instead. The command's environment includes:")
+		fmt.Fprintln(os.Stderr, "  THICKET_EVENT            Always \"change\"")
+		fmt.Fprintln(os.Stderr, "  THICKET_CHANGED_TICKETS  Comma-separated IDs of tickets added, edited, or removed")
+		fmt.Fprintln(os.Stderr, "  THICKET_TICKET_COUNT     Number of tickets after the change")
+		fmt.Fprintln(os.Stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *debounce <= 0 {
+		return thickerr.WithHint("--debounce must be positive", "Use a duration like 500ms or 2s")
+	}
+
+	handleGlobalFlags(*dataDir)
+
+	root, err := config.FindRoot()
+	if err != nil {
+		return wrapConfigError(err)
+	}
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return wrapConfigError(err)
+	}
+	applyConfig(cfg)
+
+	paths := config.GetPaths(root)
+	store, err := storage.Open(paths)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	changes, cleanup := tui.WatchFiles(paths.DataFiles(), *debounce)()
+	defer cleanup()
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	if !*jsonOutput {
+		fmt.Fprintf(os.Stderr, "Watching %s for changes (Ctrl+C to stop)\n", root)
+	}
+	return watchTickets(store, changes, stop, func(change WatchChange) {
+		switch {
+		case *command != "":
+			runWatchCommand(root, *command, change)
+		case *jsonOutput:
+			json.NewEncoder(os.Stdout).Encode(change)
+		case len(change.Changed) == 0:
+			fmt.Printf("%s Tickets changed (comments or links only)\n", time.Now().Format(time.TimeOnly))
+		default:
+			fmt.Printf("%s Tickets changed: %s\n", time.Now().Format(time.TimeOnly), strings.Join(change.Changed, ", "))
+		}
+	})
+}
+
+// watchTickets calls onChange for each debounced notification on changes
+// until stop is closed, after bringing the cache up to date and working out
+// which tickets changed.
+func watchTickets(store *storage.Store, changes <-chan tui.FileChangedMsg, stop <-chan struct{}, onChange func(WatchChange)) error {
+	before, err := ticketSnapshot(store)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case _, ok := <-changes:
+			if !ok {
+				return errors.New("watching the data files stopped unexpectedly")
+			}
+		}
+
+		if err := store.SyncFromJSONL(); err != nil {
+			return err
+		}
+		after, err := ticketSnapshot(store)
+		if err != nil {
+			return err
+		}
+		onChange(WatchChange{Changed: changedTickets(before, after), Tickets: len(after)})
+		before = after
+	}
+}
+
+// ticketSnapshot returns every ticket in the store, keyed by ID.
+func ticketSnapshot(store *storage.Store) (map[string]*ticket.Ticket, error) {
+	tickets, err := store.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(map[string]*ticket.Ticket, len(tickets))
+	for _, t := range tickets {
+		snapshot[t.ID] = t
+	}
+	return snapshot, nil
+}
+
+// changedTickets returns the sorted IDs of tickets that differ between two
+// snapshots.
+func changedTickets(before, after map[string]*ticket.Ticket) []string {
+	changed := []string{}
+	for id, t := range after {
+		if old, ok := before[id]; !ok || !reflect.DeepEqual(old, t) {
+			changed = append(changed, id)
+		}
+	}
+	for id := range before {
+		if _, ok := after[id]; !ok {
+			changed = append(changed, id)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// runWatchCommand runs command in the project root with the change described
+// in its environment. Like a hook, a failing command prints a warning but
+// doesn't stop the watch.
+func runWatchCommand(root, command string, change WatchChange) {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(),
+		"THICKET_EVENT=change",
+		"THICKET_CHANGED_TICKETS="+strings.Join(change.Changed, ","),
+		"THICKET_TICKET_COUNT="+strconv.Itoa(change.Tickets),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: watch command failed: %v\n", err)
+	}
+}
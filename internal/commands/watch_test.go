@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/tui"
+)
+
+func TestWatch_Exec(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := config.GetPaths(dir)
+	store, err := storage.Open(paths)
+	if err != nil {
+		t.Fatalf("storage.Open() error = %v", err)
+	}
+	defer store.Close()
+
+	const debounce = 200 * time.Millisecond
+	changes, stopWatching := tui.WatchFiles(paths.DataFiles(), debounce)()
+	defer stopWatching()
+
+	runs := filepath.Join(dir, "runs.txt")
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- watchTickets(store, changes, stop, func(change WatchChange) {
+			runWatchCommand(dir, `echo "$THICKET_TICKET_COUNT $THICKET_CHANGED_TICKETS" >> runs.txt`, change)
+		})
+	}()
+
+	readRuns := func() []string {
+		data, err := os.ReadFile(runs)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+
+	// Several rapid writes are one change.
+	for _, title := range []string{"One", "Two", "Three"} {
+		if err := Add([]string{"--title", title}); err != nil {
+			t.Fatalf("Add(%s) error = %v", title, err)
+		}
+	}
+	time.Sleep(4 * debounce)
+	lines := readRuns()
+	if len(lines) != 1 {
+		t.Fatalf("command ran %d times, want 1: %q", len(lines), lines)
+	}
+	if fields := strings.Fields(lines[0]); len(fields) != 2 || fields[0] != "3" || strings.Count(fields[1], ",") != 2 {
+		t.Errorf("first run saw %q, want 3 tickets with 3 changed IDs", lines[0])
+	}
+
+	ticketID := ticketsByTitle(t, dir)["Two"].ID
+	if err := Close([]string{ticketID}); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	time.Sleep(4 * debounce)
+	lines = readRuns()
+	if len(lines) != 2 {
+		t.Fatalf("command ran %d times, want 2: %q", len(lines), lines)
+	}
+	if want := "3 " + ticketID; lines[1] != want {
+		t.Errorf("second run saw %q, want %q", lines[1], want)
+	}
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("watchTickets() error = %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Webhook events.
+const (
+	webhookCreate  = "create"
+	webhookUpdate  = "update"
+	webhookClose   = "close"
+	webhookComment = "comment"
+)
+
+// Webhook delivery settings.
+const (
+	webhookTimeout = 10 * time.Second
+	webhookRetries = 3
+	webhookBackoff = 500 * time.Millisecond
+)
+
+// webhookPayload is the JSON body posted to a webhook.
+type webhookPayload struct {
+	Event   string          `json:"event"`
+	Time    time.Time       `json:"time"`
+	Ticket  *TicketJSON     `json:"ticket"`
+	Comment *ticket.Comment `json:"comment,omitempty"` // Set for comment events
+}
+
+// webhook posts ticket events to a URL. Delivery is best-effort: events are
+// posted in the background one at a time, in the order they were sent, each
+// retried with exponential backoff if the request fails or the receiver
+// returns a server error, and dropped with a warning if every attempt fails.
+type webhook struct {
+	url     string
+	client  *http.Client
+	retries int
+	backoff time.Duration
+	log     io.Writer
+
+	mu      sync.Mutex
+	queue   []webhookDelivery // Events waiting to be posted
+	running bool              // Whether a goroutine is posting the queue
+	wg      sync.WaitGroup    // Counts events not yet delivered or dropped
+}
+
+// webhookDelivery is an encoded event waiting to be posted.
+type webhookDelivery struct {
+	event    string
+	ticketID string
+	body     []byte
+}
+
+// newWebhook returns a webhook that posts to url.
+func newWebhook(url string) *webhook {
+	return &webhook{
+		url:     url,
+		client:  &http.Client{Timeout: webhookTimeout},
+		retries: webhookRetries,
+		backoff: webhookBackoff,
+		log:     os.Stderr,
+	}
+}
+
+// send queues payload to be posted after every event sent before it. It does
+// nothing for a nil webhook, so callers needn't check whether one is
+// configured.
+func (h *webhook) send(payload webhookPayload) {
+	if h == nil {
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Fprintf(h.log, "Warning: encoding %s webhook for %s: %v\n", payload.Event, payload.Ticket.ID, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.wg.Add(1)
+	h.queue = append(h.queue, webhookDelivery{event: payload.Event, ticketID: payload.Ticket.ID, body: body})
+	if !h.running {
+		h.running = true
+		go h.run()
+	}
+}
+
+// run posts queued events in order until the queue is empty.
+func (h *webhook) run() {
+	for {
+		h.mu.Lock()
+		if len(h.queue) == 0 {
+			h.running = false
+			h.mu.Unlock()
+			return
+		}
+		d := h.queue[0]
+		h.queue = h.queue[1:]
+		h.mu.Unlock()
+
+		if err := h.deliver(d.body); err != nil {
+			fmt.Fprintf(h.log, "Warning: %s webhook for %s failed: %v\n", d.event, d.ticketID, err)
+		}
+		h.wg.Done()
+	}
+}
+
+// pending returns the number of events not yet delivered or dropped.
+func (h *webhook) pending() int {
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n := len(h.queue)
+	if h.running {
+		n++ // The event being posted
+	}
+	return n
+}
+
+// wait blocks until every event sent so far has been delivered or dropped.
+func (h *webhook) wait() {
+	if h != nil {
+		h.wg.Wait()
+	}
+}
+
+// deliver posts body, retrying after failures that may be temporary.
+func (h *webhook) deliver(body []byte) error {
+	delay := h.backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		if retry, err = h.post(body); err == nil || !retry || attempt == h.retries {
+			return err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// post makes a single delivery attempt and reports whether a failure is
+// worth retrying. Client errors such as 404 are not.
+func (h *webhook) post(body []byte) (retry bool, err error) {
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode >= 500, fmt.Errorf("%s responded %s", h.url, resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// webhookReceiver records the payloads posted to it. Each request is
+// answered with the next of statuses, or 200 once they run out.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests int
+	payloads []webhookPayload
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+
+	rcv.requests++
+	if len(rcv.statuses) > 0 {
+		status := rcv.statuses[0]
+		rcv.statuses = rcv.statuses[1:]
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+	}
+
+	var p webhookPayload
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &p); err == nil {
+		rcv.payloads = append(rcv.payloads, p)
+	}
+}
+
+// newTestWebhook returns a webhook that posts to a new receiver, without
+// delays between retries, and a buffer holding its warnings.
+func newTestWebhook(t *testing.T, statuses ...int) (*webhook, *webhookReceiver, *bytes.Buffer) {
+	t.Helper()
+	rcv := &webhookReceiver{statuses: statuses}
+	ts := httptest.NewServer(rcv)
+	t.Cleanup(ts.Close)
+
+	var log bytes.Buffer
+	h := newWebhook(ts.URL)
+	h.backoff = 0
+	h.log = &log
+	return h, rcv, &log
+}
+
+// testPayload returns a create event payload for an example ticket.
+func testPayload() webhookPayload {
+	return webhookPayload{Event: webhookCreate, Ticket: &TicketJSON{Ticket: &ticket.Ticket{ID: "TH-abc123"}}}
+}
+
+func TestServe_Webhook(t *testing.T) {
+	dir, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	if err := Init([]string{"--project", "TH"}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	hook, rcv, _ := newTestWebhook(t)
+	srv := newTestServer(t, dir)
+	srv.Config.Handler.(*server).hook = hook
+
+	var created TicketJSON
+	doJSON(t, srv, "POST", "/tickets", `{"title": "Notify me"}`, &created)
+	doJSON(t, srv, "PATCH", "/tickets/"+created.ID, `{"priority": 1}`, &TicketJSON{})
+	doJSON(t, srv, "POST", "/tickets/"+created.ID+"/comments", `{"content": "On it"}`, &struct{}{})
+	doJSON(t, srv, "POST", "/tickets/"+created.ID+"/close", `{"reason": "done"}`, &TicketJSON{})
+	hook.wait()
+
+	if len(rcv.payloads) != 4 {
+		t.Fatalf("webhook received %d payloads, want 4: %+v", len(rcv.payloads), rcv.payloads)
+	}
+	for i, want := range []string{webhookCreate, webhookUpdate, webhookComment, webhookClose} {
+		p := rcv.payloads[i]
+		if p.Event != want {
+			t.Errorf("payload %d event = %q, want %q", i, p.Event, want)
+		}
+		if p.Ticket == nil || p.Ticket.ID != created.ID {
+			t.Errorf("payload %d ticket = %+v, want %s", i, p.Ticket, created.ID)
+		}
+		if p.Time.IsZero() {
+			t.Errorf("payload %d has no time", i)
+		}
+	}
+	if p := rcv.payloads[1]; p.Ticket.Priority != 1 {
+		t.Errorf("update payload priority = %d, want 1", p.Ticket.Priority)
+	}
+	if p := rcv.payloads[2]; p.Comment == nil || p.Comment.Content != "On it" {
+		t.Errorf("comment payload comment = %+v", p.Comment)
+	}
+	if p := rcv.payloads[3]; p.Ticket.Status != "closed" || p.Ticket.CloseReason != "done" {
+		t.Errorf("close payload ticket = %+v", p.Ticket.Ticket)
+	}
+}
+
+func TestWebhook_Retries(t *testing.T) {
+	hook, rcv, log := newTestWebhook(t, http.StatusBadGateway, http.StatusServiceUnavailable)
+	hook.send(testPayload())
+	hook.wait()
+
+	if rcv.requests != 3 || len(rcv.payloads) != 1 {
+		t.Errorf("requests = %d, payloads = %d; want 3 and 1", rcv.requests, len(rcv.payloads))
+	}
+	if log.Len() != 0 {
+		t.Errorf("unexpected warning: %s", log)
+	}
+}
+
+func TestWebhook_GivesUp(t *testing.T) {
+	hook, rcv, log := newTestWebhook(t, 500, 500, 500, 500, 500)
+	hook.send(testPayload())
+	hook.wait()
+
+	if rcv.requests != webhookRetries+1 {
+		t.Errorf("requests = %d, want %d", rcv.requests, webhookRetries+1)
+	}
+	if !strings.Contains(log.String(), "create webhook") {
+		t.Errorf("warning = %q, want a failed create webhook warning", log)
+	}
+
+	// Client errors are not retried.
+	hook, rcv, log = newTestWebhook(t, http.StatusNotFound)
+	hook.send(testPayload())
+	hook.wait()
+	if rcv.requests != 1 || !strings.Contains(log.String(), "404") {
+		t.Errorf("requests = %d, warning = %q; want 1 request and a 404 warning", rcv.requests, log)
+	}
+}
+
+func TestWebhook_Order(t *testing.T) {
+	// The first event needs a retry, so it would arrive last if events were
+	// posted concurrently.
+	hook, rcv, log := newTestWebhook(t, http.StatusBadGateway)
+	ids := []string{"TH-aaaaaa", "TH-bbbbbb", "TH-cccccc"}
+	for _, id := range ids {
+		p := testPayload()
+		p.Ticket = &TicketJSON{Ticket: &ticket.Ticket{ID: id}}
+		hook.send(p)
+	}
+	hook.wait()
+
+	if hook.pending() != 0 {
+		t.Errorf("pending() = %d after wait, want 0", hook.pending())
+	}
+	if len(rcv.payloads) != len(ids) {
+		t.Fatalf("webhook received %d payloads, want %d (warnings: %s)", len(rcv.payloads), len(ids), log)
+	}
+	for i, id := range ids {
+		if got := rcv.payloads[i].Ticket.ID; got != id {
+			t.Errorf("payload %d ticket = %s, want %s", i, got, id)
+		}
+	}
+}
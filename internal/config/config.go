@@ -13,6 +13,8 @@ import (
 
 var (
 	dataDirOverride string
+	projectRoot     string
+	noWalk          bool
 )
 
 // SetDataDir sets a custom directory for Thicket data.
@@ -21,6 +23,18 @@ func SetDataDir(dir string) {
 	dataDirOverride = dir
 }
 
+// SetProjectRoot selects the project whose .thicket directory is directly
+// inside dir, instead of searching from the current directory.
+func SetProjectRoot(dir string) {
+	projectRoot = dir
+}
+
+// SetNoWalk controls whether FindRoot searches parent directories.
+// When enabled, the .thicket directory must be in the starting directory itself.
+func SetNoWalk(enabled bool) {
+	noWalk = enabled
+}
+
 func getDataDir() string {
 	if dataDirOverride != "" {
 		return dataDirOverride
@@ -33,17 +47,136 @@ const (
 	ConfigFile  = "config.json"
 	TicketsFile = "tickets.jsonl"
 	CacheFile   = "cache.db"
+
+	// CommentsFile and DependenciesFile hold comments and dependencies in the
+	// split file layout. Their presence in the data directory selects it.
+	CommentsFile     = "comments.jsonl"
+	DependenciesFile = "deps.jsonl"
+
+	// GzipExt marks a gzip-compressed data file, such as tickets.jsonl.gz.
+	// A compressed file is used only when the uncompressed one is absent.
+	GzipExt = ".gz"
 )
 
 var (
-	ErrNotInitialized  = errors.New("thicket not initialized in this directory (run 'thicket init')")
-	ErrAlreadyInit     = errors.New("thicket already initialized in this directory")
-	ErrNoProjectCode   = errors.New("project code is required")
+	ErrNotInitialized = errors.New("thicket not initialized in this directory (run 'thicket init')")
+	ErrAlreadyInit    = errors.New("thicket already initialized in this directory")
+	ErrNoProjectCode  = errors.New("project code is required")
 )
 
 // Config represents the Thicket project configuration.
 type Config struct {
-	ProjectCode string `json:"project_code"`
+	ProjectCode          string              `json:"project_code"`
+	PriorityLabels       map[int]string      `json:"priority_labels,omitempty"`
+	MaxTitleLength       int                 `json:"max_title_length,omitempty"`
+	MaxDescriptionLength int                 `json:"max_description_length,omitempty"`
+	MaxCommentLength     int                 `json:"max_comment_length,omitempty"`
+	CommentWarningCount  int                 `json:"comment_warning_count,omitempty"`
+	PriorityMax          int                 `json:"priority_max,omitempty"`
+	WrapWidth            int                 `json:"wrap_width,omitempty"`
+	TimeFormat           string              `json:"time_format,omitempty"`
+	DefaultListStatus    string              `json:"default_list_status,omitempty"` // Status list shows without --status
+	SeverityThresholds   *SeverityThresholds `json:"severity_thresholds,omitempty"`
+	Hooks                *Hooks              `json:"hooks,omitempty"`
+	WebhookURL           string              `json:"webhook_url,omitempty"` // Receives ticket events from thicket serve
+}
+
+// DefaultPriorityLabels are the human-friendly priority names used when the
+// config does not define its own priority_labels.
+var DefaultPriorityLabels = map[int]string{
+	0: "Critical",
+	1: "High",
+	2: "Medium",
+	3: "Low",
+}
+
+// PriorityLabel returns the human-friendly label for a priority value.
+// It returns an empty string if no label is defined for the priority.
+func (c *Config) PriorityLabel(priority int) string {
+	labels := DefaultPriorityLabels
+	if c != nil && len(c.PriorityLabels) > 0 {
+		labels = c.PriorityLabels
+	}
+	return labels[priority]
+}
+
+// DefaultCommentWarningCount is how many comments a ticket can have before
+// adding another prints a warning, unless the config sets comment_warning_count.
+const DefaultCommentWarningCount = 100
+
+// CommentWarningThreshold returns the number of comments a ticket can have
+// before adding more prints a warning.
+func (c *Config) CommentWarningThreshold() int {
+	if c != nil && c.CommentWarningCount > 0 {
+		return c.CommentWarningCount
+	}
+	return DefaultCommentWarningCount
+}
+
+// Severity levels derived from ticket priorities.
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityNormal   = "normal"
+	SeverityLow      = "low"
+)
+
+// SeverityThresholds maps priorities to severities. A priority at or below
+// Critical is critical, at or below High is high, at or below Normal is
+// normal, and anything above Normal is low.
+type SeverityThresholds struct {
+	Critical int `json:"critical"`
+	High     int `json:"high"`
+	Normal   int `json:"normal"`
+}
+
+// DefaultSeverityThresholds are used when the config does not define its own
+// severity_thresholds.
+var DefaultSeverityThresholds = SeverityThresholds{Critical: 0, High: 1, Normal: 2}
+
+// Severity returns the severity for a priority value.
+func (c *Config) Severity(priority int) string {
+	thresholds := DefaultSeverityThresholds
+	if c != nil && c.SeverityThresholds != nil {
+		thresholds = *c.SeverityThresholds
+	}
+	switch {
+	case priority <= thresholds.Critical:
+		return SeverityCritical
+	case priority <= thresholds.High:
+		return SeverityHigh
+	case priority <= thresholds.Normal:
+		return SeverityNormal
+	default:
+		return SeverityLow
+	}
+}
+
+// Hook events.
+const (
+	HookOnCreate = "on_create"
+	HookOnClose  = "on_close"
+)
+
+// Hooks maps ticket events to shell commands that run after the event.
+type Hooks struct {
+	OnCreate string `json:"on_create,omitempty"`
+	OnClose  string `json:"on_close,omitempty"`
+}
+
+// HookCommand returns the shell command configured for event, or an empty
+// string if there is none.
+func (c *Config) HookCommand(event string) string {
+	if c == nil || c.Hooks == nil {
+		return ""
+	}
+	switch event {
+	case HookOnCreate:
+		return c.Hooks.OnCreate
+	case HookOnClose:
+		return c.Hooks.OnClose
+	}
+	return ""
 }
 
 // Paths holds the resolved paths for Thicket files.
@@ -53,9 +186,29 @@ type Paths struct {
 	Config  string // config.json path
 	Tickets string // tickets.jsonl path
 	Cache   string // cache.db path
+
+	// Comments and Dependencies are set only in the split file layout, where
+	// each record type has its own file. Otherwise all records are in Tickets.
+	Comments     string // comments.jsonl path
+	Dependencies string // deps.jsonl path
+}
+
+// Split reports whether the project uses the split file layout.
+func (p Paths) Split() bool {
+	return p.Comments != ""
+}
+
+// DataFiles returns the paths of the JSONL files that hold the project's records.
+func (p Paths) DataFiles() []string {
+	if !p.Split() {
+		return []string{p.Tickets}
+	}
+	return []string{p.Tickets, p.Comments, p.Dependencies}
 }
 
 // FindRoot locates the Thicket root directory by searching upward from the current directory.
+// A project root set with SetProjectRoot is used as-is, and SetNoWalk limits the
+// search to the current directory.
 func FindRoot() (string, error) {
 	dataDir := getDataDir()
 	if dataDir != "" {
@@ -67,25 +220,41 @@ func FindRoot() (string, error) {
 		return filepath.Dir(abs), nil
 	}
 
+	if projectRoot != "" {
+		dir, err := filepath.Abs(projectRoot)
+		if err != nil {
+			return "", fmt.Errorf("getting absolute path for project root: %w", err)
+		}
+		if !hasThicketDir(dir) {
+			return "", ErrNotInitialized
+		}
+		return dir, nil
+	}
+
 	dir, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("getting working directory: %w", err)
 	}
 
 	for {
-		thicketDir := filepath.Join(dir, ThicketDir)
-		if info, err := os.Stat(thicketDir); err == nil && info.IsDir() {
+		if hasThicketDir(dir) {
 			return dir, nil
 		}
 
 		parent := filepath.Dir(dir)
-		if parent == dir {
+		if noWalk || parent == dir {
 			return "", ErrNotInitialized
 		}
 		dir = parent
 	}
 }
 
+// hasThicketDir reports whether dir contains a .thicket directory.
+func hasThicketDir(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ThicketDir))
+	return err == nil && info.IsDir()
+}
+
 // GetPaths returns the paths for all Thicket files relative to the given root.
 func GetPaths(root string) Paths {
 	var dir string
@@ -96,20 +265,61 @@ func GetPaths(root string) Paths {
 	} else {
 		dir = filepath.Join(root, ThicketDir)
 	}
+	return newPaths(root, dir)
+}
 
-	return Paths{
+// ProjectPaths returns the paths for the .thicket directory directly under
+// root, ignoring any data directory set with SetDataDir or THICKET_DIR.
+func ProjectPaths(root string) Paths {
+	return newPaths(root, filepath.Join(root, ThicketDir))
+}
+
+// newPaths returns the paths for the data directory dir of the project at root.
+func newPaths(root, dir string) Paths {
+	paths := Paths{
 		Root:    root,
 		Dir:     dir,
 		Config:  filepath.Join(dir, ConfigFile),
-		Tickets: filepath.Join(dir, TicketsFile),
+		Tickets: dataFile(dir, TicketsFile),
 		Cache:   filepath.Join(dir, CacheFile),
 	}
+	for _, name := range []string{CommentsFile, DependenciesFile} {
+		if fileExists(filepath.Join(dir, name)) || fileExists(filepath.Join(dir, name+GzipExt)) {
+			paths.setSplit()
+			break
+		}
+	}
+	return paths
+}
+
+// setSplit switches p to the split file layout.
+func (p *Paths) setSplit() {
+	p.Comments = dataFile(p.Dir, CommentsFile)
+	p.Dependencies = dataFile(p.Dir, DependenciesFile)
+}
+
+// dataFile returns the path of the named data file in dir, which is the
+// gzip-compressed variant if only that one exists.
+func dataFile(dir, name string) string {
+	path := filepath.Join(dir, name)
+	if !fileExists(path) && fileExists(path+GzipExt) {
+		return path + GzipExt
+	}
+	return path
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 // Load reads the configuration from the given root directory.
 func Load(root string) (*Config, error) {
-	paths := GetPaths(root)
+	return LoadPaths(GetPaths(root))
+}
 
+// LoadPaths reads the configuration from paths.Config.
+func LoadPaths(paths Paths) (*Config, error) {
 	data, err := os.ReadFile(paths.Config)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -128,6 +338,16 @@ func Load(root string) (*Config, error) {
 
 // Init initializes a new Thicket project in the given directory.
 func Init(root, projectCode string) error {
+	return initProject(root, projectCode, false)
+}
+
+// InitSplit initializes a new Thicket project that uses the split file
+// layout, keeping comments and dependencies in files of their own.
+func InitSplit(root, projectCode string) error {
+	return initProject(root, projectCode, true)
+}
+
+func initProject(root, projectCode string, split bool) error {
 	if err := ticket.ValidateProjectCode(projectCode); err != nil {
 		return err
 	}
@@ -138,14 +358,48 @@ func Init(root, projectCode string) error {
 	if _, err := os.Stat(paths.Dir); err == nil {
 		return ErrAlreadyInit
 	}
+	if split {
+		paths.setSplit()
+	}
+
+	return writeProject(paths, Config{ProjectCode: projectCode}, true)
+}
+
+// Reinit rewrites the configuration of the project at root with the given
+// project code, creating the .thicket directory if needed. Other settings
+// are kept if the existing config can be read, and reset otherwise. Existing
+// tickets are kept unless wipe is set, in which case the project starts out
+// empty. The file layout is kept. The SQLite cache is always removed so that
+// it is rebuilt from the tickets file on next use.
+func Reinit(root, projectCode string, wipe bool) error {
+	if err := ticket.ValidateProjectCode(projectCode); err != nil {
+		return err
+	}
+
+	paths := GetPaths(root)
+
+	if err := os.Remove(paths.Cache); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing cache: %w", err)
+	}
 
+	cfg := Config{}
+	if existing, err := LoadPaths(paths); err == nil {
+		cfg = *existing
+	}
+	cfg.ProjectCode = projectCode
+
+	return writeProject(paths, cfg, wipe)
+}
+
+// writeProject writes cfg and a .gitignore into paths.Dir. The data files are
+// emptied if resetTickets is set and created if they do not exist.
+func writeProject(paths Paths, cfg Config, resetTickets bool) error {
 	// Create .thicket directory
 	if err := os.MkdirAll(paths.Dir, 0755); err != nil {
 		return fmt.Errorf("creating thicket directory: %w", err)
 	}
 
 	// Write config
-	cfg := Config{ProjectCode: projectCode}
 	data, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return fmt.Errorf("encoding config: %w", err)
@@ -154,9 +408,13 @@ func Init(root, projectCode string) error {
 		return fmt.Errorf("writing config: %w", err)
 	}
 
-	// Create empty tickets file
-	if err := os.WriteFile(paths.Tickets, []byte{}, 0644); err != nil {
-		return fmt.Errorf("creating tickets file: %w", err)
+	// Create empty data files
+	for _, file := range paths.DataFiles() {
+		if _, err := os.Stat(file); resetTickets || os.IsNotExist(err) {
+			if err := os.WriteFile(file, []byte{}, 0644); err != nil {
+				return fmt.Errorf("creating %s: %w", filepath.Base(file), err)
+			}
+		}
 	}
 
 	// Create .gitignore for cache
@@ -26,6 +26,20 @@ func TestGetPaths(t *testing.T) {
 	}
 }
 
+func TestProjectPaths_IgnoresDataDir(t *testing.T) {
+	t.Setenv("THICKET_DIR", t.TempDir())
+	SetDataDir(t.TempDir())
+	defer SetDataDir("")
+
+	paths := ProjectPaths("/project")
+	if paths.Dir != "/project/.thicket" {
+		t.Errorf("Dir = %q, want /project/.thicket", paths.Dir)
+	}
+	if paths.Tickets != "/project/.thicket/tickets.jsonl" {
+		t.Errorf("Tickets = %q, want /project/.thicket/tickets.jsonl", paths.Tickets)
+	}
+}
+
 func TestInit(t *testing.T) {
 	dir := t.TempDir()
 
@@ -167,6 +181,90 @@ func TestFindRoot_NotInitialized(t *testing.T) {
 	}
 }
 
+func TestFindRoot_NoWalk(t *testing.T) {
+	dir := t.TempDir()
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatalf("EvalSymlinks() error = %v", err)
+	}
+
+	if err := Init(dir, "TH"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	nested := filepath.Join(dir, "a")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	defer SetNoWalk(false)
+
+	if err := os.Chdir(nested); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	// Walking upward finds the project from a subdirectory.
+	if root, err := FindRoot(); err != nil || root != dir {
+		t.Errorf("FindRoot() = %q, %v, want %q", root, err, dir)
+	}
+
+	// Without walking, the subdirectory is not a project.
+	SetNoWalk(true)
+	if _, err := FindRoot(); err != ErrNotInitialized {
+		t.Errorf("FindRoot() with no-walk error = %v, want ErrNotInitialized", err)
+	}
+
+	// The project directory itself is still found.
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	if root, err := FindRoot(); err != nil || root != dir {
+		t.Errorf("FindRoot() with no-walk = %q, %v, want %q", root, err, dir)
+	}
+}
+
+func TestFindRoot_ProjectRoot(t *testing.T) {
+	base := t.TempDir()
+	base, err := filepath.EvalSymlinks(base)
+	if err != nil {
+		t.Fatalf("EvalSymlinks() error = %v", err)
+	}
+
+	frontend := filepath.Join(base, "frontend")
+	backend := filepath.Join(base, "backend")
+	for _, dir := range []string{frontend, backend} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+	}
+	if err := Init(frontend, "FE"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	defer SetProjectRoot("")
+
+	// From the backend directory, select the sibling frontend project.
+	if err := os.Chdir(backend); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+
+	SetProjectRoot(frontend)
+	if root, err := FindRoot(); err != nil || root != frontend {
+		t.Errorf("FindRoot() = %q, %v, want %q", root, err, frontend)
+	}
+
+	// A project root without a .thicket directory is an error, even if a
+	// parent directory has one.
+	SetProjectRoot(filepath.Join(frontend, "src"))
+	if _, err := FindRoot(); err != ErrNotInitialized {
+		t.Errorf("FindRoot() error = %v, want ErrNotInitialized", err)
+	}
+}
+
 func TestTHICKET_DIR(t *testing.T) {
 	// Reset global state after test
 	oldOverride := dataDirOverride
@@ -217,3 +315,237 @@ func TestTHICKET_DIR(t *testing.T) {
 	}
 }
 
+func TestConfig_PriorityLabel(t *testing.T) {
+	cfg := &Config{ProjectCode: "TH"}
+	if got := cfg.PriorityLabel(0); got != "Critical" {
+		t.Errorf("PriorityLabel(0) = %q, want Critical", got)
+	}
+	if got := cfg.PriorityLabel(9); got != "" {
+		t.Errorf("PriorityLabel(9) = %q, want empty", got)
+	}
+
+	cfg.PriorityLabels = map[int]string{1: "Urgent"}
+	if got := cfg.PriorityLabel(1); got != "Urgent" {
+		t.Errorf("PriorityLabel(1) = %q, want Urgent", got)
+	}
+	if got := cfg.PriorityLabel(0); got != "" {
+		t.Errorf("PriorityLabel(0) = %q, want empty when custom labels are configured", got)
+	}
+}
+
+func TestLoad_PriorityLabels(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Init(dir, "TH"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := GetPaths(dir)
+	data := []byte(`{"project_code": "TH", "priority_labels": {"0": "Blocker", "1": "Major"}}`)
+	if err := os.WriteFile(paths.Config, data, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := cfg.PriorityLabel(0); got != "Blocker" {
+		t.Errorf("PriorityLabel(0) = %q, want Blocker", got)
+	}
+}
+
+func TestConfig_Severity(t *testing.T) {
+	tests := []struct {
+		priority int
+		want     string
+	}{
+		{0, SeverityCritical},
+		{1, SeverityHigh},
+		{2, SeverityNormal},
+		{3, SeverityLow},
+		{10, SeverityLow},
+	}
+
+	cfg := &Config{ProjectCode: "TH"}
+	for _, tt := range tests {
+		if got := cfg.Severity(tt.priority); got != tt.want {
+			t.Errorf("Severity(%d) = %q, want %q", tt.priority, got, tt.want)
+		}
+	}
+
+	cfg.SeverityThresholds = &SeverityThresholds{Critical: 1, High: 3, Normal: 5}
+	if got := cfg.Severity(1); got != SeverityCritical {
+		t.Errorf("Severity(1) = %q, want critical with custom thresholds", got)
+	}
+	if got := cfg.Severity(4); got != SeverityNormal {
+		t.Errorf("Severity(4) = %q, want normal with custom thresholds", got)
+	}
+	if got := cfg.Severity(6); got != SeverityLow {
+		t.Errorf("Severity(6) = %q, want low with custom thresholds", got)
+	}
+}
+
+func TestConfig_HookCommand(t *testing.T) {
+	var cfg *Config
+	if got := cfg.HookCommand(HookOnClose); got != "" {
+		t.Errorf("HookCommand() on nil config = %q, want empty", got)
+	}
+
+	cfg = &Config{ProjectCode: "TH", Hooks: &Hooks{OnClose: "notify-close"}}
+	if got := cfg.HookCommand(HookOnClose); got != "notify-close" {
+		t.Errorf("HookCommand(on_close) = %q, want notify-close", got)
+	}
+	if got := cfg.HookCommand(HookOnCreate); got != "" {
+		t.Errorf("HookCommand(on_create) = %q, want empty", got)
+	}
+}
+
+func TestReinit_KeepsTickets(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Init(dir, "TH"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	paths := GetPaths(dir)
+	tickets := []byte(`{"id":"TH-abc123"}` + "\n")
+	if err := os.WriteFile(paths.Tickets, tickets, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(paths.Config, []byte("{broken"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(paths.Cache, []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Reinit(dir, "TH", false); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ProjectCode != "TH" {
+		t.Errorf("ProjectCode = %q, want TH", cfg.ProjectCode)
+	}
+	data, err := os.ReadFile(paths.Tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(tickets) {
+		t.Errorf("tickets = %q, want %q", data, tickets)
+	}
+	if _, err := os.Stat(paths.Cache); !os.IsNotExist(err) {
+		t.Error("Reinit() should remove the cache")
+	}
+}
+
+func TestReinit_KeepsSettings(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Init(dir, "TH"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	paths := GetPaths(dir)
+	config := `{"project_code": "TH", "webhook_url": "https://chat.example.com/hook", "default_list_status": "all"}`
+	if err := os.WriteFile(paths.Config, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Reinit(dir, "AB", false); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.ProjectCode != "AB" {
+		t.Errorf("ProjectCode = %q, want AB", cfg.ProjectCode)
+	}
+	if cfg.WebhookURL != "https://chat.example.com/hook" || cfg.DefaultListStatus != "all" {
+		t.Errorf("config = %+v, want the existing settings kept", cfg)
+	}
+}
+
+func TestReinit_Wipe(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Init(dir, "TH"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	paths := GetPaths(dir)
+	if err := os.WriteFile(paths.Tickets, []byte(`{"id":"TH-abc123"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Reinit(dir, "TH", true); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+
+	data, err := os.ReadFile(paths.Tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0 {
+		t.Errorf("tickets = %q, want empty", data)
+	}
+}
+
+func TestReinit_MissingDir(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Reinit(dir, "TH", false); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Errorf("Load() error = %v", err)
+	}
+	if _, err := os.Stat(GetPaths(dir).Tickets); err != nil {
+		t.Errorf("tickets file not created: %v", err)
+	}
+}
+
+func TestInitSplit(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := InitSplit(dir, "TH"); err != nil {
+		t.Fatalf("InitSplit() error = %v", err)
+	}
+
+	paths := GetPaths(dir)
+	if !paths.Split() {
+		t.Fatal("GetPaths().Split() = false after InitSplit()")
+	}
+	for _, path := range paths.DataFiles() {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s not created: %v", filepath.Base(path), err)
+		}
+	}
+
+	// Reinit keeps the layout.
+	if err := Reinit(dir, "TH", true); err != nil {
+		t.Fatalf("Reinit() error = %v", err)
+	}
+	if !GetPaths(dir).Split() {
+		t.Error("Reinit() lost the split layout")
+	}
+}
+
+func TestGetPaths_Combined(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := Init(dir, "TH"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	paths := GetPaths(dir)
+	if paths.Split() {
+		t.Error("GetPaths().Split() = true for a combined project")
+	}
+	if got := paths.DataFiles(); len(got) != 1 || got[0] != paths.Tickets {
+		t.Errorf("DataFiles() = %v, want only the tickets file", got)
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// IdentityEnv is the environment variable that overrides the current user's identity.
+const IdentityEnv = "THICKET_USER"
+
+// ResolveIdentity returns the name recorded as the author of new comments and
+// used for "me" shortcuts. It checks THICKET_USER, then git's user.name, then
+// the USER environment variable, and returns an empty string if none is set.
+func ResolveIdentity() string {
+	if name := strings.TrimSpace(os.Getenv(IdentityEnv)); name != "" {
+		return name
+	}
+	if out, err := exec.Command("git", "config", "user.name").Output(); err == nil {
+		if name := strings.TrimSpace(string(out)); name != "" {
+			return name
+		}
+	}
+	return strings.TrimSpace(os.Getenv("USER"))
+}
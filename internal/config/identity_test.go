@@ -0,0 +1,26 @@
+package config
+
+import "testing"
+
+func TestResolveIdentity_Env(t *testing.T) {
+	t.Setenv(IdentityEnv, "  Alice  ")
+
+	if got := ResolveIdentity(); got != "Alice" {
+		t.Errorf("ResolveIdentity() = %q, want Alice", got)
+	}
+}
+
+func TestResolveIdentity_FallsBackToUser(t *testing.T) {
+	t.Setenv(IdentityEnv, "")
+	t.Setenv("USER", "bob")
+	// Hide any git configuration so the USER fallback is used.
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	t.Chdir(t.TempDir())
+
+	if got := ResolveIdentity(); got != "bob" {
+		t.Errorf("ResolveIdentity() = %q, want bob", got)
+	}
+}
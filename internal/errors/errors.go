@@ -2,14 +2,48 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
+// Exit codes for the categories of error, so scripts can tell them apart.
+const (
+	ExitInternal       = 1 // Unexpected failures, and user errors with no category
+	ExitNotInitialized = 2 // No Thicket project was found
+	ExitNotFound       = 3 // A ticket or comment doesn't exist
+	ExitValidation     = 4 // An argument or field value was rejected
+	ExitConflict       = 5 // The change conflicts with existing data, such as a duplicate or a cycle
+)
+
+// Kind categorizes a UserError for programmatic handling.
+type Kind int
+
+// Kinds of UserError.
+const (
+	KindUnknown        Kind = iota // No particular category
+	KindNotInitialized             // No Thicket project was found
+	KindNotFound                   // A ticket or comment doesn't exist
+	KindValidation                 // An argument or field value was rejected
+	KindConflict                   // The change conflicts with existing data
+)
+
+// exitCodes maps each Kind to its exit code.
+var exitCodes = map[Kind]int{
+	KindNotInitialized: ExitNotInitialized,
+	KindNotFound:       ExitNotFound,
+	KindValidation:     ExitValidation,
+	KindConflict:       ExitConflict,
+}
+
 // UserError represents an error that should be displayed to the user.
 // These errors have user-friendly messages and optional hints.
 type UserError struct {
 	Message string
 	Hint    string
+	Kind    Kind
+	Code    int  // Exit code overriding the one for Kind, if nonzero
+	Shown   bool // Already reported in the command's output, so not printed again
 }
 
 func (e *UserError) Error() string {
@@ -19,6 +53,63 @@ func (e *UserError) Error() string {
 	return e.Message
 }
 
+// ExitCode returns the process exit code for e: its Code if set, otherwise
+// the code for its Kind, or ExitInternal if it has no kind.
+func (e *UserError) ExitCode() int {
+	if e.Code != 0 {
+		return e.Code
+	}
+	if code, ok := exitCodes[e.Kind]; ok {
+		return code
+	}
+	return ExitInternal
+}
+
+// ExitCode returns the process exit code for err: the exit code of a
+// UserError in its chain, or ExitInternal for any other error.
+func ExitCode(err error) int {
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		return userErr.ExitCode()
+	}
+	return ExitInternal
+}
+
+// KindOf returns the Kind of the UserError in err's chain, or KindUnknown if
+// there is none.
+func KindOf(err error) Kind {
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		return userErr.Kind
+	}
+	return KindUnknown
+}
+
+// IsShown reports whether err was already reported in the command's output,
+// such as a JSON error object, and so shouldn't be printed again.
+func IsShown(err error) bool {
+	var userErr *UserError
+	return errors.As(err, &userErr) && userErr.Shown
+}
+
+// IsNotInitialized reports whether err means no Thicket project was found.
+func IsNotInitialized(err error) bool { return KindOf(err) == KindNotInitialized }
+
+// IsNotFound reports whether err means a ticket or comment doesn't exist.
+func IsNotFound(err error) bool { return KindOf(err) == KindNotFound }
+
+// IsValidation reports whether err means an argument or value was rejected.
+func IsValidation(err error) bool { return KindOf(err) == KindValidation }
+
+// IsConflict reports whether err means a change conflicts with existing data.
+func IsConflict(err error) bool { return KindOf(err) == KindConflict }
+
+// withKind sets the kind of e and returns it.
+func (e *UserError) withKind(kind Kind) *UserError {
+	e.Kind = kind
+	return e
+}
+
 // New creates a new UserError with the given message.
 func New(message string) *UserError {
 	return &UserError{Message: message}
@@ -34,7 +125,7 @@ func NotInitialized() *UserError {
 	return WithHint(
 		"Thicket is not initialized in this directory",
 		"Run 'thicket init --project <CODE>' to initialize a project",
-	)
+	).withKind(KindNotInitialized)
 }
 
 // TicketNotFound returns an error for when a ticket is not found.
@@ -42,7 +133,7 @@ func TicketNotFound(id string) *UserError {
 	return WithHint(
 		fmt.Sprintf("Ticket %s not found", id),
 		"Run 'thicket list' to see available tickets",
-	)
+	).withKind(KindNotFound)
 }
 
 // InvalidTicketID returns an error for invalid ticket ID format.
@@ -50,7 +141,15 @@ func InvalidTicketID(id string) *UserError {
 	return WithHint(
 		fmt.Sprintf("Invalid ticket ID: %s", id),
 		"Ticket IDs have the format XX-xxxxxx (e.g., TH-abc123)",
-	)
+	).withKind(KindValidation)
+}
+
+// AmbiguousTicketID returns an error for a partial ticket ID that matches several tickets.
+func AmbiguousTicketID(id string, candidates []string) *UserError {
+	return WithHint(
+		fmt.Sprintf("Ticket ID %s is ambiguous; it matches %s", id, strings.Join(candidates, ", ")),
+		"Type more characters of the ticket ID to pick one",
+	).withKind(KindValidation)
 }
 
 // InvalidProjectCode returns an error for invalid project code.
@@ -58,13 +157,14 @@ func InvalidProjectCode(code string) *UserError {
 	return WithHint(
 		fmt.Sprintf("Invalid project code: %s", code),
 		"Project code must be exactly two letters (e.g., TH)",
-	)
+	).withKind(KindValidation)
 }
 
 // MissingRequired returns an error for missing required flags.
 func MissingRequired(flag string) *UserError {
 	return &UserError{
 		Message: fmt.Sprintf("Missing required flag: --%s", flag),
+		Kind:    KindValidation,
 	}
 }
 
@@ -72,8 +172,16 @@ func MissingRequired(flag string) *UserError {
 func InvalidStatus(status string) *UserError {
 	return WithHint(
 		fmt.Sprintf("Invalid status: %s", status),
-		"Valid statuses are: open, closed",
-	)
+		"Valid statuses are: open, closed, icebox, deleted",
+	).withKind(KindValidation)
+}
+
+// InvalidType returns an error for invalid ticket type values.
+func InvalidType(issueType string) *UserError {
+	return WithHint(
+		fmt.Sprintf("Invalid type: %s", issueType),
+		"Valid types are: bug, feature, task, epic, cleanup",
+	).withKind(KindValidation)
 }
 
 // StatusReadySuggestion returns an error suggesting the ready command.
@@ -81,13 +189,22 @@ func StatusReadySuggestion() *UserError {
 	return WithHint(
 		"'ready' is not a valid status",
 		"Did you mean 'thicket ready'? This command shows tickets that are ready to work on.",
-	)
+	).withKind(KindValidation)
 }
 
 // EmptyComment returns an error for empty comment content.
 func EmptyComment() *UserError {
 	return &UserError{
 		Message: "Comment content cannot be empty",
+		Kind:    KindValidation,
+	}
+}
+
+// EmptyTitle returns an error for an empty ticket title.
+func EmptyTitle() *UserError {
+	return &UserError{
+		Message: "Ticket title cannot be empty",
+		Kind:    KindValidation,
 	}
 }
 
@@ -95,6 +212,7 @@ func EmptyComment() *UserError {
 func CommentNotFound(id string) *UserError {
 	return &UserError{
 		Message: fmt.Sprintf("Comment %s not found", id),
+		Kind:    KindNotFound,
 	}
 }
 
@@ -103,13 +221,14 @@ func CircularDependency() *UserError {
 	return WithHint(
 		"This would create a circular dependency",
 		"A ticket cannot be blocked by a ticket that it transitively blocks",
-	)
+	).withKind(KindConflict)
 }
 
 // DuplicateDependency returns an error for duplicate dependencies.
 func DuplicateDependency() *UserError {
 	return &UserError{
 		Message: "This dependency already exists",
+		Kind:    KindConflict,
 	}
 }
 
@@ -117,6 +236,7 @@ func DuplicateDependency() *UserError {
 func SelfDependency() *UserError {
 	return &UserError{
 		Message: "A ticket cannot depend on itself",
+		Kind:    KindValidation,
 	}
 }
 
@@ -124,6 +244,70 @@ func SelfDependency() *UserError {
 func InvalidDependencyType(depType string) *UserError {
 	return WithHint(
 		fmt.Sprintf("Invalid dependency type: %s", depType),
-		"Valid types are: blocked_by, created_from",
-	)
+		"Valid types are: blocked_by, created_from, related_to",
+	).withKind(KindValidation)
+}
+
+// InvalidPriority returns an error for a priority outside the configured range.
+func InvalidPriority(max int) *UserError {
+	return WithHint(
+		fmt.Sprintf("Priority must be between 0 and %d", max),
+		"0 is the highest priority; set priority_max in .thicket/config.json to allow more levels",
+	).withKind(KindValidation)
+}
+
+// InvalidEstimate returns an error for a negative estimate.
+func InvalidEstimate() *UserError {
+	return WithHint(
+		"Estimate cannot be negative",
+		"Use a number of points such as 1, 3, or 8, or 0 to clear the estimate",
+	).withKind(KindValidation)
+}
+
+// InvalidCloseReason returns an error for invalid close reasons.
+func InvalidCloseReason(reason string) *UserError {
+	return WithHint(
+		fmt.Sprintf("Invalid close reason: %s", reason),
+		"Valid reasons are: done, wontfix, duplicate, obsolete",
+	).withKind(KindValidation)
+}
+
+// TitleTooLong returns an error for titles that exceed the length limit.
+func TitleTooLong(max int) *UserError {
+	return WithHint(
+		fmt.Sprintf("Title is too long (maximum is %d characters)", max),
+		"Keep the title short and put details in --description",
+	).withKind(KindValidation)
+}
+
+// DescriptionTooLong returns an error for descriptions that exceed the length limit.
+func DescriptionTooLong(max int) *UserError {
+	return WithHint(
+		fmt.Sprintf("Description is too long (maximum is %d characters)", max),
+		"Summarize the description or split the work into several tickets",
+	).withKind(KindValidation)
+}
+
+// CommentTooLong returns an error for comments that exceed the length limit.
+func CommentTooLong(max int) *UserError {
+	return WithHint(
+		fmt.Sprintf("Comment is too long (maximum is %d characters)", max),
+		"Summarize the comment, or raise max_comment_length in config.json",
+	).withKind(KindValidation)
+}
+
+// DuplicateTitle returns an error for a new ticket whose title matches an open ticket.
+func DuplicateTitle(existingID string) *UserError {
+	return WithHint(
+		fmt.Sprintf("An open ticket with this title already exists: %s", existingID),
+		"Use --allow-duplicate to create it anyway",
+	).withKind(KindConflict)
+}
+
+// UnknownField returns an error for a --fields entry that isn't a ticket field.
+func UnknownField(field string, valid []string) *UserError {
+	return WithHint(
+		fmt.Sprintf("Unknown field: %s", field),
+		"Valid fields are: "+strings.Join(valid, ", "),
+	).withKind(KindValidation)
 }
@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -85,3 +87,145 @@ func TestInvalidStatus(t *testing.T) {
 		t.Errorf("Error() should mention valid statuses, got %q", msg)
 	}
 }
+
+func TestTitleTooLong(t *testing.T) {
+	msg := TitleTooLong(200).Error()
+	if !strings.Contains(msg, "200") {
+		t.Errorf("Error() should mention the limit, got %q", msg)
+	}
+}
+
+func TestDescriptionTooLong(t *testing.T) {
+	msg := DescriptionTooLong(10000).Error()
+	if !strings.Contains(msg, "10000") {
+		t.Errorf("Error() should mention the limit, got %q", msg)
+	}
+}
+
+func TestDuplicateTitle(t *testing.T) {
+	err := DuplicateTitle("TH-abc123")
+	if !strings.Contains(err.Error(), "TH-abc123") {
+		t.Errorf("Error() should mention the existing ticket, got %q", err.Error())
+	}
+	if !strings.Contains(err.Hint, "--allow-duplicate") {
+		t.Errorf("Hint should mention --allow-duplicate, got %q", err.Hint)
+	}
+}
+
+func TestAmbiguousTicketID(t *testing.T) {
+	msg := AmbiguousTicketID("ab", []string{"TH-abc123", "TH-abd456"}).Error()
+	if !strings.Contains(msg, "TH-abc123, TH-abd456") {
+		t.Errorf("Error() should list the candidates, got %q", msg)
+	}
+}
+
+func TestUnknownField(t *testing.T) {
+	err := UnknownField("colour", []string{"id", "title"})
+	if !strings.Contains(err.Error(), "colour") {
+		t.Errorf("Error() should mention the field, got %q", err.Error())
+	}
+	if !strings.Contains(err.Hint, "id, title") {
+		t.Errorf("Hint should list the valid fields, got %q", err.Hint)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{NotInitialized(), ExitNotInitialized},
+		{TicketNotFound("TH-abc123"), ExitNotFound},
+		{CommentNotFound("TH-cabc123"), ExitNotFound},
+		{InvalidStatus("pending"), ExitValidation},
+		{EmptyTitle(), ExitValidation},
+		{CircularDependency(), ExitConflict},
+		{DuplicateTitle("TH-abc123"), ExitConflict},
+		{fmt.Errorf("adding ticket: %w", TicketNotFound("TH-abc123")), ExitNotFound},
+		{New("no category"), ExitInternal},
+		{errors.New("disk full"), ExitInternal},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%q) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestConstructorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *UserError
+		want Kind
+	}{
+		{"NotInitialized", NotInitialized(), KindNotInitialized},
+		{"TicketNotFound", TicketNotFound("TH-abc123"), KindNotFound},
+		{"CommentNotFound", CommentNotFound("TH-cabc123"), KindNotFound},
+		{"InvalidTicketID", InvalidTicketID("bad"), KindValidation},
+		{"AmbiguousTicketID", AmbiguousTicketID("ab", []string{"TH-abc123", "TH-abd456"}), KindValidation},
+		{"InvalidProjectCode", InvalidProjectCode("XYZ"), KindValidation},
+		{"MissingRequired", MissingRequired("title"), KindValidation},
+		{"InvalidStatus", InvalidStatus("pending"), KindValidation},
+		{"StatusReadySuggestion", StatusReadySuggestion(), KindValidation},
+		{"EmptyComment", EmptyComment(), KindValidation},
+		{"EmptyTitle", EmptyTitle(), KindValidation},
+		{"SelfDependency", SelfDependency(), KindValidation},
+		{"InvalidDependencyType", InvalidDependencyType("parent"), KindValidation},
+		{"InvalidPriority", InvalidPriority(5), KindValidation},
+		{"InvalidEstimate", InvalidEstimate(), KindValidation},
+		{"InvalidCloseReason", InvalidCloseReason("meh"), KindValidation},
+		{"TitleTooLong", TitleTooLong(200), KindValidation},
+		{"DescriptionTooLong", DescriptionTooLong(10000), KindValidation},
+		{"UnknownField", UnknownField("colour", []string{"id"}), KindValidation},
+		{"CircularDependency", CircularDependency(), KindConflict},
+		{"DuplicateDependency", DuplicateDependency(), KindConflict},
+		{"DuplicateTitle", DuplicateTitle("TH-abc123"), KindConflict},
+		{"New", New("plain"), KindUnknown},
+		{"WithHint", WithHint("plain", "hint"), KindUnknown},
+	}
+	for _, tt := range tests {
+		if tt.err.Kind != tt.want {
+			t.Errorf("%s().Kind = %d, want %d", tt.name, tt.err.Kind, tt.want)
+		}
+	}
+}
+
+func TestKindHelpers(t *testing.T) {
+	wrapped := fmt.Errorf("showing ticket: %w", TicketNotFound("TH-abc123"))
+	if !IsNotFound(wrapped) {
+		t.Error("IsNotFound() = false for a wrapped TicketNotFound")
+	}
+	if IsValidation(wrapped) || IsConflict(wrapped) || IsNotInitialized(wrapped) {
+		t.Error("a not-found error should not match the other kinds")
+	}
+	if !IsNotInitialized(NotInitialized()) || !IsValidation(EmptyTitle()) || !IsConflict(CircularDependency()) {
+		t.Error("each helper should match its own kind")
+	}
+	if KindOf(errors.New("plain")) != KindUnknown {
+		t.Error("KindOf() of a non-UserError should be KindUnknown")
+	}
+}
+
+func TestIsShown(t *testing.T) {
+	err := DuplicateDependency()
+	if IsShown(err) {
+		t.Error("IsShown() = true for a new error")
+	}
+	err.Shown = true
+	if !IsShown(fmt.Errorf("wrapped: %w", err)) {
+		t.Error("IsShown() = false for a wrapped shown error")
+	}
+}
+
+func TestUserError_ExitCode(t *testing.T) {
+	if got := TicketNotFound("TH-abc123").ExitCode(); got != ExitNotFound {
+		t.Errorf("ExitCode() = %d, want %d", got, ExitNotFound)
+	}
+	custom := &UserError{Message: "custom", Kind: KindValidation, Code: 9}
+	if got := custom.ExitCode(); got != 9 {
+		t.Errorf("ExitCode() with Code set = %d, want 9", got)
+	}
+	if got := New("plain").ExitCode(); got != ExitInternal {
+		t.Errorf("ExitCode() without a kind = %d, want %d", got, ExitInternal)
+	}
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Backend is the queryable cache behind a Store. The JSONL files remain the
+// source of truth: the Store writes every change to them and then mirrors it
+// in the backend, and rebuilds the backend from them whenever they change
+// outside the Store.
+//
+// *DB, backed by SQLite, is the default implementation.
+type Backend interface {
+	// Close releases the backend's resources.
+	Close() error
+
+	// Reset discards every record and all metadata.
+	Reset() error
+	// RebuildFromAll replaces every record with the given ones.
+	RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) error
+	// Counts returns the number of records of each kind.
+	Counts() (RecordCounts, error)
+	// GetMetadata returns the value stored for key, or "" if there is none.
+	GetMetadata(key string) (string, error)
+	// SetMetadata stores value for key.
+	SetMetadata(key, value string) error
+
+	// InsertTicket adds a new ticket.
+	InsertTicket(t *ticket.Ticket) error
+	// UpdateTicket replaces an existing ticket.
+	UpdateTicket(t *ticket.Ticket) error
+	// RenameLabel replaces label old with new on every ticket.
+	RenameLabel(old, new string) error
+	// GetTicket returns the ticket with the given ID, or nil if there is none.
+	GetTicket(id string) (*ticket.Ticket, error)
+	// GetAllTickets returns every ticket, including deleted ones.
+	GetAllTickets() ([]*ticket.Ticket, error)
+	// FindByIdempotencyKey returns the ticket created with the given
+	// idempotency key, or nil if there is none.
+	FindByIdempotencyKey(key string) (*ticket.Ticket, error)
+	// ListTicketIDsLike returns the IDs of tickets matching a SQL LIKE pattern.
+	ListTicketIDsLike(pattern string) ([]string, error)
+
+	// ListTickets and the other List methods return tickets in rank order,
+	// except ListStaleTickets and ListUpdatedSince, which order them by
+	// update time. Without a status, deleted tickets are excluded.
+	ListTickets(status *ticket.Status) ([]*ticket.Ticket, error)
+	ListTicketsByLabel(label string, status *ticket.Status) ([]*ticket.Ticket, error)
+	ListTicketsByLabels(labels []string, matchAll bool, status *ticket.Status) ([]*ticket.Ticket, error)
+	ListTicketsWithLabels(status *ticket.Status, labels []string, matchAll bool, exclude []string) ([]*ticket.Ticket, error)
+	ListByTypes(types []ticket.Type, status *ticket.Status) ([]*ticket.Ticket, error)
+	ListUnassigned(status *ticket.Status) ([]*ticket.Ticket, error)
+	ListByWatcher(user string, status *ticket.Status) ([]*ticket.Ticket, error)
+	ListReadyTickets() ([]*ticket.Ticket, error)
+	ListBlockedTickets() ([]*ticket.Ticket, error)
+	ListStaleTickets(olderThan time.Time) ([]*ticket.Ticket, error)
+	ListUpdatedSince(since time.Time, status *ticket.Status) ([]*ticket.Ticket, error)
+	FindByTitle(title string) ([]*ticket.Ticket, error)
+	// BlockedTicketIDs returns the IDs of open tickets blocked by other open
+	// tickets.
+	BlockedTicketIDs() (map[string]bool, error)
+	// StatsByStatus counts tickets and sums their estimates by status.
+	StatsByStatus() ([]StatusStats, error)
+	// StatsByAssignee counts open and closed tickets by assignee.
+	StatsByAssignee() ([]AssigneeStats, error)
+
+	// InsertComment adds a new comment.
+	InsertComment(c *ticket.Comment) error
+	// UpdateComment replaces an existing comment.
+	UpdateComment(c *ticket.Comment) error
+	// GetComment returns the comment with the given ID, or nil if there is none.
+	GetComment(id string) (*ticket.Comment, error)
+	// GetCommentsForTicket returns a ticket's comments, oldest first.
+	GetCommentsForTicket(ticketID string) ([]*ticket.Comment, error)
+	// GetAllComments returns every comment.
+	GetAllComments() ([]*ticket.Comment, error)
+
+	// InsertDependency adds a new dependency.
+	InsertDependency(d *ticket.Dependency) error
+	// GetDependenciesFrom returns the dependencies of the given ticket.
+	GetDependenciesFrom(ticketID string) ([]*ticket.Dependency, error)
+	// GetDependenciesTo returns the dependencies on the given ticket.
+	GetDependenciesTo(ticketID string) ([]*ticket.Dependency, error)
+	// GetBlockingDependencies returns every blocked_by dependency.
+	GetBlockingDependencies() ([]*ticket.Dependency, error)
+	// DependencyExists reports whether the given dependency exists.
+	DependencyExists(fromTicketID, toTicketID string, depType ticket.DependencyType) (bool, error)
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// mockBackend keeps tickets and comments in maps. Methods the tests don't
+// need fall through to the nil embedded Backend and panic if called.
+type mockBackend struct {
+	Backend
+	metadata map[string]string
+	tickets  map[string]*ticket.Ticket
+	comments []*ticket.Comment
+	rebuilds int
+	closed   bool
+
+	rebuildErr error
+}
+
+func newMockBackend() *mockBackend {
+	return &mockBackend{metadata: map[string]string{}, tickets: map[string]*ticket.Ticket{}}
+}
+
+func (m *mockBackend) Close() error {
+	m.closed = true
+	return nil
+}
+
+func (m *mockBackend) GetMetadata(key string) (string, error) {
+	return m.metadata[key], nil
+}
+
+func (m *mockBackend) SetMetadata(key, value string) error {
+	m.metadata[key] = value
+	return nil
+}
+
+func (m *mockBackend) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) error {
+	if m.rebuildErr != nil {
+		return m.rebuildErr
+	}
+	m.rebuilds++
+	m.tickets = map[string]*ticket.Ticket{}
+	for _, t := range tickets {
+		m.tickets[t.ID] = t
+	}
+	m.comments = comments
+	return nil
+}
+
+func (m *mockBackend) InsertTicket(t *ticket.Ticket) error {
+	m.tickets[t.ID] = t
+	return nil
+}
+
+func (m *mockBackend) UpdateTicket(t *ticket.Ticket) error {
+	if _, ok := m.tickets[t.ID]; !ok {
+		return errors.New("no such ticket")
+	}
+	m.tickets[t.ID] = t
+	return nil
+}
+
+func (m *mockBackend) GetTicket(id string) (*ticket.Ticket, error) {
+	return m.tickets[id], nil
+}
+
+func (m *mockBackend) InsertComment(c *ticket.Comment) error {
+	m.comments = append(m.comments, c)
+	return nil
+}
+
+func (m *mockBackend) GetCommentsForTicket(ticketID string) ([]*ticket.Comment, error) {
+	var comments []*ticket.Comment
+	for _, c := range m.comments {
+		if c.TicketID == ticketID {
+			comments = append(comments, c)
+		}
+	}
+	return comments, nil
+}
+
+func TestStore_OpenBackend(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	existing, _ := ticket.New("TH", "Existing", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := AppendJSONL(paths, existing); err != nil {
+		t.Fatalf("AppendJSONL() error = %v", err)
+	}
+
+	backend := newMockBackend()
+	store, err := OpenBackend(paths, backend)
+	if err != nil {
+		t.Fatalf("OpenBackend() error = %v", err)
+	}
+	if backend.rebuilds != 1 {
+		t.Errorf("rebuilds = %d, want 1 from the initial sync", backend.rebuilds)
+	}
+	if got, err := store.Get(existing.ID); err != nil || got == nil || got.Title != "Existing" {
+		t.Fatalf("Get(%s) = %v, %v; want the ticket from tickets.jsonl", existing.ID, got, err)
+	}
+
+	added, _ := ticket.New("TH", "Added", "", ticket.TypeTask, 2, nil, "", 0)
+	if err := store.Add(added); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if backend.tickets[added.ID] != added {
+		t.Error("Add() didn't insert the ticket into the backend")
+	}
+
+	added.Title = "Renamed"
+	if err := store.Update(added); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := backend.tickets[added.ID].Title; got != "Renamed" {
+		t.Errorf("backend title = %q, want Renamed", got)
+	}
+
+	c, _ := ticket.NewComment(added.ID, "Looks good")
+	if err := store.AddComment(c); err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+	comments, err := store.GetComments(added.ID)
+	if err != nil || len(comments) != 1 || comments[0].ID != c.ID {
+		t.Errorf("GetComments() = %v, %v; want the new comment", comments, err)
+	}
+
+	// The store's own writes keep the backend in sync without a rebuild.
+	if err := store.SyncFromJSONL(); err != nil {
+		t.Fatalf("SyncFromJSONL() error = %v", err)
+	}
+	if backend.rebuilds != 1 {
+		t.Errorf("rebuilds = %d after the store's own writes, want 1", backend.rebuilds)
+	}
+
+	// Changes written to JSONL by someone else are picked up on sync.
+	other, err := OpenBackend(paths, newMockBackend())
+	if err != nil {
+		t.Fatalf("OpenBackend() error = %v", err)
+	}
+	elsewhere, _ := ticket.New("TH", "Elsewhere", "", ticket.TypeTask, 2, nil, "", 0)
+	if err := other.Add(elsewhere); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	other.Close()
+	if err := store.SyncFromJSONL(); err != nil {
+		t.Fatalf("SyncFromJSONL() error = %v", err)
+	}
+	if backend.rebuilds != 2 || backend.tickets[elsewhere.ID] == nil {
+		t.Errorf("rebuilds = %d, want 2 including the other store's ticket", backend.rebuilds)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !backend.closed {
+		t.Error("Close() didn't close the backend")
+	}
+}
+
+func TestStore_OpenBackend_SyncError(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	backend := newMockBackend()
+	backend.rebuildErr = errors.New("backend unavailable")
+	if _, err := OpenBackend(paths, backend); !errors.Is(err, backend.rebuildErr) {
+		t.Errorf("OpenBackend() error = %v, want %v", err, backend.rebuildErr)
+	}
+	if !backend.closed {
+		t.Error("OpenBackend() didn't close the backend after failing")
+	}
+}
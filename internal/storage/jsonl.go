@@ -3,11 +3,18 @@ package storage
 
 import (
 	"bufio"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"hash/crc32"
+	"io"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
@@ -19,18 +26,18 @@ type rawRecord struct {
 
 // ReadJSONL reads all tickets from a JSONL file, ignoring comments and dependencies.
 func ReadJSONL(path string) ([]*ticket.Ticket, error) {
-	tickets, _, _, err := ReadAllJSONL(path)
+	tickets, _, _, err := readJSONLFile(path)
 	return tickets, err
 }
 
-// AppendJSONL appends a single ticket to the JSONL file by rewriting it sorted.
-func AppendJSONL(path string, t *ticket.Ticket) error {
-	tickets, comments, dependencies, err := ReadAllJSONL(path)
+// AppendJSONL appends a single ticket to the JSONL files by rewriting them sorted.
+func AppendJSONL(paths config.Paths, t *ticket.Ticket) error {
+	tickets, comments, dependencies, err := ReadAllJSONL(paths)
 	if err != nil {
 		return err
 	}
 	tickets = append(tickets, t)
-	return WriteAllJSONL(path, tickets, comments, dependencies)
+	return WriteAllJSONL(paths, tickets, comments, dependencies)
 }
 
 // WriteJSONL writes all tickets to a JSONL file, replacing existing content and sorting by ID.
@@ -39,56 +46,129 @@ func WriteJSONL(path string, tickets []*ticket.Ticket) error {
 		return tickets[i].ID < tickets[j].ID
 	})
 
-	file, err := os.Create(path)
+	return writeJSONLFile(path, tickets, nil, nil)
+}
+
+// Compressed reports whether path names a gzip-compressed JSONL file.
+func Compressed(path string) bool {
+	return strings.HasSuffix(path, config.GzipExt)
+}
+
+// NewJSONLReader returns a reader of the JSONL records in r, which holds the
+// contents of the file at path. Files with a .gz extension are decompressed;
+// an empty compressed file reads as empty.
+func NewJSONLReader(path string, r io.Reader) (io.Reader, error) {
+	if !Compressed(path) {
+		return r, nil
+	}
+	zr, err := gzip.NewReader(r)
+	if err == io.EOF {
+		return strings.NewReader(""), nil
+	}
 	if err != nil {
-		return fmt.Errorf("creating tickets file: %w", err)
+		return nil, fmt.Errorf("decompressing %s: %w", filepath.Base(path), err)
 	}
-	defer file.Close()
+	return zr, nil
+}
 
-	for _, t := range tickets {
-		data, err := json.Marshal(t)
+// GetJSONLModTime returns the latest modification time of the JSONL files.
+func GetJSONLModTime(paths config.Paths) (int64, error) {
+	var latest int64
+	for _, path := range paths.DataFiles() {
+		info, err := os.Stat(path)
 		if err != nil {
-			return fmt.Errorf("encoding ticket %s: %w", t.ID, err)
-		}
-		if _, err := file.Write(append(data, '\n')); err != nil {
-			return fmt.Errorf("writing ticket %s: %w", t.ID, err)
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, fmt.Errorf("getting file info: %w", err)
 		}
+		latest = max(latest, info.ModTime().UnixNano())
 	}
+	return latest, nil
+}
 
-	return nil
+// GetJSONLChecksum returns a cheap fingerprint of the JSONL files' contents.
+// In the split layout, the fingerprints of the files are joined with commas.
+func GetJSONLChecksum(paths config.Paths) (string, error) {
+	var sums []string
+	for _, path := range paths.DataFiles() {
+		sum, err := fileChecksum(path)
+		if err != nil {
+			return "", err
+		}
+		sums = append(sums, sum)
+	}
+	return strings.Join(sums, ","), nil
 }
 
-// GetJSONLModTime returns the modification time of the JSONL file.
-func GetJSONLModTime(path string) (int64, error) {
-	info, err := os.Stat(path)
+// fileChecksum returns a file's size and CRC-32. It returns an empty string
+// if the file doesn't exist.
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return 0, nil
+			return "", nil
 		}
-		return 0, fmt.Errorf("getting file info: %w", err)
+		return "", fmt.Errorf("opening file: %w", err)
+	}
+	defer file.Close()
+
+	hash := crc32.NewIEEE()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", fmt.Errorf("reading file: %w", err)
 	}
-	return info.ModTime().UnixNano(), nil
+	return fmt.Sprintf("%d:%08x", size, hash.Sum32()), nil
 }
 
-// ReadAllJSONL reads all tickets, comments, and dependencies from a JSONL file.
+// ReadAllJSONL reads all tickets, comments, and dependencies from the JSONL
+// files: the tickets file alone, or in the split layout, all three files.
+func ReadAllJSONL(paths config.Paths) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.Dependency, error) {
+	var tickets []*ticket.Ticket
+	var comments []*ticket.Comment
+	var dependencies []*ticket.Dependency
+	for _, path := range paths.DataFiles() {
+		t, c, d, err := readJSONLFile(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		tickets = append(tickets, t...)
+		comments = append(comments, c...)
+		dependencies = append(dependencies, d...)
+	}
+	return tickets, comments, dependencies, nil
+}
+
+// readJSONLFile reads all records from a single JSONL file.
 // It distinguishes between record types by checking for specific fields:
 // - Dependencies have from_ticket_id
 // - Comments have ticket_id
 // - Tickets have neither
-func ReadAllJSONL(path string) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.Dependency, error) {
+func readJSONLFile(path string) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.Dependency, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil, nil, nil
 		}
-		return nil, nil, nil, fmt.Errorf("opening tickets file: %w", err)
+		return nil, nil, nil, fmt.Errorf("opening %s: %w", filepath.Base(path), err)
 	}
 	defer file.Close()
 
+	r, err := NewJSONLReader(path, file)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return DecodeAllJSONL(r)
+}
+
+// DecodeAllJSONL reads tickets, comments, and dependencies from r, which
+// holds records in the tickets file format. Times are converted to UTC, as
+// Thicket writes them, in case the file was edited by hand.
+func DecodeAllJSONL(r io.Reader) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.Dependency, error) {
 	var tickets []*ticket.Ticket
 	var comments []*ticket.Comment
 	var dependencies []*ticket.Dependency
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 
 	lineNum := 0
 	for scanner.Scan() {
@@ -110,6 +190,7 @@ func ReadAllJSONL(path string) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.D
 			if err := json.Unmarshal([]byte(line), &d); err != nil {
 				return nil, nil, nil, fmt.Errorf("parsing dependency at line %d: %w", lineNum, err)
 			}
+			d.Created = d.Created.UTC()
 			dependencies = append(dependencies, &d)
 		} else if raw.TicketID != "" {
 			// This is a comment
@@ -117,6 +198,7 @@ func ReadAllJSONL(path string) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.D
 			if err := json.Unmarshal([]byte(line), &c); err != nil {
 				return nil, nil, nil, fmt.Errorf("parsing comment at line %d: %w", lineNum, err)
 			}
+			c.Created, c.Acked = c.Created.UTC(), c.Acked.UTC()
 			comments = append(comments, &c)
 		} else {
 			// This is a ticket
@@ -124,6 +206,7 @@ func ReadAllJSONL(path string) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.D
 			if err := json.Unmarshal([]byte(line), &t); err != nil {
 				return nil, nil, nil, fmt.Errorf("parsing ticket at line %d: %w", lineNum, err)
 			}
+			t.Created, t.Updated = t.Created.UTC(), t.Updated.UTC()
 			tickets = append(tickets, &t)
 		}
 	}
@@ -135,28 +218,92 @@ func ReadAllJSONL(path string) ([]*ticket.Ticket, []*ticket.Comment, []*ticket.D
 	return tickets, comments, dependencies, nil
 }
 
-// AppendComment appends a single comment to the JSONL file by rewriting it sorted.
-func AppendComment(path string, c *ticket.Comment) error {
-	tickets, comments, dependencies, err := ReadAllJSONL(path)
+// timestampFields are the record fields that hold times.
+var timestampFields = []string{"created", "updated", "acked"}
+
+// CheckTimestamps returns a description of each timestamp in the JSONL files
+// that isn't in RFC 3339 format, giving the file and line it is on. Such a
+// timestamp stops the files from loading at all, so this finds them without
+// decoding the records.
+func CheckTimestamps(paths config.Paths) ([]string, error) {
+	var problems []string
+	for _, path := range paths.DataFiles() {
+		found, err := checkFileTimestamps(path)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, found...)
+	}
+	return problems, nil
+}
+
+// checkFileTimestamps returns CheckTimestamps' problems for one data file,
+// which may not exist.
+func checkFileTimestamps(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", filepath.Base(path), err)
+	}
+	defer file.Close()
+
+	r, err := NewJSONLReader(path, file)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &fields); err != nil {
+			continue
+		}
+		for _, name := range timestampFields {
+			raw, ok := fields[name]
+			if !ok {
+				continue
+			}
+			var value string
+			if err := json.Unmarshal(raw, &value); err == nil {
+				if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+					continue
+				}
+			}
+			problems = append(problems, fmt.Sprintf("%s line %d: %s time %s is not an RFC 3339 timestamp", filepath.Base(path), lineNum, name, raw))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+	return problems, nil
+}
+
+// AppendComment appends a single comment to the JSONL files by rewriting them sorted.
+func AppendComment(paths config.Paths, c *ticket.Comment) error {
+	tickets, comments, dependencies, err := ReadAllJSONL(paths)
 	if err != nil {
 		return err
 	}
 	comments = append(comments, c)
-	return WriteAllJSONL(path, tickets, comments, dependencies)
+	return WriteAllJSONL(paths, tickets, comments, dependencies)
 }
 
-// AppendDependency appends a single dependency to the JSONL file by rewriting it sorted.
-func AppendDependency(path string, d *ticket.Dependency) error {
-	tickets, comments, dependencies, err := ReadAllJSONL(path)
+// AppendDependency appends a single dependency to the JSONL files by rewriting them sorted.
+func AppendDependency(paths config.Paths, d *ticket.Dependency) error {
+	tickets, comments, dependencies, err := ReadAllJSONL(paths)
 	if err != nil {
 		return err
 	}
 	dependencies = append(dependencies, d)
-	return WriteAllJSONL(path, tickets, comments, dependencies)
+	return WriteAllJSONL(paths, tickets, comments, dependencies)
 }
 
-// WriteAllJSONL writes all tickets, comments, and dependencies to a JSONL file, replacing existing content and sorting by ID.
-func WriteAllJSONL(path string, tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) error {
+// WriteAllJSONL writes all tickets, comments, and dependencies to the JSONL
+// files, replacing existing content and sorting by ID. In the split layout,
+// each record type goes to its own file.
+func WriteAllJSONL(paths config.Paths, tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) error {
 	// Sort everything by ID to reduce merge conflicts
 	sort.Slice(tickets, func(i, j int) bool {
 		return tickets[i].ID < tickets[j].ID
@@ -168,18 +315,49 @@ func WriteAllJSONL(path string, tickets []*ticket.Ticket, comments []*ticket.Com
 		return dependencies[i].ID < dependencies[j].ID
 	})
 
+	if !paths.Split() {
+		return writeJSONLFile(paths.Tickets, tickets, comments, dependencies)
+	}
+	if err := writeJSONLFile(paths.Tickets, tickets, nil, nil); err != nil {
+		return err
+	}
+	if err := writeJSONLFile(paths.Comments, nil, comments, nil); err != nil {
+		return err
+	}
+	return writeJSONLFile(paths.Dependencies, nil, nil, dependencies)
+}
+
+// writeJSONLFile replaces the contents of a single JSONL file with the given records.
+func writeJSONLFile(path string, tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) error {
 	file, err := os.Create(path)
 	if err != nil {
-		return fmt.Errorf("creating tickets file: %w", err)
+		return fmt.Errorf("creating %s: %w", filepath.Base(path), err)
 	}
 	defer file.Close()
 
+	if !Compressed(path) {
+		return EncodeAllJSONL(file, tickets, comments, dependencies)
+	}
+	zw := gzip.NewWriter(file)
+	if err := EncodeAllJSONL(zw, tickets, comments, dependencies); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compressing %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// EncodeAllJSONL writes tickets, then comments, then dependencies to w, one
+// JSON record per line, in the order given. The output has the same format
+// as the tickets file.
+func EncodeAllJSONL(w io.Writer, tickets []*ticket.Ticket, comments []*ticket.Comment, dependencies []*ticket.Dependency) error {
 	for _, t := range tickets {
 		data, err := json.Marshal(t)
 		if err != nil {
 			return fmt.Errorf("encoding ticket %s: %w", t.ID, err)
 		}
-		if _, err := file.Write(append(data, '\n')); err != nil {
+		if _, err := w.Write(append(data, '\n')); err != nil {
 			return fmt.Errorf("writing ticket %s: %w", t.ID, err)
 		}
 	}
@@ -189,7 +367,7 @@ func WriteAllJSONL(path string, tickets []*ticket.Ticket, comments []*ticket.Com
 		if err != nil {
 			return fmt.Errorf("encoding comment %s: %w", c.ID, err)
 		}
-		if _, err := file.Write(append(data, '\n')); err != nil {
+		if _, err := w.Write(append(data, '\n')); err != nil {
 			return fmt.Errorf("writing comment %s: %w", c.ID, err)
 		}
 	}
@@ -199,7 +377,7 @@ func WriteAllJSONL(path string, tickets []*ticket.Ticket, comments []*ticket.Com
 		if err != nil {
 			return fmt.Errorf("encoding dependency %s: %w", d.ID, err)
 		}
-		if _, err := file.Write(append(data, '\n')); err != nil {
+		if _, err := w.Write(append(data, '\n')); err != nil {
 			return fmt.Errorf("writing dependency %s: %w", d.ID, err)
 		}
 	}
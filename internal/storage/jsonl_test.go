@@ -3,9 +3,11 @@ package storage
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
@@ -59,11 +61,11 @@ func TestAppendJSONL(t *testing.T) {
 	t1 := &ticket.Ticket{ID: "TH-111111", Title: "First", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now}
 	t2 := &ticket.Ticket{ID: "TH-222222", Title: "Second", Status: ticket.StatusOpen, Priority: 2, Created: now, Updated: now}
 
-	if err := AppendJSONL(path, t1); err != nil {
+	if err := AppendJSONL(config.Paths{Tickets: path}, t1); err != nil {
 		t.Fatalf("AppendJSONL() error = %v", err)
 	}
 
-	if err := AppendJSONL(path, t2); err != nil {
+	if err := AppendJSONL(config.Paths{Tickets: path}, t2); err != nil {
 		t.Fatalf("AppendJSONL() error = %v", err)
 	}
 
@@ -134,7 +136,7 @@ func TestGetJSONLModTime(t *testing.T) {
 	path := filepath.Join(dir, "tickets.jsonl")
 
 	// Non-existent file should return 0
-	modTime, err := GetJSONLModTime(path)
+	modTime, err := GetJSONLModTime(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("GetJSONLModTime() error = %v", err)
 	}
@@ -147,7 +149,7 @@ func TestGetJSONLModTime(t *testing.T) {
 		t.Fatalf("WriteFile() error = %v", err)
 	}
 
-	modTime, err = GetJSONLModTime(path)
+	modTime, err = GetJSONLModTime(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("GetJSONLModTime() error = %v", err)
 	}
@@ -156,6 +158,39 @@ func TestGetJSONLModTime(t *testing.T) {
 	}
 }
 
+func TestGetJSONLChecksum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tickets.jsonl")
+
+	checksum, err := GetJSONLChecksum(config.Paths{Tickets: path})
+	if err != nil {
+		t.Fatalf("GetJSONLChecksum() error = %v", err)
+	}
+	if checksum != "" {
+		t.Errorf("GetJSONLChecksum() = %q for missing file, want empty", checksum)
+	}
+
+	if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	first, err := GetJSONLChecksum(config.Paths{Tickets: path})
+	if err != nil {
+		t.Fatalf("GetJSONLChecksum() error = %v", err)
+	}
+
+	// Same size, different content.
+	if err := os.WriteFile(path, []byte("tent"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	second, err := GetJSONLChecksum(config.Paths{Tickets: path})
+	if err != nil {
+		t.Fatalf("GetJSONLChecksum() error = %v", err)
+	}
+	if first == second {
+		t.Errorf("GetJSONLChecksum() = %q for both contents, want different checksums", first)
+	}
+}
+
 func TestReadAllJSONL_MixedContent(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "tickets.jsonl")
@@ -170,7 +205,7 @@ func TestReadAllJSONL_MixedContent(t *testing.T) {
 		t.Fatalf("WriteFile() error = %v", err)
 	}
 
-	tickets, comments, deps, err := ReadAllJSONL(path)
+	tickets, comments, deps, err := ReadAllJSONL(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("ReadAllJSONL() error = %v", err)
 	}
@@ -208,7 +243,7 @@ func TestReadAllJSONL_NonExistent(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "nonexistent.jsonl")
 
-	tickets, comments, deps, err := ReadAllJSONL(path)
+	tickets, comments, deps, err := ReadAllJSONL(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("ReadAllJSONL() error = %v", err)
 	}
@@ -235,11 +270,11 @@ func TestAppendComment(t *testing.T) {
 		Created:  now,
 	}
 
-	if err := AppendComment(path, c); err != nil {
+	if err := AppendComment(config.Paths{Tickets: path}, c); err != nil {
 		t.Fatalf("AppendComment() error = %v", err)
 	}
 
-	_, comments, _, err := ReadAllJSONL(path)
+	_, comments, _, err := ReadAllJSONL(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("ReadAllJSONL() error = %v", err)
 	}
@@ -271,11 +306,11 @@ func TestWriteAllJSONL(t *testing.T) {
 		{ID: "TH-cabcdef", TicketID: "TH-111111", Content: "A comment", Created: now},
 	}
 
-	if err := WriteAllJSONL(path, tickets, comments, nil); err != nil {
+	if err := WriteAllJSONL(config.Paths{Tickets: path}, tickets, comments, nil); err != nil {
 		t.Fatalf("WriteAllJSONL() error = %v", err)
 	}
 
-	readTickets, readComments, _, err := ReadAllJSONL(path)
+	readTickets, readComments, _, err := ReadAllJSONL(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("ReadAllJSONL() error = %v", err)
 	}
@@ -314,11 +349,11 @@ func TestWriteAllJSONL_Sorting(t *testing.T) {
 		{ID: "TH-d11111", FromTicketID: "TH-111111", ToTicketID: "TH-333333", Type: ticket.DependencyCreatedFrom, Created: now},
 	}
 
-	if err := WriteAllJSONL(path, tickets, comments, dependencies); err != nil {
+	if err := WriteAllJSONL(config.Paths{Tickets: path}, tickets, comments, dependencies); err != nil {
 		t.Fatalf("WriteAllJSONL() error = %v", err)
 	}
 
-	readTickets, readComments, readDeps, err := ReadAllJSONL(path)
+	readTickets, readComments, readDeps, err := ReadAllJSONL(config.Paths{Tickets: path})
 	if err != nil {
 		t.Fatalf("ReadAllJSONL() error = %v", err)
 	}
@@ -338,3 +373,190 @@ func TestWriteAllJSONL_Sorting(t *testing.T) {
 		t.Errorf("Dependencies not sorted: %s, %s", readDeps[0].ID, readDeps[1].ID)
 	}
 }
+
+func TestWriteAllJSONL_Split(t *testing.T) {
+	dir := t.TempDir()
+	paths := config.Paths{
+		Tickets:      filepath.Join(dir, "tickets.jsonl"),
+		Comments:     filepath.Join(dir, "comments.jsonl"),
+		Dependencies: filepath.Join(dir, "deps.jsonl"),
+	}
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-222222", Title: "Second", Created: now, Updated: now},
+		{ID: "TH-111111", Title: "First", Created: now, Updated: now},
+	}
+	comments := []*ticket.Comment{
+		{ID: "TH-c11111", TicketID: "TH-111111", Content: "A comment", Created: now},
+	}
+	dependencies := []*ticket.Dependency{
+		{ID: "TH-d11111", FromTicketID: "TH-222222", ToTicketID: "TH-111111", Type: ticket.DependencyBlockedBy, Created: now},
+	}
+
+	if err := WriteAllJSONL(paths, tickets, comments, dependencies); err != nil {
+		t.Fatalf("WriteAllJSONL() error = %v", err)
+	}
+
+	// Each record type lands in its own file.
+	for path, want := range map[string]int{paths.Tickets: 2, paths.Comments: 1, paths.Dependencies: 1} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) error = %v", path, err)
+		}
+		if got := strings.Count(string(data), "\n"); got != want {
+			t.Errorf("%s has %d records, want %d", filepath.Base(path), got, want)
+		}
+	}
+	readTickets, err := ReadJSONL(paths.Tickets)
+	if err != nil {
+		t.Fatalf("ReadJSONL() error = %v", err)
+	}
+	if len(readTickets) != 2 {
+		t.Errorf("tickets.jsonl has %d tickets, want 2", len(readTickets))
+	}
+
+	readTickets, readComments, readDeps, err := ReadAllJSONL(paths)
+	if err != nil {
+		t.Fatalf("ReadAllJSONL() error = %v", err)
+	}
+	if len(readTickets) != 2 || readTickets[0].ID != "TH-111111" {
+		t.Errorf("ReadAllJSONL() tickets = %v, want TH-111111 and TH-222222", readTickets)
+	}
+	if len(readComments) != 1 || readComments[0].Content != "A comment" {
+		t.Errorf("ReadAllJSONL() comments = %v, want the comment", readComments)
+	}
+	if len(readDeps) != 1 || readDeps[0].ToTicketID != "TH-111111" {
+		t.Errorf("ReadAllJSONL() dependencies = %v, want the dependency", readDeps)
+	}
+}
+
+func TestGetJSONLChecksum_Split(t *testing.T) {
+	dir := t.TempDir()
+	paths := config.Paths{
+		Tickets:      filepath.Join(dir, "tickets.jsonl"),
+		Comments:     filepath.Join(dir, "comments.jsonl"),
+		Dependencies: filepath.Join(dir, "deps.jsonl"),
+	}
+	for _, path := range paths.DataFiles() {
+		if err := os.WriteFile(path, []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	before, err := GetJSONLChecksum(paths)
+	if err != nil {
+		t.Fatalf("GetJSONLChecksum() error = %v", err)
+	}
+	if err := os.WriteFile(paths.Comments, []byte(`{"id":"TH-c11111","ticket_id":"TH-111111"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	after, err := GetJSONLChecksum(paths)
+	if err != nil {
+		t.Fatalf("GetJSONLChecksum() error = %v", err)
+	}
+	if before == after {
+		t.Error("GetJSONLChecksum() did not change when comments.jsonl changed")
+	}
+}
+
+func TestWriteAllJSONL_Gzip(t *testing.T) {
+	dir := t.TempDir()
+	paths := config.Paths{Tickets: filepath.Join(dir, "tickets.jsonl.gz")}
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Compressed", Type: ticket.TypeBug, Created: now, Updated: now},
+	}
+	comments := []*ticket.Comment{
+		{ID: "TH-c11111", TicketID: "TH-111111", Content: "Also compressed", Created: now},
+	}
+
+	if err := WriteAllJSONL(paths, tickets, comments, nil); err != nil {
+		t.Fatalf("WriteAllJSONL() error = %v", err)
+	}
+
+	data, err := os.ReadFile(paths.Tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) < 2 || data[0] != 0x1f || data[1] != 0x8b {
+		t.Fatalf("tickets.jsonl.gz is not gzip-compressed: %q", data)
+	}
+
+	readTickets, readComments, _, err := ReadAllJSONL(paths)
+	if err != nil {
+		t.Fatalf("ReadAllJSONL() error = %v", err)
+	}
+	if len(readTickets) != 1 || readTickets[0].Title != "Compressed" {
+		t.Errorf("ReadAllJSONL() tickets = %v, want the compressed ticket", readTickets)
+	}
+	if len(readComments) != 1 || readComments[0].Content != "Also compressed" {
+		t.Errorf("ReadAllJSONL() comments = %v, want the compressed comment", readComments)
+	}
+}
+
+func TestReadAllJSONL_EmptyGzip(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tickets.jsonl.gz")
+	if err := os.WriteFile(path, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tickets, _, _, err := ReadAllJSONL(config.Paths{Tickets: path})
+	if err != nil {
+		t.Fatalf("ReadAllJSONL() error = %v", err)
+	}
+	if len(tickets) != 0 {
+		t.Errorf("ReadAllJSONL() = %d tickets, want 0", len(tickets))
+	}
+}
+
+func TestReadAllJSONL_NormalizesTimesToUTC(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tickets.jsonl")
+
+	content := `{"id":"TH-111111","title":"Edited by hand","description":"","status":"open","priority":1,"created":"2024-01-01T02:00:00+02:00","updated":"2024-01-01T09:30:00-05:00"}
+{"id":"TH-c222222","ticket_id":"TH-111111","content":"A comment","created":"2024-01-01T01:00:00+01:00"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tickets, comments, _, err := ReadAllJSONL(config.Paths{Tickets: path})
+	if err != nil {
+		t.Fatalf("ReadAllJSONL() error = %v", err)
+	}
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, got := range map[string]time.Time{
+		"ticket created":  tickets[0].Created,
+		"ticket updated":  tickets[0].Updated.Add(-14*time.Hour - 30*time.Minute),
+		"comment created": comments[0].Created,
+	} {
+		if got != midnight {
+			t.Errorf("%s = %v, want %v", name, got, midnight)
+		}
+	}
+}
+
+func TestCheckTimestamps(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tickets.jsonl")
+
+	content := `{"id":"TH-111111","title":"Good","description":"","status":"open","priority":1,"created":"2024-01-01T02:00:00+02:00","updated":"2024-01-01T00:00:00Z"}
+
+{"id":"TH-333333","title":"Bad","description":"","status":"open","priority":1,"created":"yesterday","updated":"2024-01-01T00:00:00Z"}
+{"id":"TH-c444444","ticket_id":"TH-111111","content":"A comment","created":"2024-01-01 00:00:00"}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	problems, err := CheckTimestamps(config.Paths{Tickets: path})
+	if err != nil {
+		t.Fatalf("CheckTimestamps() error = %v", err)
+	}
+	if len(problems) != 2 || !strings.Contains(problems[0], "line 3: created") || !strings.Contains(problems[1], "line 4: created") {
+		t.Errorf("CheckTimestamps() = %q, want the created times on lines 3 and 4", problems)
+	}
+}
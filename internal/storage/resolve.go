@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// ErrNoMatchingTicket is returned when a partial ID matches no tickets.
+var ErrNoMatchingTicket = errors.New("no ticket matches the given ID")
+
+// AmbiguousIDError is returned when a partial ID matches more than one ticket.
+type AmbiguousIDError struct {
+	Partial    string
+	Candidates []string
+}
+
+func (e *AmbiguousIDError) Error() string {
+	return fmt.Sprintf("ticket ID %q is ambiguous: matches %s", e.Partial, strings.Join(e.Candidates, ", "))
+}
+
+// partialIDPattern matches a partial ticket ID: an optional project code
+// followed by the first one to six characters of the random suffix.
+var partialIDPattern = regexp.MustCompile(`^(?:([A-Za-z]{2})-)?([a-zA-Z0-9]{1,6})$`)
+
+// ResolveID expands a full or partial ticket ID into a full ticket ID.
+//
+// A full ID is returned unchanged, without checking that the ticket exists.
+// Otherwise the input is treated as a prefix of the ID suffix, optionally
+// preceded by the project code (e.g., "abc" or "TH-abc"), and must match
+// exactly one ticket.
+func (s *Store) ResolveID(partial string) (string, error) {
+	if ticket.ValidateID(partial) == nil {
+		return partial, nil
+	}
+
+	m := partialIDPattern.FindStringSubmatch(partial)
+	if m == nil {
+		return "", ticket.ErrInvalidID
+	}
+
+	code := "__"
+	if m[1] != "" {
+		code = strings.ToUpper(m[1])
+	}
+	pattern := code + "-" + strings.ToLower(m[2]) + "%"
+
+	ids, err := s.db.ListTicketIDsLike(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", ErrNoMatchingTicket
+	case 1:
+		return ids[0], nil
+	default:
+		return "", &AmbiguousIDError{Partial: partial, Candidates: ids}
+	}
+}
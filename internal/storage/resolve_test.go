@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func setupResolveStore(t *testing.T) *Store {
+	t.Helper()
+	paths, _ := setupTestProject(t)
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	for _, id := range []string{"TH-abc123", "TH-abd456", "TH-xyz789"} {
+		tk, err := ticket.New("TH", "Ticket "+id, "", ticket.TypeTask, 2, nil, "", 0)
+		if err != nil {
+			t.Fatalf("ticket.New() error = %v", err)
+		}
+		tk.ID = id
+		if err := store.Add(tk); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	return store
+}
+
+func TestStore_ResolveID(t *testing.T) {
+	store := setupResolveStore(t)
+
+	tests := []struct {
+		partial string
+		want    string
+	}{
+		{"TH-abc123", "TH-abc123"},
+		{"TH-000000", "TH-000000"}, // full IDs are returned without a lookup
+		{"abc123", "TH-abc123"},
+		{"abc", "TH-abc123"},
+		{"ABC", "TH-abc123"},
+		{"x", "TH-xyz789"},
+		{"TH-abd", "TH-abd456"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.partial, func(t *testing.T) {
+			got, err := store.ResolveID(tt.partial)
+			if err != nil {
+				t.Fatalf("ResolveID(%q) error = %v", tt.partial, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveID(%q) = %q, want %q", tt.partial, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore_ResolveID_Ambiguous(t *testing.T) {
+	store := setupResolveStore(t)
+
+	_, err := store.ResolveID("ab")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("ResolveID() error = %v, want AmbiguousIDError", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0] != "TH-abc123" || ambiguous.Candidates[1] != "TH-abd456" {
+		t.Errorf("Candidates = %v, want [TH-abc123 TH-abd456]", ambiguous.Candidates)
+	}
+}
+
+func TestStore_ResolveID_NoMatch(t *testing.T) {
+	store := setupResolveStore(t)
+
+	if _, err := store.ResolveID("qqq"); !errors.Is(err, ErrNoMatchingTicket) {
+		t.Errorf("ResolveID() error = %v, want ErrNoMatchingTicket", err)
+	}
+	if _, err := store.ResolveID("XY-abc"); !errors.Is(err, ErrNoMatchingTicket) {
+		t.Errorf("ResolveID() with other project code error = %v, want ErrNoMatchingTicket", err)
+	}
+}
+
+func TestStore_ResolveID_Invalid(t *testing.T) {
+	store := setupResolveStore(t)
+
+	for _, partial := range []string{"", "invalid", "TH-", "ab%"} {
+		if _, err := store.ResolveID(partial); !errors.Is(err, ticket.ErrInvalidID) {
+			t.Errorf("ResolveID(%q) error = %v, want ErrInvalidID", partial, err)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package storage
 import (
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -19,12 +21,19 @@ CREATE TABLE IF NOT EXISTS tickets (
     status TEXT NOT NULL DEFAULT 'open',
     priority INTEGER NOT NULL DEFAULT 0,
     assignee TEXT DEFAULT '',
+    close_reason TEXT DEFAULT '',
+    order_rank INTEGER NOT NULL DEFAULT 0,
+    estimate INTEGER NOT NULL DEFAULT 0,
+    created_by TEXT NOT NULL DEFAULT '',
+    updated_by TEXT NOT NULL DEFAULT '',
+    idempotency_key TEXT NOT NULL DEFAULT '',
     created TEXT NOT NULL,
     updated TEXT NOT NULL
 );
 
 CREATE INDEX IF NOT EXISTS idx_tickets_status ON tickets(status);
 CREATE INDEX IF NOT EXISTS idx_tickets_priority ON tickets(priority);
+CREATE INDEX IF NOT EXISTS idx_tickets_updated ON tickets(julianday(updated));
 
 CREATE TABLE IF NOT EXISTS ticket_labels (
     ticket_id TEXT NOT NULL,
@@ -34,11 +43,22 @@ CREATE TABLE IF NOT EXISTS ticket_labels (
 
 CREATE INDEX IF NOT EXISTS idx_ticket_labels_label ON ticket_labels(label);
 
+CREATE TABLE IF NOT EXISTS ticket_watchers (
+    ticket_id TEXT NOT NULL,
+    watcher TEXT NOT NULL,
+    PRIMARY KEY (ticket_id, watcher)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_watchers_watcher ON ticket_watchers(watcher);
+
 CREATE TABLE IF NOT EXISTS comments (
     id TEXT PRIMARY KEY,
     ticket_id TEXT NOT NULL,
     content TEXT NOT NULL,
-    created TEXT NOT NULL
+    author TEXT DEFAULT '',
+    created TEXT NOT NULL,
+    acked TEXT DEFAULT '',
+    acked_by TEXT DEFAULT ''
 );
 
 CREATE INDEX IF NOT EXISTS idx_comments_ticket_id ON comments(ticket_id);
@@ -62,25 +82,105 @@ CREATE TABLE IF NOT EXISTS metadata (
 );
 `
 
+// schemaVersion identifies the cache schema. Bump it whenever the schema
+// changes; an existing cache with a different version is dropped and rebuilt
+// from the JSONL file, which is the source of truth.
+const schemaVersion = "9"
+
+const metaKeySchemaVersion = "schema_version"
+
+// dropSchema removes every cache table.
+const dropSchema = `
+DROP TABLE IF EXISTS tickets;
+DROP TABLE IF EXISTS ticket_labels;
+DROP TABLE IF EXISTS ticket_watchers;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS dependencies;
+DROP TABLE IF EXISTS metadata;
+`
+
 // DB wraps a SQLite database connection for ticket operations.
 type DB struct {
 	conn *sql.DB
 	path string
 }
 
-// OpenDB opens or creates a SQLite database at the given path.
+// MemoryPath is the path that opens a private in-memory database.
+const MemoryPath = ":memory:"
+
+// OpenDB opens or creates a SQLite database at the given path. Pass
+// MemoryPath for a database that lives only as long as the DB.
 func OpenDB(path string) (*DB, error) {
 	conn, err := sql.Open("sqlite3", path)
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
 	}
+	if path == MemoryPath {
+		// Every connection to :memory: gets its own empty database, so
+		// keep a single connection for the life of the DB.
+		conn.SetMaxOpenConns(1)
+	}
 
 	if _, err := conn.Exec(schema); err != nil {
 		conn.Close()
 		return nil, fmt.Errorf("creating schema: %w", err)
 	}
 
-	return &DB{conn: conn, path: path}, nil
+	db := &DB{conn: conn, path: path}
+	if err := db.ensureSchemaVersion(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// ensureSchemaVersion recreates the cache tables if they were created by a
+// different version of Thicket. Dropping the metadata table also clears the
+// stored JSONL modification time, so the next sync repopulates the cache.
+func (db *DB) ensureSchemaVersion() error {
+	version, err := db.GetMetadata(metaKeySchemaVersion)
+	if err != nil {
+		return err
+	}
+	if version == schemaVersion {
+		return nil
+	}
+	return db.Reset()
+}
+
+// Reset drops every cache table and recreates an empty schema, including
+// the metadata that records when the cache was last synced.
+func (db *DB) Reset() error {
+	if _, err := db.conn.Exec(dropSchema); err != nil {
+		return fmt.Errorf("dropping schema: %w", err)
+	}
+	if _, err := db.conn.Exec(schema); err != nil {
+		return fmt.Errorf("creating schema: %w", err)
+	}
+	return db.SetMetadata(metaKeySchemaVersion, schemaVersion)
+}
+
+// RecordCounts holds the number of each kind of record.
+type RecordCounts struct {
+	Tickets      int `json:"tickets"`
+	Comments     int `json:"comments"`
+	Dependencies int `json:"dependencies"`
+}
+
+// Counts returns the number of tickets, comments, and dependencies in the cache.
+func (db *DB) Counts() (RecordCounts, error) {
+	var c RecordCounts
+	err := db.conn.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM tickets),
+			(SELECT COUNT(*) FROM comments),
+			(SELECT COUNT(*) FROM dependencies)
+	`).Scan(&c.Tickets, &c.Comments, &c.Dependencies)
+	if err != nil {
+		return RecordCounts{}, fmt.Errorf("counting records: %w", err)
+	}
+	return c, nil
 }
 
 // Close closes the database connection.
@@ -129,9 +229,13 @@ func (db *DB) RebuildFromTickets(tickets []*ticket.Ticket) error {
 		return fmt.Errorf("clearing ticket labels: %w", err)
 	}
 
+	if _, err := tx.Exec("DELETE FROM ticket_watchers"); err != nil {
+		return fmt.Errorf("clearing ticket watchers: %w", err)
+	}
+
 	ticketStmt, err := tx.Prepare(`
-		INSERT INTO tickets (id, title, description, type, status, priority, assignee, created, updated)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tickets (id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("preparing insert: %w", err)
@@ -144,6 +248,12 @@ func (db *DB) RebuildFromTickets(tickets []*ticket.Ticket) error {
 	}
 	defer labelStmt.Close()
 
+	watcherStmt, err := tx.Prepare(`INSERT INTO ticket_watchers (ticket_id, watcher) VALUES (?, ?)`)
+	if err != nil {
+		return fmt.Errorf("preparing watcher insert: %w", err)
+	}
+	defer watcherStmt.Close()
+
 	for _, t := range tickets {
 		_, err := ticketStmt.Exec(
 			t.ID,
@@ -153,8 +263,14 @@ func (db *DB) RebuildFromTickets(tickets []*ticket.Ticket) error {
 			string(t.Status),
 			t.Priority,
 			t.Assignee,
-			t.Created.Format(time.RFC3339Nano),
-			t.Updated.Format(time.RFC3339Nano),
+			string(t.CloseReason),
+			t.Rank,
+			t.Estimate,
+			t.CreatedBy,
+			t.UpdatedBy,
+			t.IdempotencyKey,
+			formatTime(t.Created),
+			formatTime(t.Updated),
 		)
 		if err != nil {
 			return fmt.Errorf("inserting ticket %s: %w", t.ID, err)
@@ -165,6 +281,12 @@ func (db *DB) RebuildFromTickets(tickets []*ticket.Ticket) error {
 				return fmt.Errorf("inserting label for ticket %s: %w", t.ID, err)
 			}
 		}
+
+		for _, watcher := range t.Watchers {
+			if _, err := watcherStmt.Exec(t.ID, watcher); err != nil {
+				return fmt.Errorf("inserting watcher for ticket %s: %w", t.ID, err)
+			}
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -183,8 +305,8 @@ func (db *DB) InsertTicket(t *ticket.Ticket) error {
 	defer tx.Rollback()
 
 	_, err = tx.Exec(`
-		INSERT INTO tickets (id, title, description, type, status, priority, assignee, created, updated)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tickets (id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		t.ID,
 		t.Title,
@@ -193,8 +315,14 @@ func (db *DB) InsertTicket(t *ticket.Ticket) error {
 		string(t.Status),
 		t.Priority,
 		t.Assignee,
-		t.Created.Format(time.RFC3339Nano),
-		t.Updated.Format(time.RFC3339Nano),
+		string(t.CloseReason),
+		t.Rank,
+		t.Estimate,
+		t.CreatedBy,
+		t.UpdatedBy,
+		t.IdempotencyKey,
+		formatTime(t.Created),
+		formatTime(t.Updated),
 	)
 	if err != nil {
 		return fmt.Errorf("inserting ticket: %w", err)
@@ -207,6 +335,13 @@ func (db *DB) InsertTicket(t *ticket.Ticket) error {
 		}
 	}
 
+	for _, watcher := range t.Watchers {
+		_, err = tx.Exec(`INSERT INTO ticket_watchers (ticket_id, watcher) VALUES (?, ?)`, t.ID, watcher)
+		if err != nil {
+			return fmt.Errorf("inserting watcher: %w", err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
@@ -223,7 +358,7 @@ func (db *DB) UpdateTicket(t *ticket.Ticket) error {
 
 	result, err := tx.Exec(`
 		UPDATE tickets
-		SET title = ?, description = ?, type = ?, status = ?, priority = ?, assignee = ?, updated = ?
+		SET title = ?, description = ?, type = ?, status = ?, priority = ?, assignee = ?, close_reason = ?, order_rank = ?, estimate = ?, updated_by = ?, updated = ?
 		WHERE id = ?
 	`,
 		t.Title,
@@ -232,7 +367,11 @@ func (db *DB) UpdateTicket(t *ticket.Ticket) error {
 		string(t.Status),
 		t.Priority,
 		t.Assignee,
-		t.Updated.Format(time.RFC3339Nano),
+		string(t.CloseReason),
+		t.Rank,
+		t.Estimate,
+		t.UpdatedBy,
+		formatTime(t.Updated),
 		t.ID,
 	)
 	if err != nil {
@@ -260,24 +399,44 @@ func (db *DB) UpdateTicket(t *ticket.Ticket) error {
 		}
 	}
 
+	// Replace watchers
+	_, err = tx.Exec(`DELETE FROM ticket_watchers WHERE ticket_id = ?`, t.ID)
+	if err != nil {
+		return fmt.Errorf("deleting watchers: %w", err)
+	}
+
+	for _, watcher := range t.Watchers {
+		_, err = tx.Exec(`INSERT INTO ticket_watchers (ticket_id, watcher) VALUES (?, ?)`, t.ID, watcher)
+		if err != nil {
+			return fmt.Errorf("inserting watcher: %w", err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("committing transaction: %w", err)
 	}
 	return nil
 }
 
+// formatTime formats t for storage in the cache. Times are stored in UTC so
+// that they sort correctly as strings.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
 // GetTicket retrieves a ticket by ID.
 func (db *DB) GetTicket(id string) (*ticket.Ticket, error) {
 	var t ticket.Ticket
 	var status string
 	var issueType sql.NullString
 	var assignee sql.NullString
+	var closeReason sql.NullString
 	var created, updated string
 
 	err := db.conn.QueryRow(`
-		SELECT id, title, description, type, status, priority, assignee, created, updated
+		SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
 		FROM tickets WHERE id = ?
-	`, id).Scan(&t.ID, &t.Title, &t.Description, &issueType, &status, &t.Priority, &assignee, &created, &updated)
+	`, id).Scan(&t.ID, &t.Title, &t.Description, &issueType, &status, &t.Priority, &assignee, &closeReason, &t.Rank, &t.Estimate, &t.CreatedBy, &t.UpdatedBy, &t.IdempotencyKey, &created, &updated)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -293,8 +452,15 @@ func (db *DB) GetTicket(id string) (*ticket.Ticket, error) {
 	if assignee.Valid {
 		t.Assignee = assignee.String
 	}
-	t.Created, _ = time.Parse(time.RFC3339Nano, created)
-	t.Updated, _ = time.Parse(time.RFC3339Nano, updated)
+	if closeReason.Valid {
+		t.CloseReason = ticket.CloseReason(closeReason.String)
+	}
+	if t.Created, err = time.Parse(time.RFC3339Nano, created); err != nil {
+		return nil, fmt.Errorf("parsing ticket created time: %w", err)
+	}
+	if t.Updated, err = time.Parse(time.RFC3339Nano, updated); err != nil {
+		return nil, fmt.Errorf("parsing ticket updated time: %w", err)
+	}
 
 	// Fetch labels
 	labels, err := db.getLabelsForTicket(id)
@@ -303,6 +469,12 @@ func (db *DB) GetTicket(id string) (*ticket.Ticket, error) {
 	}
 	t.Labels = labels
 
+	watchers, err := db.getWatchersForTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	t.Watchers = watchers
+
 	return &t, nil
 }
 
@@ -330,22 +502,47 @@ func (db *DB) getLabelsForTicket(ticketID string) ([]string, error) {
 	return labels, nil
 }
 
+// getWatchersForTicket retrieves all watchers of a ticket.
+func (db *DB) getWatchersForTicket(ticketID string) ([]string, error) {
+	rows, err := db.conn.Query(`SELECT watcher FROM ticket_watchers WHERE ticket_id = ? ORDER BY watcher`, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("querying watchers: %w", err)
+	}
+	defer rows.Close()
+
+	var watchers []string
+	for rows.Next() {
+		var watcher string
+		if err := rows.Scan(&watcher); err != nil {
+			return nil, fmt.Errorf("scanning watcher: %w", err)
+		}
+		watchers = append(watchers, watcher)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating watchers: %w", err)
+	}
+
+	return watchers, nil
+}
+
 // ListTickets retrieves tickets with optional status filter, ordered by priority.
+// Without a status filter, deleted tickets are excluded.
 func (db *DB) ListTickets(status *ticket.Status) ([]*ticket.Ticket, error) {
 	var rows *sql.Rows
 	var err error
 
 	if status != nil {
 		rows, err = db.conn.Query(`
-			SELECT id, title, description, type, status, priority, assignee, created, updated
+			SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
 			FROM tickets WHERE status = ?
-			ORDER BY priority ASC, created ASC
+			ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
 		`, string(*status))
 	} else {
 		rows, err = db.conn.Query(`
-			SELECT id, title, description, type, status, priority, assignee, created, updated
-			FROM tickets
-			ORDER BY priority ASC, created ASC
+			SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+			FROM tickets WHERE status != 'deleted'
+			ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
 		`)
 	}
 
@@ -359,7 +556,7 @@ func (db *DB) ListTickets(status *ticket.Status) ([]*ticket.Ticket, error) {
 		return nil, err
 	}
 
-	if err := db.loadLabelsForTickets(tickets); err != nil {
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
 		return nil, err
 	}
 
@@ -369,7 +566,7 @@ func (db *DB) ListTickets(status *ticket.Status) ([]*ticket.Ticket, error) {
 // ListReadyTickets retrieves open tickets that are not blocked by other open tickets.
 func (db *DB) ListReadyTickets() ([]*ticket.Ticket, error) {
 	rows, err := db.conn.Query(`
-		SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.created, t.updated
+		SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
 		FROM tickets t
 		WHERE t.status = 'open'
 		AND NOT EXISTS (
@@ -380,7 +577,7 @@ func (db *DB) ListReadyTickets() ([]*ticket.Ticket, error) {
 			AND d.type = 'blocked_by'
 			AND bt.status = 'open'
 		)
-		ORDER BY t.priority ASC, t.created ASC
+		ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("querying ready tickets: %w", err)
@@ -392,33 +589,295 @@ func (db *DB) ListReadyTickets() ([]*ticket.Ticket, error) {
 		return nil, err
 	}
 
-	if err := db.loadLabelsForTickets(tickets); err != nil {
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
 		return nil, err
 	}
 
 	return tickets, nil
 }
 
+// BlockedTicketIDs returns the IDs of the open tickets that are blocked by at
+// least one open ticket, as a set.
+func (db *DB) BlockedTicketIDs() (map[string]bool, error) {
+	rows, err := db.conn.Query(`
+		SELECT DISTINCT d.from_ticket_id
+		FROM dependencies d
+		JOIN tickets t ON d.from_ticket_id = t.id
+		JOIN tickets bt ON d.to_ticket_id = bt.id
+		WHERE d.type = 'blocked_by'
+		AND t.status = 'open'
+		AND bt.status = 'open'
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying blocked ticket IDs: %w", err)
+	}
+	defer rows.Close()
+
+	blocked := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning blocked ticket ID: %w", err)
+		}
+		blocked[id] = true
+	}
+	return blocked, rows.Err()
+}
+
+// ListBlockedTickets retrieves open tickets that are blocked by at least one open ticket.
+func (db *DB) ListBlockedTickets() ([]*ticket.Ticket, error) {
+	rows, err := db.conn.Query(`
+		SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
+		FROM tickets t
+		WHERE t.status = 'open'
+		AND EXISTS (
+			SELECT 1
+			FROM dependencies d
+			JOIN tickets bt ON d.to_ticket_id = bt.id
+			WHERE d.from_ticket_id = t.id
+			AND d.type = 'blocked_by'
+			AND bt.status = 'open'
+		)
+		ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying blocked tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// ListStaleTickets retrieves open tickets last updated before olderThan,
+// least recently updated first.
+func (db *DB) ListStaleTickets(olderThan time.Time) ([]*ticket.Ticket, error) {
+	// Compare with julianday rather than as strings: RFC 3339 timestamps
+	// with trimmed fractional seconds don't sort lexically.
+	rows, err := db.conn.Query(`
+		SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+		FROM tickets
+		WHERE status = 'open' AND julianday(updated) < julianday(?)
+		ORDER BY julianday(updated) ASC, id ASC
+	`, formatTime(olderThan))
+	if err != nil {
+		return nil, fmt.Errorf("querying stale tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// ListUpdatedSince retrieves tickets updated at or after since, least
+// recently updated first. Without a status filter, deleted tickets are
+// excluded. The query uses idx_tickets_updated, so polling for changes stays
+// fast however many tickets there are.
+func (db *DB) ListUpdatedSince(since time.Time, status *ticket.Status) ([]*ticket.Ticket, error) {
+	var rows *sql.Rows
+	var err error
+
+	// Compare with julianday, as ListStaleTickets does; the index is on the
+	// same expression.
+	if status != nil {
+		rows, err = db.conn.Query(`
+			SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+			FROM tickets
+			WHERE julianday(updated) >= julianday(?) AND status = ?
+			ORDER BY julianday(updated) ASC, id ASC
+		`, formatTime(since), string(*status))
+	} else {
+		rows, err = db.conn.Query(`
+			SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+			FROM tickets
+			WHERE julianday(updated) >= julianday(?) AND status != 'deleted'
+			ORDER BY julianday(updated) ASC, id ASC
+		`, formatTime(since))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("querying recently updated tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// StatusStats summarizes the tickets with one status.
+type StatusStats struct {
+	Status   ticket.Status `json:"status"`
+	Count    int           `json:"count"`
+	Estimate int           `json:"estimate"` // Sum of the tickets' estimates
+}
+
+// StatsByStatus counts the tickets with each status, other than deleted, and
+// sums their estimates. Statuses are ordered open, icebox, then closed.
+func (db *DB) StatsByStatus() ([]StatusStats, error) {
+	rows, err := db.conn.Query(`
+		SELECT status, COUNT(*), COALESCE(SUM(estimate), 0)
+		FROM tickets
+		WHERE status != 'deleted'
+		GROUP BY status
+		ORDER BY CASE status WHEN 'open' THEN 0 WHEN 'icebox' THEN 1 WHEN 'closed' THEN 2 ELSE 3 END, status
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying ticket stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []StatusStats
+	for rows.Next() {
+		var s StatusStats
+		var status string
+		if err := rows.Scan(&status, &s.Count, &s.Estimate); err != nil {
+			return nil, fmt.Errorf("scanning ticket stats: %w", err)
+		}
+		s.Status = ticket.Status(status)
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// AssigneeStats counts the open and closed tickets of one assignee.
+type AssigneeStats struct {
+	Assignee string `json:"assignee"` // Empty for unassigned tickets
+	Open     int    `json:"open"`
+	Closed   int    `json:"closed"`
+}
+
+// StatsByAssignee counts the open and closed tickets of each assignee,
+// ordered by assignee with unassigned tickets last.
+func (db *DB) StatsByAssignee() ([]AssigneeStats, error) {
+	rows, err := db.conn.Query(`
+		SELECT assignee, SUM(status = 'open'), SUM(status = 'closed')
+		FROM tickets
+		WHERE status IN ('open', 'closed')
+		GROUP BY assignee
+		ORDER BY assignee = '', assignee
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying assignee stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []AssigneeStats
+	for rows.Next() {
+		var s AssigneeStats
+		if err := rows.Scan(&s.Assignee, &s.Open, &s.Closed); err != nil {
+			return nil, fmt.Errorf("scanning assignee stats: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// FindByTitle retrieves open tickets whose title matches the given title,
+// ignoring case and surrounding whitespace.
+func (db *DB) FindByTitle(title string) ([]*ticket.Ticket, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+		FROM tickets
+		WHERE status = 'open' AND LOWER(TRIM(title)) = LOWER(TRIM(?))
+		ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
+	`, title)
+	if err != nil {
+		return nil, fmt.Errorf("querying tickets by title: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// FindByIdempotencyKey retrieves the ticket created with the given
+// idempotency key, or nil if there is none.
+func (db *DB) FindByIdempotencyKey(key string) (*ticket.Ticket, error) {
+	var id string
+	err := db.conn.QueryRow(`
+		SELECT id FROM tickets WHERE idempotency_key = ? ORDER BY created ASC LIMIT 1
+	`, key).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("querying ticket by idempotency key: %w", err)
+	}
+	return db.GetTicket(id)
+}
+
+// ListTicketIDsLike retrieves the IDs of tickets matching a SQL LIKE pattern, sorted by ID.
+func (db *DB) ListTicketIDsLike(pattern string) ([]string, error) {
+	rows, err := db.conn.Query(`SELECT id FROM tickets WHERE id LIKE ? ORDER BY id`, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("querying ticket IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scanning ticket ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // ListTicketsByLabel retrieves tickets that have the specified label.
+// Without a status filter, deleted tickets are excluded.
 func (db *DB) ListTicketsByLabel(label string, status *ticket.Status) ([]*ticket.Ticket, error) {
 	var rows *sql.Rows
 	var err error
 
 	if status != nil {
 		rows, err = db.conn.Query(`
-			SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.created, t.updated
+			SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
 			FROM tickets t
 			JOIN ticket_labels tl ON t.id = tl.ticket_id
 			WHERE tl.label = ? AND t.status = ?
-			ORDER BY t.priority ASC, t.created ASC
+			ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
 		`, label, string(*status))
 	} else {
 		rows, err = db.conn.Query(`
-			SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.created, t.updated
+			SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
 			FROM tickets t
 			JOIN ticket_labels tl ON t.id = tl.ticket_id
-			WHERE tl.label = ?
-			ORDER BY t.priority ASC, t.created ASC
+			WHERE tl.label = ? AND t.status != 'deleted'
+			ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
 		`, label)
 	}
 
@@ -432,48 +891,293 @@ func (db *DB) ListTicketsByLabel(label string, status *ticket.Status) ([]*ticket
 		return nil, err
 	}
 
-	if err := db.loadLabelsForTickets(tickets); err != nil {
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
 		return nil, err
 	}
 
 	return tickets, nil
 }
 
-// loadLabelsForTickets fetches and populates labels for a slice of tickets.
-func (db *DB) loadLabelsForTickets(tickets []*ticket.Ticket) error {
-	if len(tickets) == 0 {
-		return nil
+// ListUnassigned retrieves tickets with no assignee. Without a status
+// filter, deleted tickets are excluded.
+func (db *DB) ListUnassigned(status *ticket.Status) ([]*ticket.Ticket, error) {
+	var rows *sql.Rows
+	var err error
+
+	if status != nil {
+		rows, err = db.conn.Query(`
+			SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+			FROM tickets
+			WHERE COALESCE(assignee, '') = '' AND status = ?
+			ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
+		`, string(*status))
+	} else {
+		rows, err = db.conn.Query(`
+			SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+			FROM tickets
+			WHERE COALESCE(assignee, '') = '' AND status != 'deleted'
+			ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
+		`)
 	}
 
-	// Build a map for quick lookup
-	ticketMap := make(map[string]*ticket.Ticket)
-	for _, t := range tickets {
-		ticketMap[t.ID] = t
+	if err != nil {
+		return nil, fmt.Errorf("querying unassigned tickets: %w", err)
 	}
+	defer rows.Close()
 
-	// Fetch all labels for these tickets in one query
-	rows, err := db.conn.Query(`
-		SELECT ticket_id, label FROM ticket_labels
-		WHERE ticket_id IN (SELECT id FROM tickets)
-		ORDER BY ticket_id, label
-	`)
+	tickets, err := scanTickets(rows)
 	if err != nil {
-		return fmt.Errorf("querying labels: %w", err)
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// ListByWatcher retrieves tickets watched by user. Without a status filter,
+// deleted tickets are excluded.
+func (db *DB) ListByWatcher(user string, status *ticket.Status) ([]*ticket.Ticket, error) {
+	var rows *sql.Rows
+	var err error
+
+	if status != nil {
+		rows, err = db.conn.Query(`
+			SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
+			FROM tickets t
+			JOIN ticket_watchers tw ON t.id = tw.ticket_id
+			WHERE tw.watcher = ? AND t.status = ?
+			ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
+		`, user, string(*status))
+	} else {
+		rows, err = db.conn.Query(`
+			SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
+			FROM tickets t
+			JOIN ticket_watchers tw ON t.id = tw.ticket_id
+			WHERE tw.watcher = ? AND t.status != 'deleted'
+			ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
+		`, user)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("querying tickets by watcher: %w", err)
 	}
 	defer rows.Close()
 
-	for rows.Next() {
-		var ticketID, label string
-		if err := rows.Scan(&ticketID, &label); err != nil {
-			return fmt.Errorf("scanning label: %w", err)
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// RenameLabel replaces label old with new on every ticket. Tickets that
+// already carry new just lose old, so no ticket ends up with new twice.
+func (db *DB) RenameLabel(old, new string) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM ticket_labels
+		WHERE label = ? AND ticket_id IN (SELECT ticket_id FROM ticket_labels WHERE label = ?)
+	`, old, new)
+	if err != nil {
+		return fmt.Errorf("merging label: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE ticket_labels SET label = ? WHERE label = ?`, new, old); err != nil {
+		return fmt.Errorf("renaming label: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
+
+// ListTicketsByLabels retrieves tickets with the optional status that carry
+// all of labels if matchAll is set, or any of them otherwise.
+func (db *DB) ListTicketsByLabels(labels []string, matchAll bool, status *ticket.Status) ([]*ticket.Ticket, error) {
+	return db.ListTicketsWithLabels(status, labels, matchAll, nil)
+}
+
+// ListTicketsWithLabels retrieves tickets with the optional status that
+// carry labels, matching all of them if matchAll is set or any of them
+// otherwise, and none of the exclude labels. Empty labels match every
+// ticket. A nil status matches every ticket that isn't deleted.
+func (db *DB) ListTicketsWithLabels(status *ticket.Status, labels []string, matchAll bool, exclude []string) ([]*ticket.Ticket, error) {
+	var conditions []string
+	var args []any
+
+	if status != nil {
+		conditions = append(conditions, "t.status = ?")
+		args = append(args, string(*status))
+	} else {
+		conditions = append(conditions, "t.status != 'deleted'")
+	}
+	if labels = slices.Compact(slices.Sorted(slices.Values(labels))); len(labels) > 0 {
+		subquery := "SELECT ticket_id FROM ticket_labels WHERE label IN (" + placeholders(len(labels)) + ")"
+		if matchAll {
+			subquery += " GROUP BY ticket_id HAVING COUNT(DISTINCT label) = ?"
+		}
+		conditions = append(conditions, "t.id IN ("+subquery+")")
+		for _, l := range labels {
+			args = append(args, l)
 		}
-		if t, ok := ticketMap[ticketID]; ok {
-			t.Labels = append(t.Labels, label)
+		if matchAll {
+			args = append(args, len(labels))
+		}
+	}
+	if len(exclude) > 0 {
+		conditions = append(conditions, "t.id NOT IN (SELECT ticket_id FROM ticket_labels WHERE label IN ("+placeholders(len(exclude))+"))")
+		for _, l := range exclude {
+			args = append(args, l)
 		}
 	}
 
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("iterating labels: %w", err)
+	rows, err := db.conn.Query(`
+		SELECT t.id, t.title, t.description, t.type, t.status, t.priority, t.assignee, t.close_reason, t.order_rank, t.estimate, t.created_by, t.updated_by, t.idempotency_key, t.created, t.updated
+		FROM tickets t
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY t.priority ASC, t.order_rank = 0, t.order_rank ASC, t.created ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying tickets by labels: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// ListByTypes retrieves tickets of any of the given types. A nil status
+// matches every ticket that isn't deleted.
+func (db *DB) ListByTypes(types []ticket.Type, status *ticket.Status) ([]*ticket.Ticket, error) {
+	conditions := []string{"type IN (" + placeholders(len(types)) + ")"}
+	var args []any
+	for _, t := range types {
+		args = append(args, string(t))
+	}
+	if status != nil {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(*status))
+	} else {
+		conditions = append(conditions, "status != 'deleted'")
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+		FROM tickets
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying tickets by type: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// placeholders returns n comma-separated SQL placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// loadLabelsAndWatchers populates the labels and watchers of a slice of
+// tickets.
+func (db *DB) loadLabelsAndWatchers(tickets []*ticket.Ticket) error {
+	if err := db.loadLabelsForTickets(tickets); err != nil {
+		return err
+	}
+	return db.loadWatchersForTickets(tickets)
+}
+
+// loadLabelsForTickets fetches and populates labels for a slice of tickets.
+func (db *DB) loadLabelsForTickets(tickets []*ticket.Ticket) error {
+	return db.loadTicketValues(tickets, "ticket_labels", "label", func(t *ticket.Ticket, label string) {
+		t.Labels = append(t.Labels, label)
+	})
+}
+
+// loadWatchersForTickets fetches and populates watchers for a slice of tickets.
+func (db *DB) loadWatchersForTickets(tickets []*ticket.Ticket) error {
+	return db.loadTicketValues(tickets, "ticket_watchers", "watcher", func(t *ticket.Ticket, watcher string) {
+		t.Watchers = append(t.Watchers, watcher)
+	})
+}
+
+// maxBoundIDs caps the ticket IDs bound in one query, keeping well under
+// SQLite's limit on host parameters.
+const maxBoundIDs = 500
+
+// loadTicketValues reads column from table for just the given tickets, in
+// batches of at most maxBoundIDs, and passes each value to add in sorted
+// order.
+func (db *DB) loadTicketValues(tickets []*ticket.Ticket, table, column string, add func(*ticket.Ticket, string)) error {
+	ticketMap := make(map[string]*ticket.Ticket, len(tickets))
+	for _, t := range tickets {
+		ticketMap[t.ID] = t
+	}
+
+	for start := 0; start < len(tickets); start += maxBoundIDs {
+		batch := tickets[start:min(start+maxBoundIDs, len(tickets))]
+		args := make([]any, len(batch))
+		for i, t := range batch {
+			args[i] = t.ID
+		}
+
+		rows, err := db.conn.Query(fmt.Sprintf(`
+			SELECT ticket_id, %[1]s FROM %[2]s
+			WHERE ticket_id IN (%[3]s)
+			ORDER BY ticket_id, %[1]s
+		`, column, table, placeholders(len(batch))), args...)
+		if err != nil {
+			return fmt.Errorf("querying %ss: %w", column, err)
+		}
+
+		for rows.Next() {
+			var ticketID, value string
+			if err := rows.Scan(&ticketID, &value); err != nil {
+				rows.Close()
+				return fmt.Errorf("scanning %s: %w", column, err)
+			}
+			if t, ok := ticketMap[ticketID]; ok {
+				add(t, value)
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("iterating %ss: %w", column, err)
+		}
 	}
 
 	return nil
@@ -486,9 +1190,10 @@ func scanTickets(rows *sql.Rows) ([]*ticket.Ticket, error) {
 		var statusStr string
 		var issueType sql.NullString
 		var assignee sql.NullString
+		var closeReason sql.NullString
 		var created, updated string
 
-		if err := rows.Scan(&t.ID, &t.Title, &t.Description, &issueType, &statusStr, &t.Priority, &assignee, &created, &updated); err != nil {
+		if err := rows.Scan(&t.ID, &t.Title, &t.Description, &issueType, &statusStr, &t.Priority, &assignee, &closeReason, &t.Rank, &t.Estimate, &t.CreatedBy, &t.UpdatedBy, &t.IdempotencyKey, &created, &updated); err != nil {
 			return nil, fmt.Errorf("scanning ticket: %w", err)
 		}
 
@@ -499,6 +1204,9 @@ func scanTickets(rows *sql.Rows) ([]*ticket.Ticket, error) {
 		if assignee.Valid {
 			t.Assignee = assignee.String
 		}
+		if closeReason.Valid {
+			t.CloseReason = ticket.CloseReason(closeReason.String)
+		}
 		createdTime, err := time.Parse(time.RFC3339Nano, created)
 		if err != nil {
 			return nil, fmt.Errorf("parsing ticket created time: %w", err)
@@ -521,15 +1229,34 @@ func scanTickets(rows *sql.Rows) ([]*ticket.Ticket, error) {
 	return tickets, nil
 }
 
-// GetAllTickets retrieves all tickets from the database.
+// GetAllTickets retrieves all tickets from the database, including deleted ones.
 func (db *DB) GetAllTickets() ([]*ticket.Ticket, error) {
-	return db.ListTickets(nil)
+	rows, err := db.conn.Query(`
+		SELECT id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated
+		FROM tickets
+		ORDER BY priority ASC, order_rank = 0, order_rank ASC, created ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets, err := scanTickets(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.loadLabelsAndWatchers(tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
 }
 
 // GetAllComments retrieves all comments from the database.
 func (db *DB) GetAllComments() ([]*ticket.Comment, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, ticket_id, content, created
+		SELECT id, ticket_id, content, author, created, acked, acked_by
 		FROM comments
 		ORDER BY created ASC
 	`)
@@ -538,40 +1265,22 @@ func (db *DB) GetAllComments() ([]*ticket.Comment, error) {
 	}
 	defer rows.Close()
 
-	var comments []*ticket.Comment
-	for rows.Next() {
-		var c ticket.Comment
-		var created string
-
-		if err := rows.Scan(&c.ID, &c.TicketID, &c.Content, &created); err != nil {
-			return nil, fmt.Errorf("scanning comment: %w", err)
-		}
-
-		createdTime, err := time.Parse(time.RFC3339Nano, created)
-		if err != nil {
-			return nil, fmt.Errorf("parsing comment time: %w", err)
-		}
-		c.Created = createdTime
-		comments = append(comments, &c)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating comments: %w", err)
-	}
-
-	return comments, nil
+	return scanComments(rows)
 }
 
 // InsertComment adds a new comment to the database.
 func (db *DB) InsertComment(c *ticket.Comment) error {
 	_, err := db.conn.Exec(`
-		INSERT INTO comments (id, ticket_id, content, created)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO comments (id, ticket_id, content, author, created, acked, acked_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`,
 		c.ID,
 		c.TicketID,
 		c.Content,
-		c.Created.Format(time.RFC3339Nano),
+		c.Author,
+		formatTime(c.Created),
+		formatAcked(c),
+		c.AckedBy,
 	)
 	if err != nil {
 		return fmt.Errorf("inserting comment: %w", err)
@@ -579,10 +1288,58 @@ func (db *DB) InsertComment(c *ticket.Comment) error {
 	return nil
 }
 
+// UpdateComment replaces an existing comment.
+func (db *DB) UpdateComment(c *ticket.Comment) error {
+	result, err := db.conn.Exec(`
+		UPDATE comments SET content = ?, author = ?, acked = ?, acked_by = ?
+		WHERE id = ?
+	`,
+		c.Content,
+		c.Author,
+		formatAcked(c),
+		c.AckedBy,
+		c.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("updating comment: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("comment %s not found", c.ID)
+	}
+	return nil
+}
+
+// GetComment retrieves a comment by ID, or returns nil if there is none.
+func (db *DB) GetComment(id string) (*ticket.Comment, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, ticket_id, content, author, created, acked, acked_by
+		FROM comments WHERE id = ?
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("querying comment: %w", err)
+	}
+	defer rows.Close()
+
+	comments, err := scanComments(rows)
+	if err != nil || len(comments) == 0 {
+		return nil, err
+	}
+	return comments[0], nil
+}
+
+// formatAcked returns the acknowledgement time of c for the cache, or "" if
+// it hasn't been acknowledged.
+func formatAcked(c *ticket.Comment) string {
+	if !c.IsAcked() {
+		return ""
+	}
+	return formatTime(c.Acked)
+}
+
 // GetCommentsForTicket retrieves all comments for a ticket, ordered by creation time.
 func (db *DB) GetCommentsForTicket(ticketID string) ([]*ticket.Comment, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, ticket_id, content, created
+		SELECT id, ticket_id, content, author, created, acked, acked_by
 		FROM comments WHERE ticket_id = ?
 		ORDER BY created ASC
 	`, ticketID)
@@ -591,12 +1348,18 @@ func (db *DB) GetCommentsForTicket(ticketID string) ([]*ticket.Comment, error) {
 	}
 	defer rows.Close()
 
+	return scanComments(rows)
+}
+
+// scanComments reads comments from query rows.
+func scanComments(rows *sql.Rows) ([]*ticket.Comment, error) {
 	var comments []*ticket.Comment
 	for rows.Next() {
 		var c ticket.Comment
+		var author, acked, ackedBy sql.NullString
 		var created string
 
-		if err := rows.Scan(&c.ID, &c.TicketID, &c.Content, &created); err != nil {
+		if err := rows.Scan(&c.ID, &c.TicketID, &c.Content, &author, &created, &acked, &ackedBy); err != nil {
 			return nil, fmt.Errorf("scanning comment: %w", err)
 		}
 
@@ -604,6 +1367,13 @@ func (db *DB) GetCommentsForTicket(ticketID string) ([]*ticket.Comment, error) {
 		if err != nil {
 			return nil, fmt.Errorf("parsing comment time: %w", err)
 		}
+		if acked.String != "" {
+			if c.Acked, err = time.Parse(time.RFC3339Nano, acked.String); err != nil {
+				return nil, fmt.Errorf("parsing comment acknowledgement time: %w", err)
+			}
+		}
+		c.Author = author.String
+		c.AckedBy = ackedBy.String
 		c.Created = createdTime
 		comments = append(comments, &c)
 	}
@@ -631,6 +1401,10 @@ func (db *DB) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Commen
 		return fmt.Errorf("clearing ticket labels: %w", err)
 	}
 
+	if _, err := tx.Exec("DELETE FROM ticket_watchers"); err != nil {
+		return fmt.Errorf("clearing ticket watchers: %w", err)
+	}
+
 	if _, err := tx.Exec("DELETE FROM comments"); err != nil {
 		return fmt.Errorf("clearing comments: %w", err)
 	}
@@ -640,8 +1414,8 @@ func (db *DB) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Commen
 	}
 
 	ticketStmt, err := tx.Prepare(`
-		INSERT INTO tickets (id, title, description, status, priority, assignee, created, updated)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tickets (id, title, description, type, status, priority, assignee, close_reason, order_rank, estimate, created_by, updated_by, idempotency_key, created, updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("preparing ticket insert: %w", err)
@@ -654,16 +1428,29 @@ func (db *DB) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Commen
 	}
 	defer labelStmt.Close()
 
+	watcherStmt, err := tx.Prepare(`INSERT INTO ticket_watchers (ticket_id, watcher) VALUES (?, ?)`)
+	if err != nil {
+		return fmt.Errorf("preparing watcher insert: %w", err)
+	}
+	defer watcherStmt.Close()
+
 	for _, t := range tickets {
 		_, err := ticketStmt.Exec(
 			t.ID,
 			t.Title,
 			t.Description,
+			string(t.Type),
 			string(t.Status),
 			t.Priority,
 			t.Assignee,
-			t.Created.Format(time.RFC3339Nano),
-			t.Updated.Format(time.RFC3339Nano),
+			string(t.CloseReason),
+			t.Rank,
+			t.Estimate,
+			t.CreatedBy,
+			t.UpdatedBy,
+			t.IdempotencyKey,
+			formatTime(t.Created),
+			formatTime(t.Updated),
 		)
 		if err != nil {
 			return fmt.Errorf("inserting ticket %s: %w", t.ID, err)
@@ -674,11 +1461,17 @@ func (db *DB) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Commen
 				return fmt.Errorf("inserting label for ticket %s: %w", t.ID, err)
 			}
 		}
+
+		for _, watcher := range t.Watchers {
+			if _, err := watcherStmt.Exec(t.ID, watcher); err != nil {
+				return fmt.Errorf("inserting watcher for ticket %s: %w", t.ID, err)
+			}
+		}
 	}
 
 	commentStmt, err := tx.Prepare(`
-		INSERT INTO comments (id, ticket_id, content, created)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO comments (id, ticket_id, content, author, created, acked, acked_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("preparing comment insert: %w", err)
@@ -690,7 +1483,10 @@ func (db *DB) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Commen
 			c.ID,
 			c.TicketID,
 			c.Content,
-			c.Created.Format(time.RFC3339Nano),
+			c.Author,
+			formatTime(c.Created),
+			formatAcked(c),
+			c.AckedBy,
 		)
 		if err != nil {
 			return fmt.Errorf("inserting comment %s: %w", c.ID, err)
@@ -712,7 +1508,7 @@ func (db *DB) RebuildFromAll(tickets []*ticket.Ticket, comments []*ticket.Commen
 			d.FromTicketID,
 			d.ToTicketID,
 			string(d.Type),
-			d.Created.Format(time.RFC3339Nano),
+			formatTime(d.Created),
 		)
 		if err != nil {
 			return fmt.Errorf("inserting dependency %s: %w", d.ID, err)
@@ -736,7 +1532,7 @@ func (db *DB) InsertDependency(d *ticket.Dependency) error {
 		d.FromTicketID,
 		d.ToTicketID,
 		string(d.Type),
-		d.Created.Format(time.RFC3339Nano),
+		formatTime(d.Created),
 	)
 	if err != nil {
 		return fmt.Errorf("inserting dependency: %w", err)
@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"database/sql"
+	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -334,6 +337,155 @@ func TestDB_ListReadyTickets(t *testing.T) {
 	}
 }
 
+func TestDB_ListBlockedTickets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Blocked low", Type: ticket.TypeBug, Status: ticket.StatusOpen, Priority: 3, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Open Blocker", Type: ticket.TypeFeature, Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+		{ID: "TH-333333", Title: "Blocked by closed", Type: ticket.TypeTask, Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+		{ID: "TH-444444", Title: "Closed Blocker", Type: ticket.TypeTask, Status: ticket.StatusClosed, Priority: 1, Created: now, Updated: now},
+		{ID: "TH-555555", Title: "Blocked high", Type: ticket.TypeBug, Status: ticket.StatusOpen, Priority: 0, Created: now, Updated: now},
+		{ID: "TH-666666", Title: "Closed but blocked", Type: ticket.TypeBug, Status: ticket.StatusClosed, Priority: 0, Created: now, Updated: now},
+	}
+
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	dependencies := []*ticket.Dependency{
+		{ID: "D1", FromTicketID: "TH-111111", ToTicketID: "TH-222222", Type: ticket.DependencyBlockedBy, Created: now},
+		{ID: "D2", FromTicketID: "TH-333333", ToTicketID: "TH-444444", Type: ticket.DependencyBlockedBy, Created: now},
+		{ID: "D3", FromTicketID: "TH-555555", ToTicketID: "TH-222222", Type: ticket.DependencyBlockedBy, Created: now},
+		{ID: "D4", FromTicketID: "TH-666666", ToTicketID: "TH-222222", Type: ticket.DependencyBlockedBy, Created: now},
+	}
+
+	for _, d := range dependencies {
+		if err := db.InsertDependency(d); err != nil {
+			t.Fatalf("InsertDependency() error = %v", err)
+		}
+	}
+
+	blocked, err := db.ListBlockedTickets()
+	if err != nil {
+		t.Fatalf("ListBlockedTickets() error = %v", err)
+	}
+
+	// Only open tickets with an open blocker are returned, highest priority first.
+	// TH-333333 is only blocked by a closed ticket and TH-666666 is itself closed.
+	if len(blocked) != 2 {
+		t.Fatalf("ListBlockedTickets() returned %d tickets, want 2", len(blocked))
+	}
+	if blocked[0].ID != "TH-555555" || blocked[1].ID != "TH-111111" {
+		t.Errorf("ListBlockedTickets() = [%s %s], want [TH-555555 TH-111111]", blocked[0].ID, blocked[1].ID)
+	}
+}
+
+func TestDB_FindByTitle(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Fix the login bug", Type: ticket.TypeBug, Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Fix the login bug", Type: ticket.TypeBug, Status: ticket.StatusClosed, Priority: 1, Created: now, Updated: now},
+		{ID: "TH-333333", Title: "Fix the logout bug", Type: ticket.TypeBug, Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+	}
+
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	found, err := db.FindByTitle("  FIX the Login bug ")
+	if err != nil {
+		t.Fatalf("FindByTitle() error = %v", err)
+	}
+	if len(found) != 1 || found[0].ID != "TH-111111" {
+		t.Errorf("FindByTitle() = %v, want only TH-111111", found)
+	}
+
+	found, err = db.FindByTitle("Something else")
+	if err != nil {
+		t.Fatalf("FindByTitle() error = %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("FindByTitle() returned %d tickets, want 0", len(found))
+	}
+}
+
+func TestDB_ListTickets_ExcludesDeleted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Open", Type: ticket.TypeTask, Status: ticket.StatusOpen, Priority: 1, Labels: []string{"backend"}, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Deleted", Type: ticket.TypeTask, Status: ticket.StatusDeleted, Priority: 1, Labels: []string{"backend"}, Created: now, Updated: now},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	all, err := db.ListTickets(nil)
+	if err != nil {
+		t.Fatalf("ListTickets() error = %v", err)
+	}
+	if len(all) != 1 || all[0].ID != "TH-111111" {
+		t.Errorf("ListTickets(nil) = %d tickets, want only TH-111111", len(all))
+	}
+
+	labeled, err := db.ListTicketsByLabel("backend", nil)
+	if err != nil {
+		t.Fatalf("ListTicketsByLabel() error = %v", err)
+	}
+	if len(labeled) != 1 {
+		t.Errorf("ListTicketsByLabel(nil) returned %d tickets, want 1", len(labeled))
+	}
+
+	deleted := ticket.StatusDeleted
+	onlyDeleted, err := db.ListTickets(&deleted)
+	if err != nil {
+		t.Fatalf("ListTickets() error = %v", err)
+	}
+	if len(onlyDeleted) != 1 || onlyDeleted[0].ID != "TH-222222" {
+		t.Errorf("ListTickets(deleted) = %d tickets, want only TH-222222", len(onlyDeleted))
+	}
+
+	everything, err := db.GetAllTickets()
+	if err != nil {
+		t.Fatalf("GetAllTickets() error = %v", err)
+	}
+	if len(everything) != 2 {
+		t.Errorf("GetAllTickets() returned %d tickets, want 2", len(everything))
+	}
+}
+
 func TestDB_RebuildFromTickets(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "test.db")
@@ -386,3 +538,708 @@ func TestDB_RebuildFromTickets(t *testing.T) {
 		t.Errorf("all[1].Type = %q, want %q", all[1].Type, ticket.TypeFeature)
 	}
 }
+
+func TestOpenDB_RecreatesOutdatedSchema(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	// Simulate a cache written by an older version without the author column.
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	_, err = conn.Exec(`
+		CREATE TABLE comments (id TEXT PRIMARY KEY, ticket_id TEXT NOT NULL, content TEXT NOT NULL, created TEXT NOT NULL);
+		CREATE TABLE metadata (key TEXT PRIMARY KEY, value TEXT);
+		INSERT INTO metadata (key, value) VALUES ('jsonl_modtime', '12345');
+	`)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("creating old schema: %v", err)
+	}
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	// The stored mod time is cleared so the cache is rebuilt from JSONL.
+	if modTime, _ := db.GetMetadata("jsonl_modtime"); modTime != "" {
+		t.Errorf("jsonl_modtime = %q, want it cleared", modTime)
+	}
+
+	c := &ticket.Comment{ID: "TH-cabc123", TicketID: "TH-abc123", Content: "Hi", Author: "Alice", Created: time.Now().UTC()}
+	if err := db.InsertComment(c); err != nil {
+		t.Fatalf("InsertComment() error = %v", err)
+	}
+	comments, err := db.GetCommentsForTicket("TH-abc123")
+	if err != nil {
+		t.Fatalf("GetCommentsForTicket() error = %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "Alice" {
+		t.Errorf("GetCommentsForTicket() = %+v, want one comment by Alice", comments)
+	}
+}
+
+func TestDB_ListTickets_OrdersByRank(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Oldest", Status: ticket.StatusOpen, Priority: 1, Rank: 2, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Middle", Status: ticket.StatusOpen, Priority: 1, Rank: 1, Created: now.Add(time.Second), Updated: now},
+		{ID: "TH-333333", Title: "Urgent", Status: ticket.StatusOpen, Priority: 0, Rank: 5, Created: now.Add(2 * time.Second), Updated: now},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	all, err := db.ListTickets(nil)
+	if err != nil {
+		t.Fatalf("ListTickets() error = %v", err)
+	}
+	var got []string
+	for _, tk := range all {
+		got = append(got, tk.ID)
+	}
+	want := []string{"TH-333333", "TH-222222", "TH-111111"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ListTickets() order = %v, want %v", got, want)
+	}
+	if all[1].Rank != 1 {
+		t.Errorf("Rank = %d, want 1", all[1].Rank)
+	}
+}
+
+func TestDB_TicketAuthors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tk := &ticket.Ticket{ID: "TH-111111", Title: "Authored", Status: ticket.StatusOpen, CreatedBy: "Alice", Created: now, Updated: now}
+	if err := db.InsertTicket(tk); err != nil {
+		t.Fatalf("InsertTicket() error = %v", err)
+	}
+
+	tk.UpdatedBy = "Bob"
+	if err := db.UpdateTicket(tk); err != nil {
+		t.Fatalf("UpdateTicket() error = %v", err)
+	}
+
+	got, err := db.GetTicket(tk.ID)
+	if err != nil {
+		t.Fatalf("GetTicket() error = %v", err)
+	}
+	if got.CreatedBy != "Alice" || got.UpdatedBy != "Bob" {
+		t.Errorf("authors = %q/%q, want Alice/Bob", got.CreatedBy, got.UpdatedBy)
+	}
+}
+
+func TestDB_TicketEstimate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tk := &ticket.Ticket{ID: "TH-111111", Title: "Sized", Status: ticket.StatusOpen, Estimate: 3, Created: now, Updated: now}
+	if err := db.InsertTicket(tk); err != nil {
+		t.Fatalf("InsertTicket() error = %v", err)
+	}
+	if got, _ := db.GetTicket(tk.ID); got.Estimate != 3 {
+		t.Errorf("Estimate after insert = %d, want 3", got.Estimate)
+	}
+
+	tk.Estimate = 5
+	if err := db.UpdateTicket(tk); err != nil {
+		t.Fatalf("UpdateTicket() error = %v", err)
+	}
+	if got, _ := db.GetTicket(tk.ID); got.Estimate != 5 {
+		t.Errorf("Estimate after update = %d, want 5", got.Estimate)
+	}
+
+	tk.Estimate = 8
+	if err := db.RebuildFromTickets([]*ticket.Ticket{tk}); err != nil {
+		t.Fatalf("RebuildFromTickets() error = %v", err)
+	}
+	tickets, err := db.ListTickets(nil)
+	if err != nil {
+		t.Fatalf("ListTickets() error = %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].Estimate != 8 {
+		t.Errorf("Estimate after rebuild = %+v, want 8", tickets)
+	}
+}
+
+func TestDB_ListStaleTickets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Fresh", Status: ticket.StatusOpen, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Stale", Status: ticket.StatusOpen, Created: now, Updated: now.Add(-40 * 24 * time.Hour)},
+		{ID: "TH-333333", Title: "Staler", Status: ticket.StatusOpen, Created: now, Updated: now.Add(-90*24*time.Hour + 500*time.Millisecond)},
+		{ID: "TH-444444", Title: "Closed", Status: ticket.StatusClosed, Created: now, Updated: now.Add(-90 * 24 * time.Hour)},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	stale, err := db.ListStaleTickets(now.Add(-30 * 24 * time.Hour))
+	if err != nil {
+		t.Fatalf("ListStaleTickets() error = %v", err)
+	}
+	var got []string
+	for _, tk := range stale {
+		got = append(got, tk.ID)
+	}
+	want := []string{"TH-333333", "TH-222222"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ListStaleTickets() = %v, want %v", got, want)
+	}
+}
+
+func TestDB_ListTicketsWithLabels(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Backend", Status: ticket.StatusOpen, Labels: []string{"backend"}, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Backend wontfix", Status: ticket.StatusOpen, Labels: []string{"backend", "wontfix"}, Created: now.Add(time.Second), Updated: now},
+		{ID: "TH-333333", Title: "Stale", Status: ticket.StatusOpen, Labels: []string{"stale"}, Created: now.Add(2 * time.Second), Updated: now},
+		{ID: "TH-444444", Title: "Unlabeled", Status: ticket.StatusOpen, Created: now.Add(3 * time.Second), Updated: now},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		labels  []string
+		exclude []string
+		want    string
+	}{
+		{nil, []string{"wontfix", "stale"}, "Backend,Unlabeled"},
+		{[]string{"backend"}, []string{"wontfix"}, "Backend"},
+		{nil, nil, "Backend,Backend wontfix,Stale,Unlabeled"},
+	}
+	for _, tt := range tests {
+		got, err := db.ListTicketsWithLabels(nil, tt.labels, false, tt.exclude)
+		if err != nil {
+			t.Fatalf("ListTicketsWithLabels() error = %v", err)
+		}
+		var titles []string
+		for _, tk := range got {
+			titles = append(titles, tk.Title)
+		}
+		if strings.Join(titles, ",") != tt.want {
+			t.Errorf("ListTicketsWithLabels(%v, %v) = %v, want %s", tt.labels, tt.exclude, titles, tt.want)
+		}
+	}
+}
+
+func TestDB_ListTicketsByLabels(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Both", Status: ticket.StatusOpen, Labels: []string{"backend", "urgent"}, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Backend", Status: ticket.StatusOpen, Labels: []string{"backend"}, Created: now.Add(time.Second), Updated: now},
+		{ID: "TH-333333", Title: "Urgent", Status: ticket.StatusOpen, Labels: []string{"urgent", "ui"}, Created: now.Add(2 * time.Second), Updated: now},
+		{ID: "TH-444444", Title: "Other", Status: ticket.StatusOpen, Labels: []string{"docs"}, Created: now.Add(3 * time.Second), Updated: now},
+		{ID: "TH-555555", Title: "Closed both", Status: ticket.StatusClosed, Labels: []string{"backend", "urgent"}, Created: now.Add(4 * time.Second), Updated: now},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	open := ticket.StatusOpen
+	tests := []struct {
+		labels   []string
+		matchAll bool
+		status   *ticket.Status
+		want     string
+	}{
+		{[]string{"backend", "urgent"}, false, &open, "Both,Backend,Urgent"},
+		{[]string{"backend", "urgent"}, true, &open, "Both"},
+		{[]string{"backend", "urgent"}, true, nil, "Both,Closed both"},
+		{[]string{"backend", "backend"}, true, &open, "Both,Backend"},
+		{[]string{"backend", "docs"}, true, &open, ""},
+	}
+	for _, tt := range tests {
+		got, err := db.ListTicketsByLabels(tt.labels, tt.matchAll, tt.status)
+		if err != nil {
+			t.Fatalf("ListTicketsByLabels() error = %v", err)
+		}
+		var titles []string
+		for _, tk := range got {
+			titles = append(titles, tk.Title)
+		}
+		if strings.Join(titles, ",") != tt.want {
+			t.Errorf("ListTicketsByLabels(%v, matchAll=%v) = %v, want %s", tt.labels, tt.matchAll, titles, tt.want)
+		}
+	}
+}
+
+func TestDB_ListByWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Watched", Status: ticket.StatusOpen, Watchers: []string{"alice", "bob"}, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Bob only", Status: ticket.StatusOpen, Watchers: []string{"bob"}, Created: now.Add(time.Second), Updated: now},
+		{ID: "TH-333333", Title: "Unwatched", Status: ticket.StatusOpen, Created: now.Add(2 * time.Second), Updated: now},
+		{ID: "TH-444444", Title: "Closed", Status: ticket.StatusClosed, Watchers: []string{"alice"}, Created: now.Add(3 * time.Second), Updated: now},
+		{ID: "TH-555555", Title: "Deleted", Status: ticket.StatusDeleted, Watchers: []string{"alice"}, Created: now.Add(4 * time.Second), Updated: now},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	open := ticket.StatusOpen
+	tests := []struct {
+		user   string
+		status *ticket.Status
+		want   string
+	}{
+		{"alice", nil, "Watched,Closed"},
+		{"alice", &open, "Watched"},
+		{"bob", nil, "Watched,Bob only"},
+		{"carol", nil, ""},
+	}
+	for _, tt := range tests {
+		got, err := db.ListByWatcher(tt.user, tt.status)
+		if err != nil {
+			t.Fatalf("ListByWatcher() error = %v", err)
+		}
+		var titles []string
+		for _, tk := range got {
+			titles = append(titles, tk.Title)
+		}
+		if strings.Join(titles, ",") != tt.want {
+			t.Errorf("ListByWatcher(%s, %v) = %v, want %s", tt.user, tt.status, titles, tt.want)
+		}
+	}
+}
+
+func TestDB_RenameLabel(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Typo", Status: ticket.StatusOpen, Labels: []string{"bugg", "ui"}, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Both", Status: ticket.StatusOpen, Labels: []string{"bug", "bugg"}, Created: now, Updated: now},
+	} {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	if err := db.RenameLabel("bugg", "bug"); err != nil {
+		t.Fatalf("RenameLabel() error = %v", err)
+	}
+
+	for id, want := range map[string]string{"TH-111111": "bug,ui", "TH-222222": "bug"} {
+		got, err := db.GetTicket(id)
+		if err != nil {
+			t.Fatalf("GetTicket() error = %v", err)
+		}
+		if strings.Join(got.Labels, ",") != want {
+			t.Errorf("%s labels = %v, want %s", id, got.Labels, want)
+		}
+	}
+}
+
+func TestDB_ListUnassigned(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Nobody", Status: ticket.StatusOpen, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Alice", Status: ticket.StatusOpen, Assignee: "Alice", Created: now.Add(time.Second), Updated: now},
+		{ID: "TH-333333", Title: "Closed nobody", Status: ticket.StatusClosed, Created: now.Add(2 * time.Second), Updated: now},
+		{ID: "TH-444444", Title: "Deleted nobody", Status: ticket.StatusDeleted, Created: now.Add(3 * time.Second), Updated: now},
+	} {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	open := ticket.StatusOpen
+	for _, tt := range []struct {
+		status *ticket.Status
+		want   string
+	}{
+		{nil, "Nobody,Closed nobody"},
+		{&open, "Nobody"},
+	} {
+		got, err := db.ListUnassigned(tt.status)
+		if err != nil {
+			t.Fatalf("ListUnassigned() error = %v", err)
+		}
+		var titles []string
+		for _, tk := range got {
+			titles = append(titles, tk.Title)
+		}
+		if strings.Join(titles, ",") != tt.want {
+			t.Errorf("ListUnassigned(%v) = %v, want %s", tt.status, titles, tt.want)
+		}
+	}
+}
+
+// insertLabeledTickets inserts n tickets, each labeled with its own index.
+func insertLabeledTickets(tb testing.TB, db *DB, n int) []*ticket.Ticket {
+	tb.Helper()
+
+	now := time.Now().UTC()
+	tickets := make([]*ticket.Ticket, n)
+	for i := range tickets {
+		tickets[i] = &ticket.Ticket{
+			ID:      fmt.Sprintf("TH-%06d", i),
+			Title:   fmt.Sprintf("Ticket %d", i),
+			Status:  ticket.StatusOpen,
+			Labels:  []string{fmt.Sprintf("l%d", i)},
+			Created: now,
+			Updated: now,
+		}
+	}
+	if err := db.RebuildFromTickets(tickets); err != nil {
+		tb.Fatalf("RebuildFromTickets() error = %v", err)
+	}
+	return tickets
+}
+
+func TestDB_LoadLabelsForTickets(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	insertLabeledTickets(t, db, 2*maxBoundIDs+10)
+
+	// Load labels for fresh copies of a subset that spans several batches,
+	// skipping every other ticket.
+	var subset []*ticket.Ticket
+	for i := 1; i < 2*maxBoundIDs+10; i += 2 {
+		subset = append(subset, &ticket.Ticket{ID: fmt.Sprintf("TH-%06d", i)})
+	}
+	if err := db.loadLabelsForTickets(subset); err != nil {
+		t.Fatalf("loadLabelsForTickets() error = %v", err)
+	}
+	for _, tk := range subset {
+		var i int
+		fmt.Sscanf(tk.ID, "TH-%06d", &i)
+		if want := fmt.Sprintf("l%d", i); len(tk.Labels) != 1 || tk.Labels[0] != want {
+			t.Fatalf("%s labels = %v, want [%s]", tk.ID, tk.Labels, want)
+		}
+	}
+
+	if err := db.loadLabelsForTickets(nil); err != nil {
+		t.Errorf("loadLabelsForTickets(nil) error = %v", err)
+	}
+}
+
+func BenchmarkDB_LoadLabelsForFewTickets(b *testing.B) {
+	db, err := OpenDB(filepath.Join(b.TempDir(), "test.db"))
+	if err != nil {
+		b.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	tickets := insertLabeledTickets(b, db, 5000)
+	few := tickets[:10]
+
+	for b.Loop() {
+		for _, tk := range few {
+			tk.Labels = nil
+		}
+		if err := db.loadLabelsForTickets(few); err != nil {
+			b.Fatalf("loadLabelsForTickets() error = %v", err)
+		}
+	}
+}
+
+func TestDB_BlockedTicketIDs(t *testing.T) {
+	dir := t.TempDir()
+	db, err := OpenDB(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Open blocker", Status: ticket.StatusOpen, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Closed blocker", Status: ticket.StatusClosed, Created: now, Updated: now},
+		{ID: "TH-333333", Title: "Blocked", Status: ticket.StatusOpen, Created: now, Updated: now},
+		{ID: "TH-444444", Title: "Blocked by closed", Status: ticket.StatusOpen, Created: now, Updated: now},
+		{ID: "TH-555555", Title: "Closed and blocked", Status: ticket.StatusClosed, Created: now, Updated: now},
+	} {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+	for _, d := range []*ticket.Dependency{
+		{ID: "TH-d11111", FromTicketID: "TH-333333", ToTicketID: "TH-111111", Type: ticket.DependencyBlockedBy, Created: now},
+		{ID: "TH-d22222", FromTicketID: "TH-444444", ToTicketID: "TH-222222", Type: ticket.DependencyBlockedBy, Created: now},
+		{ID: "TH-d33333", FromTicketID: "TH-555555", ToTicketID: "TH-111111", Type: ticket.DependencyBlockedBy, Created: now},
+		{ID: "TH-d44444", FromTicketID: "TH-111111", ToTicketID: "TH-333333", Type: ticket.DependencyRelatedTo, Created: now},
+	} {
+		if err := db.InsertDependency(d); err != nil {
+			t.Fatalf("InsertDependency() error = %v", err)
+		}
+	}
+
+	blocked, err := db.BlockedTicketIDs()
+	if err != nil {
+		t.Fatalf("BlockedTicketIDs() error = %v", err)
+	}
+	if len(blocked) != 1 || !blocked["TH-333333"] {
+		t.Errorf("BlockedTicketIDs() = %v, want only TH-333333", blocked)
+	}
+}
+
+func TestDB_GetTicket_MalformedTime(t *testing.T) {
+	db, err := OpenDB(MemoryPath)
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	tk, _ := ticket.New("TH", "Task", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := db.InsertTicket(tk); err != nil {
+		t.Fatalf("InsertTicket() error = %v", err)
+	}
+	if _, err := db.conn.Exec("UPDATE tickets SET updated = 'not a time' WHERE id = ?", tk.ID); err != nil {
+		t.Fatalf("corrupting updated time: %v", err)
+	}
+
+	if _, err := db.GetTicket(tk.ID); err == nil {
+		t.Error("GetTicket() with a malformed updated time expected error")
+	}
+}
+
+func TestDB_ListUpdatedSince(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	cutoff := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Before", Status: ticket.StatusOpen, Created: cutoff, Updated: cutoff.Add(-time.Nanosecond)},
+		{ID: "TH-222222", Title: "At", Status: ticket.StatusOpen, Created: cutoff, Updated: cutoff},
+		{ID: "TH-333333", Title: "After", Status: ticket.StatusClosed, Created: cutoff, Updated: cutoff.Add(time.Hour)},
+		{ID: "TH-444444", Title: "Fractional", Status: ticket.StatusOpen, Created: cutoff, Updated: cutoff.Add(500 * time.Millisecond)},
+		{ID: "TH-555555", Title: "Deleted", Status: ticket.StatusDeleted, Created: cutoff, Updated: cutoff.Add(time.Hour)},
+		// Non-UTC times are normalized on write, so they compare correctly.
+		{ID: "TH-666666", Title: "Offset", Status: ticket.StatusOpen, Created: cutoff, Updated: cutoff.Add(-time.Minute).In(time.FixedZone("EST", -5*60*60))},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	ids := func(tickets []*ticket.Ticket) string {
+		var got []string
+		for _, tk := range tickets {
+			got = append(got, tk.ID)
+		}
+		return strings.Join(got, ",")
+	}
+
+	updated, err := db.ListUpdatedSince(cutoff, nil)
+	if err != nil {
+		t.Fatalf("ListUpdatedSince() error = %v", err)
+	}
+	if got, want := ids(updated), "TH-222222,TH-444444,TH-333333"; got != want {
+		t.Errorf("ListUpdatedSince() = %v, want %v", got, want)
+	}
+
+	closed := ticket.StatusClosed
+	updated, err = db.ListUpdatedSince(cutoff, &closed)
+	if err != nil {
+		t.Fatalf("ListUpdatedSince(closed) error = %v", err)
+	}
+	if got, want := ids(updated), "TH-333333"; got != want {
+		t.Errorf("ListUpdatedSince(closed) = %v, want %v", got, want)
+	}
+
+	var plan strings.Builder
+	rows, err := db.conn.Query(`EXPLAIN QUERY PLAN SELECT id FROM tickets WHERE julianday(updated) >= julianday(?)`, formatTime(cutoff))
+	if err != nil {
+		t.Fatalf("EXPLAIN QUERY PLAN error = %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		rows.Scan(&id, &parent, &notUsed, &detail)
+		plan.WriteString(detail)
+	}
+	if !strings.Contains(plan.String(), "idx_tickets_updated") {
+		t.Errorf("query plan = %q, want it to use idx_tickets_updated", plan.String())
+	}
+}
+
+// BenchmarkDB_ListUpdatedSince polls for the few tickets changed in the last
+// hour among many older ones, with and without idx_tickets_updated.
+func BenchmarkDB_ListUpdatedSince(b *testing.B) {
+	for _, indexed := range []bool{true, false} {
+		name := "indexed"
+		if !indexed {
+			name = "unindexed"
+		}
+		b.Run(name, func(b *testing.B) {
+			db, err := OpenDB(filepath.Join(b.TempDir(), "test.db"))
+			if err != nil {
+				b.Fatalf("OpenDB() error = %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now().UTC()
+			tickets := make([]*ticket.Ticket, 20000)
+			for i := range tickets {
+				tickets[i] = &ticket.Ticket{
+					ID:      fmt.Sprintf("TH-%06d", i),
+					Title:   fmt.Sprintf("Ticket %d", i),
+					Status:  ticket.StatusOpen,
+					Created: now,
+					Updated: now.Add(-time.Duration(i) * time.Minute),
+				}
+			}
+			if err := db.RebuildFromTickets(tickets); err != nil {
+				b.Fatalf("RebuildFromTickets() error = %v", err)
+			}
+			if !indexed {
+				if _, err := db.conn.Exec("DROP INDEX idx_tickets_updated"); err != nil {
+					b.Fatalf("dropping index: %v", err)
+				}
+			}
+
+			since := now.Add(-time.Hour)
+			for b.Loop() {
+				updated, err := db.ListUpdatedSince(since, nil)
+				if err != nil {
+					b.Fatalf("ListUpdatedSince() error = %v", err)
+				}
+				if len(updated) != 61 {
+					b.Fatalf("ListUpdatedSince() returned %d tickets, want 61", len(updated))
+				}
+			}
+		})
+	}
+}
+
+func TestDB_ListByTypes(t *testing.T) {
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("OpenDB() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Bug", Type: ticket.TypeBug, Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+		{ID: "TH-222222", Title: "Cleanup", Type: ticket.TypeCleanup, Status: ticket.StatusOpen, Priority: 0, Created: now, Updated: now},
+		{ID: "TH-333333", Title: "Feature", Type: ticket.TypeFeature, Status: ticket.StatusOpen, Priority: 0, Created: now, Updated: now},
+		{ID: "TH-444444", Title: "Closed bug", Type: ticket.TypeBug, Status: ticket.StatusClosed, Priority: 0, Created: now, Updated: now},
+		{ID: "TH-555555", Title: "Deleted bug", Type: ticket.TypeBug, Status: ticket.StatusDeleted, Priority: 0, Created: now, Updated: now},
+	}
+	for _, tk := range tickets {
+		if err := db.InsertTicket(tk); err != nil {
+			t.Fatalf("InsertTicket() error = %v", err)
+		}
+	}
+
+	ids := func(tickets []*ticket.Ticket) string {
+		var got []string
+		for _, tk := range tickets {
+			got = append(got, tk.ID)
+		}
+		return strings.Join(got, ",")
+	}
+
+	found, err := db.ListByTypes([]ticket.Type{ticket.TypeBug, ticket.TypeCleanup}, nil)
+	if err != nil {
+		t.Fatalf("ListByTypes() error = %v", err)
+	}
+	if got, want := ids(found), "TH-222222,TH-444444,TH-111111"; got != want {
+		t.Errorf("ListByTypes(bug, cleanup) = %v, want %v", got, want)
+	}
+
+	open := ticket.StatusOpen
+	found, err = db.ListByTypes([]ticket.Type{ticket.TypeBug, ticket.TypeCleanup}, &open)
+	if err != nil {
+		t.Fatalf("ListByTypes(open) error = %v", err)
+	}
+	if got, want := ids(found), "TH-222222,TH-111111"; got != want {
+		t.Errorf("ListByTypes(bug, cleanup, open) = %v, want %v", got, want)
+	}
+}
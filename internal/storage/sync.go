@@ -1,36 +1,231 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/ticket"
 )
 
-const metaKeyJSONLModTime = "jsonl_modtime"
+const (
+	metaKeyJSONLModTime  = "jsonl_modtime"
+	metaKeyJSONLChecksum = "jsonl_checksum"
+)
+
+// verboseOutput receives diagnostic messages for newly opened stores.
+var verboseOutput io.Writer
+
+// memoryCache makes newly opened stores keep their cache in memory.
+var memoryCache bool
+
+// SetMemoryCache makes newly opened stores keep their cache in an in-memory
+// database, rebuilt from the JSONL file on every open, instead of in the
+// cache file. Nothing is written to the cache path.
+func SetMemoryCache(enabled bool) {
+	memoryCache = enabled
+}
+
+// SetVerbose directs timing and cache rebuild diagnostics to w.
+// Pass nil to disable them.
+func SetVerbose(w io.Writer) {
+	verboseOutput = w
+}
+
+// DebugEnv is the environment variable that turns on debug logging. When it
+// is set to a true value such as 1, stores log opens, cache rebuilds, JSONL
+// writes, and SQL errors to stderr as key=value records.
+const DebugEnv = "THICKET_DEBUG"
+
+// debugWriter receives debug logs when DebugEnv is set.
+var debugWriter io.Writer = os.Stderr
+
+// newDebugLogger returns the debug logger for a newly opened store, or nil if
+// DebugEnv is not set.
+func newDebugLogger() *slog.Logger {
+	if on, _ := strconv.ParseBool(os.Getenv(DebugEnv)); !on {
+		return nil
+	}
+	return slog.New(slog.NewTextHandler(debugWriter, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// warningOutput receives warnings about problems the store worked around.
+var warningOutput io.Writer = os.Stderr
+
+// ErrReadOnly is returned when changing tickets in a store whose cache could
+// not be written, which usually means .thicket is on a read-only filesystem.
+var ErrReadOnly = errors.New("cannot change tickets: the .thicket directory is read-only")
 
 // Store provides synchronized access to ticket storage.
 type Store struct {
-	db    *DB
-	paths config.Paths
+	db       Backend
+	paths    config.Paths
+	verbose  io.Writer
+	debug    *slog.Logger
+	rebuilt  bool
+	repaired bool
+	readOnly bool
 }
 
 // Open creates a new Store, opening the SQLite database and syncing from JSONL if needed.
+//
+// If the cache cannot be written, the store uses an in-memory cache built
+// from the JSONL file instead, so read-only commands still work, and every
+// change returns ErrReadOnly.
 func Open(paths config.Paths) (*Store, error) {
+	start := time.Now()
+
+	cachePath := paths.Cache
+	readOnly := false
+	switch {
+	case memoryCache:
+		cachePath = MemoryPath
+	case !isWritable(paths.Cache):
+		cachePath = MemoryPath
+		readOnly = true
+	}
+
+	db, err := OpenDB(cachePath)
+	if err != nil {
+		if cachePath != paths.Cache {
+			return nil, err
+		}
+		return repairCache(paths, err)
+	}
+
+	store := &Store{db: db, paths: paths, verbose: verboseOutput, debug: newDebugLogger(), readOnly: readOnly}
+	store.debugf("open store", "dir", paths.Dir, "cache", cachePath, "read_only", readOnly)
+	if readOnly {
+		store.logf("%s is not writable; using an in-memory cache", paths.Cache)
+	}
+
+	opened, err := store.open(start)
+	var cacheErr *cacheError
+	if errors.As(err, &cacheErr) && cachePath == paths.Cache {
+		return repairCache(paths, err)
+	}
+	return opened, err
+}
+
+// repairCache deletes a cache file that failed with err and builds a new one
+// from the JSONL files. The cache holds nothing that isn't in them, so this
+// loses nothing, but it is slow for large projects, so it warns.
+func repairCache(paths config.Paths, cause error) (*Store, error) {
+	fmt.Fprintf(warningOutput, "Warning: the cache is unreadable (%v); rebuilding it from tickets.jsonl\n", cause)
+	if err := RemoveCache(paths); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
 	db, err := OpenDB(paths.Cache)
 	if err != nil {
 		return nil, err
 	}
+	store := &Store{db: db, paths: paths, verbose: verboseOutput, debug: newDebugLogger(), repaired: true}
+	store.debugf("repair cache", "dir", paths.Dir, "cache", paths.Cache, "cause", cause)
+	return store.open(start)
+}
 
-	store := &Store{db: db, paths: paths}
+// RemoveCache deletes the cache file, so that the next store opened builds a
+// new one from the JSONL files.
+func RemoveCache(paths config.Paths) error {
+	if err := os.Remove(paths.Cache); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing cache: %w", err)
+	}
+	return nil
+}
+
+// OpenBackend creates a new Store that caches tickets in backend instead of
+// the SQLite cache file, syncing it from JSONL if needed. The store takes
+// ownership of backend and closes it with the store, or if opening fails.
+func OpenBackend(paths config.Paths, backend Backend) (*Store, error) {
+	start := time.Now()
+	store := &Store{db: backend, paths: paths, verbose: verboseOutput, debug: newDebugLogger()}
+	store.debugf("open store", "dir", paths.Dir, "backend", fmt.Sprintf("%T", backend))
+	return store.open(start)
+}
 
-	if err := store.SyncFromJSONL(); err != nil {
-		db.Close()
+// open finishes opening a store by bringing its cache up to date.
+func (s *Store) open(start time.Time) (*Store, error) {
+	if err := s.SyncFromJSONL(); err != nil {
+		s.db.Close()
 		return nil, err
 	}
 
-	return store, nil
+	s.logf("opened store in %s", time.Since(start))
+	return s, nil
+}
+
+// isWritable reports whether the cache file at path can be written, or
+// created if it doesn't exist yet.
+func isWritable(path string) bool {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err == nil {
+		f.Close()
+		return true
+	}
+	if !os.IsNotExist(err) {
+		return false
+	}
+
+	probe, err := os.CreateTemp(filepath.Dir(path), ".thicket-probe-*")
+	if err != nil {
+		return false
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return true
+}
+
+// Repaired reports whether Open found the cache unreadable and rebuilt it
+// from scratch.
+func (s *Store) Repaired() bool {
+	return s.repaired
+}
+
+// ReadOnly reports whether the store fell back to an in-memory cache because
+// its cache could not be written. Changes to a read-only store fail.
+func (s *Store) ReadOnly() bool {
+	return s.readOnly
+}
+
+// logf writes a diagnostic message if verbose output is enabled.
+func (s *Store) logf(format string, args ...any) {
+	if s.verbose == nil {
+		return
+	}
+	fmt.Fprintf(s.verbose, "thicket: "+format+"\n", args...)
+}
+
+// debugf writes a debug record if debug logging is enabled. It does nothing,
+// and costs only a nil check, otherwise.
+func (s *Store) debugf(msg string, args ...any) {
+	if s.debug == nil {
+		return
+	}
+	s.debug.Debug(msg, args...)
+}
+
+// cacheError is an error from the cache rather than from the JSONL files.
+// Its message is the underlying error's.
+type cacheError struct {
+	err error
+}
+
+func (e *cacheError) Error() string { return e.err.Error() }
+func (e *cacheError) Unwrap() error { return e.err }
+
+// sqlError logs a failed cache operation and marks err as a cache error.
+func (s *Store) sqlError(op string, err error) error {
+	s.debugf("SQL error", "op", op, "error", err)
+	return &cacheError{err: err}
 }
 
 // Close closes the underlying database.
@@ -40,15 +235,27 @@ func (s *Store) Close() error {
 
 // SyncFromJSONL checks if the JSONL file has been modified and rebuilds the cache if needed.
 // This is useful when external processes modify the tickets file.
+//
+// The file counts as modified if either its modification time or its
+// checksum differs from when the cache was last synced. The checksum catches
+// edits that land within the filesystem's mod time resolution.
 func (s *Store) SyncFromJSONL() error {
-	currentModTime, err := GetJSONLModTime(s.paths.Tickets)
+	currentModTime, err := GetJSONLModTime(s.paths)
 	if err != nil {
 		return fmt.Errorf("getting JSONL mod time: %w", err)
 	}
+	currentChecksum, err := GetJSONLChecksum(s.paths)
+	if err != nil {
+		return fmt.Errorf("getting JSONL checksum: %w", err)
+	}
 
 	storedModTimeStr, err := s.db.GetMetadata(metaKeyJSONLModTime)
 	if err != nil {
-		return fmt.Errorf("getting stored mod time: %w", err)
+		return fmt.Errorf("getting stored mod time: %w", s.sqlError("get metadata", err))
+	}
+	storedChecksum, err := s.db.GetMetadata(metaKeyJSONLChecksum)
+	if err != nil {
+		return fmt.Errorf("getting stored checksum: %w", s.sqlError("get metadata", err))
 	}
 
 	var storedModTime int64
@@ -56,41 +263,90 @@ func (s *Store) SyncFromJSONL() error {
 		storedModTime, _ = strconv.ParseInt(storedModTimeStr, 10, 64)
 	}
 
-	if currentModTime != storedModTime {
-		tickets, comments, dependencies, err := ReadAllJSONL(s.paths.Tickets)
-		if err != nil {
-			return fmt.Errorf("reading JSONL: %w", err)
-		}
+	if currentModTime == storedModTime && currentChecksum == storedChecksum {
+		s.logf("cache is up to date")
+		return nil
+	}
+	s.rebuilt = true
 
-		if err := s.db.RebuildFromAll(tickets, comments, dependencies); err != nil {
-			return fmt.Errorf("rebuilding cache: %w", err)
-		}
+	s.logf("JSONL data in %s changed; rebuilding cache", s.paths.Dir)
+	start := time.Now()
 
-		if err := s.db.SetMetadata(metaKeyJSONLModTime, strconv.FormatInt(currentModTime, 10)); err != nil {
-			return fmt.Errorf("storing mod time: %w", err)
-		}
+	tickets, comments, dependencies, err := ReadAllJSONL(s.paths)
+	if err != nil {
+		return fmt.Errorf("reading JSONL: %w", err)
+	}
+	s.logf("loaded %d tickets, %d comments, %d dependencies in %s", len(tickets), len(comments), len(dependencies), time.Since(start))
+
+	if err := s.db.RebuildFromAll(tickets, comments, dependencies); err != nil {
+		return fmt.Errorf("rebuilding cache: %w", s.sqlError("rebuild cache", err))
 	}
 
+	if err := s.db.SetMetadata(metaKeyJSONLModTime, strconv.FormatInt(currentModTime, 10)); err != nil {
+		return fmt.Errorf("storing mod time: %w", s.sqlError("set metadata", err))
+	}
+	if err := s.db.SetMetadata(metaKeyJSONLChecksum, currentChecksum); err != nil {
+		return fmt.Errorf("storing checksum: %w", s.sqlError("set metadata", err))
+	}
+
+	s.logf("rebuilt cache in %s", time.Since(start))
+	s.debugf("rebuild cache", "tickets", len(tickets), "comments", len(comments), "dependencies", len(dependencies), "duration", time.Since(start))
 	return nil
 }
 
-// updateJSONLModTime updates the stored modification time after a write.
+// Rebuilt reports whether the cache has been rebuilt from the JSONL file
+// since the store was opened, including by Open itself.
+func (s *Store) Rebuilt() bool {
+	return s.rebuilt
+}
+
+// ForceRebuild discards the cache and rebuilds it from the JSONL file,
+// regardless of whether the JSONL file appears to have changed.
+func (s *Store) ForceRebuild() error {
+	s.logf("forcing cache rebuild")
+	if err := s.db.Reset(); err != nil {
+		return fmt.Errorf("resetting cache: %w", s.sqlError("reset cache", err))
+	}
+	return s.SyncFromJSONL()
+}
+
+// CacheCounts returns the number of records in the cache.
+func (s *Store) CacheCounts() (RecordCounts, error) {
+	return s.db.Counts()
+}
+
+// updateJSONLModTime updates the stored modification time and checksum after a write.
 func (s *Store) updateJSONLModTime() error {
-	modTime, err := GetJSONLModTime(s.paths.Tickets)
+	modTime, err := GetJSONLModTime(s.paths)
+	if err != nil {
+		return err
+	}
+	checksum, err := GetJSONLChecksum(s.paths)
 	if err != nil {
 		return err
 	}
-	return s.db.SetMetadata(metaKeyJSONLModTime, strconv.FormatInt(modTime, 10))
+	if err := s.db.SetMetadata(metaKeyJSONLModTime, strconv.FormatInt(modTime, 10)); err != nil {
+		return s.sqlError("set metadata", err)
+	}
+	if err := s.db.SetMetadata(metaKeyJSONLChecksum, checksum); err != nil {
+		return s.sqlError("set metadata", err)
+	}
+	return nil
 }
 
 // Add creates a new ticket and persists it to both JSONL and SQLite.
 func (s *Store) Add(t *ticket.Ticket) error {
-	if err := AppendJSONL(s.paths.Tickets, t); err != nil {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	if err := AppendJSONL(s.paths, t); err != nil {
 		return err
 	}
+	s.debugf("append JSONL", "dir", s.paths.Dir, "ticket", t.ID)
 
 	if err := s.db.InsertTicket(t); err != nil {
-		return err
+		return s.sqlError("insert ticket", err)
 	}
 
 	return s.updateJSONLModTime()
@@ -98,69 +354,246 @@ func (s *Store) Add(t *ticket.Ticket) error {
 
 // Update modifies an existing ticket in both JSONL and SQLite.
 func (s *Store) Update(t *ticket.Ticket) error {
-	// Read everything, update the matching ticket, and rewrite
-	tickets, comments, dependencies, err := ReadAllJSONL(s.paths.Tickets)
+	return s.UpdateTickets([]*ticket.Ticket{t})
+}
+
+// UpdateTickets modifies several existing tickets in both JSONL and SQLite,
+// rewriting the JSONL file once.
+func (s *Store) UpdateTickets(updated []*ticket.Ticket) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	// Read everything, update the matching tickets, and rewrite
+	tickets, comments, dependencies, err := ReadAllJSONL(s.paths)
 	if err != nil {
 		return err
 	}
 
-	found := false
+	index := make(map[string]int, len(tickets))
 	for i, existing := range tickets {
-		if existing.ID == t.ID {
-			tickets[i] = t
-			found = true
-			break
-		}
+		index[existing.ID] = i
 	}
-
-	if !found {
-		return fmt.Errorf("ticket %s not found", t.ID)
+	for _, t := range updated {
+		i, ok := index[t.ID]
+		if !ok {
+			return fmt.Errorf("ticket %s not found", t.ID)
+		}
+		tickets[i] = t
 	}
 
-	if err := WriteAllJSONL(s.paths.Tickets, tickets, comments, dependencies); err != nil {
+	if err := WriteAllJSONL(s.paths, tickets, comments, dependencies); err != nil {
 		return err
 	}
+	s.debugf("rewrite JSONL", "dir", s.paths.Dir, "tickets", len(tickets), "updated", len(updated))
 
-	if err := s.db.UpdateTicket(t); err != nil {
-		return err
+	for _, t := range updated {
+		if err := s.db.UpdateTicket(t); err != nil {
+			return s.sqlError("update ticket", err)
+		}
 	}
 
 	return s.updateJSONLModTime()
 }
 
+// RenameLabel replaces label old with new on every ticket, including closed
+// and deleted ones, and returns the IDs of the tickets that changed. A
+// ticket that already has new keeps a single copy of it. Ticket update
+// times are left alone.
+func (s *Store) RenameLabel(old, new string) ([]string, error) {
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	tickets, comments, dependencies, err := ReadAllJSONL(s.paths)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, t := range tickets {
+		i := slices.Index(t.Labels, old)
+		if i < 0 {
+			continue
+		}
+		if slices.Contains(t.Labels, new) {
+			t.Labels = slices.Delete(t.Labels, i, i+1)
+		} else {
+			t.Labels[i] = new
+		}
+		changed = append(changed, t.ID)
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	if err := WriteAllJSONL(s.paths, tickets, comments, dependencies); err != nil {
+		return nil, err
+	}
+	s.debugf("rewrite JSONL", "dir", s.paths.Dir, "tickets", len(tickets), "updated", len(changed))
+
+	if err := s.db.RenameLabel(old, new); err != nil {
+		return nil, s.sqlError("rename label", err)
+	}
+
+	return changed, s.updateJSONLModTime()
+}
+
 // Get retrieves a ticket by ID.
 func (s *Store) Get(id string) (*ticket.Ticket, error) {
 	return s.db.GetTicket(id)
 }
 
 // List retrieves tickets with optional status filter.
+// Without a status filter, deleted tickets are excluded.
 func (s *Store) List(status *ticket.Status) ([]*ticket.Ticket, error) {
 	return s.db.ListTickets(status)
 }
 
+// ListAll retrieves every ticket, including deleted ones.
+func (s *Store) ListAll() ([]*ticket.Ticket, error) {
+	return s.db.GetAllTickets()
+}
+
+// ListByLabels retrieves tickets with the optional status that carry all of
+// labels if matchAll is set, or any of them otherwise.
+func (s *Store) ListByLabels(labels []string, matchAll bool, status *ticket.Status) ([]*ticket.Ticket, error) {
+	return s.db.ListTicketsByLabels(labels, matchAll, status)
+}
+
+// ListWithLabels retrieves tickets with the optional status that carry
+// labels, matching all or any of them, and none of the exclude labels.
+func (s *Store) ListWithLabels(status *ticket.Status, labels []string, matchAll bool, exclude []string) ([]*ticket.Ticket, error) {
+	return s.db.ListTicketsWithLabels(status, labels, matchAll, exclude)
+}
+
 // ListByLabel retrieves tickets with the specified label.
 func (s *Store) ListByLabel(label string, status *ticket.Status) ([]*ticket.Ticket, error) {
 	return s.db.ListTicketsByLabel(label, status)
 }
 
+// ListByTypes retrieves tickets with the optional status and any of types.
+func (s *Store) ListByTypes(types []ticket.Type, status *ticket.Status) ([]*ticket.Ticket, error) {
+	return s.db.ListByTypes(types, status)
+}
+
+// ListUnassigned retrieves tickets with the optional status that have no
+// assignee.
+func (s *Store) ListUnassigned(status *ticket.Status) ([]*ticket.Ticket, error) {
+	return s.db.ListUnassigned(status)
+}
+
+// ListByWatcher retrieves tickets with the optional status that user watches.
+func (s *Store) ListByWatcher(user string, status *ticket.Status) ([]*ticket.Ticket, error) {
+	return s.db.ListByWatcher(user, status)
+}
+
 // ListReady retrieves open tickets that are not blocked by other open tickets.
 func (s *Store) ListReady() ([]*ticket.Ticket, error) {
 	return s.db.ListReadyTickets()
 }
 
+// BlockedTicketIDs returns the IDs of the open tickets that are blocked by
+// other open tickets.
+func (s *Store) BlockedTicketIDs() (map[string]bool, error) {
+	return s.db.BlockedTicketIDs()
+}
+
+// ListBlocked retrieves open tickets that are blocked by other open tickets.
+func (s *Store) ListBlocked() ([]*ticket.Ticket, error) {
+	return s.db.ListBlockedTickets()
+}
+
+// ListStale retrieves open tickets last updated before olderThan, oldest first.
+func (s *Store) ListStale(olderThan time.Time) ([]*ticket.Ticket, error) {
+	return s.db.ListStaleTickets(olderThan)
+}
+
+// ListUpdatedSince retrieves tickets with the optional status updated at or
+// after since, least recently updated first.
+func (s *Store) ListUpdatedSince(since time.Time, status *ticket.Status) ([]*ticket.Ticket, error) {
+	return s.db.ListUpdatedSince(since, status)
+}
+
+// StatsByStatus counts the tickets with each status and sums their estimates.
+func (s *Store) StatsByStatus() ([]StatusStats, error) {
+	return s.db.StatsByStatus()
+}
+
+// StatsByAssignee counts the open and closed tickets of each assignee.
+func (s *Store) StatsByAssignee() ([]AssigneeStats, error) {
+	return s.db.StatsByAssignee()
+}
+
+// FindByTitle retrieves open tickets with the same title, ignoring case and surrounding whitespace.
+func (s *Store) FindByTitle(title string) ([]*ticket.Ticket, error) {
+	return s.db.FindByTitle(title)
+}
+
+// FindByIdempotencyKey retrieves the ticket created with the given
+// idempotency key, or nil if there is none.
+func (s *Store) FindByIdempotencyKey(key string) (*ticket.Ticket, error) {
+	return s.db.FindByIdempotencyKey(key)
+}
+
 // AddComment creates a new comment and persists it to both JSONL and SQLite.
+// Comments longer than ticket.MaxCommentLength are rejected.
 func (s *Store) AddComment(c *ticket.Comment) error {
-	if err := AppendComment(s.paths.Tickets, c); err != nil {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if err := ticket.ValidateCommentLength(c.Content); err != nil {
+		return err
+	}
+
+	if err := AppendComment(s.paths, c); err != nil {
 		return err
 	}
+	s.debugf("append JSONL", "dir", s.paths.Dir, "comment", c.ID)
 
 	if err := s.db.InsertComment(c); err != nil {
+		return s.sqlError("insert comment", err)
+	}
+
+	return s.updateJSONLModTime()
+}
+
+// UpdateComment modifies an existing comment in both JSONL and SQLite.
+func (s *Store) UpdateComment(c *ticket.Comment) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if err := ticket.ValidateCommentLength(c.Content); err != nil {
 		return err
 	}
 
+	tickets, comments, dependencies, err := ReadAllJSONL(s.paths)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(comments, func(existing *ticket.Comment) bool { return existing.ID == c.ID })
+	if i < 0 {
+		return fmt.Errorf("comment %s not found", c.ID)
+	}
+	comments[i] = c
+
+	if err := WriteAllJSONL(s.paths, tickets, comments, dependencies); err != nil {
+		return err
+	}
+	s.debugf("rewrite JSONL", "dir", s.paths.Dir, "comment", c.ID)
+
+	if err := s.db.UpdateComment(c); err != nil {
+		return s.sqlError("update comment", err)
+	}
+
 	return s.updateJSONLModTime()
 }
 
+// GetComment retrieves a comment by ID, or returns nil if there is none.
+func (s *Store) GetComment(id string) (*ticket.Comment, error) {
+	return s.db.GetComment(id)
+}
+
 // GetComments retrieves all comments for a ticket.
 func (s *Store) GetComments(ticketID string) ([]*ticket.Comment, error) {
 	return s.db.GetCommentsForTicket(ticketID)
@@ -174,6 +607,10 @@ func (s *Store) ListAllComments() ([]*ticket.Comment, error) {
 // AddDependency creates a new dependency and persists it to both JSONL and SQLite.
 // For blocked_by dependencies, it validates that no circular dependency would be created.
 func (s *Store) AddDependency(d *ticket.Dependency) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
 	// Check if dependency already exists
 	exists, err := s.db.DependencyExists(d.FromTicketID, d.ToTicketID, d.Type)
 	if err != nil {
@@ -190,12 +627,13 @@ func (s *Store) AddDependency(d *ticket.Dependency) error {
 		}
 	}
 
-	if err := AppendDependency(s.paths.Tickets, d); err != nil {
+	if err := AppendDependency(s.paths, d); err != nil {
 		return err
 	}
+	s.debugf("append JSONL", "dir", s.paths.Dir, "dependency", d.ID)
 
 	if err := s.db.InsertDependency(d); err != nil {
-		return err
+		return s.sqlError("insert dependency", err)
 	}
 
 	return s.updateJSONLModTime()
@@ -217,9 +655,17 @@ func (s *Store) checkCircularDependency(fromID, toID string) error {
 		blockedBy[d.FromTicketID] = append(blockedBy[d.FromTicketID], d.ToTicketID)
 	}
 
-	// Check if adding fromID -> toID creates a cycle
-	// This would happen if toID transitively blocks fromID
-	// (i.e., if we can reach fromID starting from toID through the blocked_by graph)
+	if wouldCycle(blockedBy, fromID, toID) {
+		return ticket.ErrCircularDependency
+	}
+
+	return nil
+}
+
+// wouldCycle reports whether making fromID blocked by toID would create a
+// cycle in blockedBy, where blockedBy[A] lists the tickets that block A. That
+// happens if toID is already blocked, directly or transitively, by fromID.
+func wouldCycle(blockedBy map[string][]string, fromID, toID string) bool {
 	visited := make(map[string]bool)
 	var canReach func(current, target string) bool
 	canReach = func(current, target string) bool {
@@ -238,12 +684,112 @@ func (s *Store) checkCircularDependency(fromID, toID string) error {
 		}
 		return false
 	}
+	return canReach(toID, fromID)
+}
 
-	if canReach(toID, fromID) {
-		return ticket.ErrCircularDependency
+// MergeResult reports what Merge moved from one ticket to another.
+type MergeResult struct {
+	Comments     int `json:"comments"`
+	Dependencies int `json:"dependencies"`
+	Dropped      int `json:"dropped"`
+}
+
+// Merge moves the comments and dependencies of src onto dstID, saves src
+// (which the caller has already closed or deleted), and adds link, which
+// normally relates src to dstID. Dependencies that would point a ticket at
+// itself, duplicate an existing dependency, or create a blocking cycle are
+// dropped rather than moved.
+func (s *Store) Merge(src *ticket.Ticket, dstID string, link *ticket.Dependency) (MergeResult, error) {
+	var result MergeResult
+	if s.readOnly {
+		return result, ErrReadOnly
 	}
 
-	return nil
+	tickets, comments, dependencies, err := ReadAllJSONL(s.paths)
+	if err != nil {
+		return result, err
+	}
+
+	found := false
+	for i, t := range tickets {
+		if t.ID == src.ID {
+			tickets[i] = src
+			found = true
+		}
+	}
+	if !found {
+		return result, fmt.Errorf("ticket %s not found", src.ID)
+	}
+
+	for _, c := range comments {
+		if c.TicketID == src.ID {
+			c.TicketID = dstID
+			result.Comments++
+		}
+	}
+
+	// Keep the dependencies that don't involve src first, so the ones being
+	// moved are checked against the complete existing graph.
+	type depKey struct {
+		from, to string
+		typ      ticket.DependencyType
+	}
+	seen := make(map[depKey]bool)
+	blockedBy := make(map[string][]string)
+	kept := make([]*ticket.Dependency, 0, len(dependencies)+1)
+	var moving []*ticket.Dependency
+	for _, d := range dependencies {
+		if d.FromTicketID == src.ID || d.ToTicketID == src.ID {
+			moving = append(moving, d)
+			continue
+		}
+		seen[depKey{d.FromTicketID, d.ToTicketID, d.Type}] = true
+		if d.Type == ticket.DependencyBlockedBy {
+			blockedBy[d.FromTicketID] = append(blockedBy[d.FromTicketID], d.ToTicketID)
+		}
+		kept = append(kept, d)
+	}
+
+	for _, d := range moving {
+		if d.FromTicketID == src.ID {
+			d.FromTicketID = dstID
+		}
+		if d.ToTicketID == src.ID {
+			d.ToTicketID = dstID
+		}
+		key := depKey{d.FromTicketID, d.ToTicketID, d.Type}
+		if d.FromTicketID == d.ToTicketID || seen[key] {
+			result.Dropped++
+			continue
+		}
+		if d.Type == ticket.DependencyBlockedBy {
+			if wouldCycle(blockedBy, d.FromTicketID, d.ToTicketID) {
+				result.Dropped++
+				continue
+			}
+			blockedBy[d.FromTicketID] = append(blockedBy[d.FromTicketID], d.ToTicketID)
+		}
+		seen[key] = true
+		kept = append(kept, d)
+		result.Dependencies++
+	}
+
+	if link != nil && !seen[depKey{link.FromTicketID, link.ToTicketID, link.Type}] {
+		kept = append(kept, link)
+	}
+
+	if err := WriteAllJSONL(s.paths, tickets, comments, kept); err != nil {
+		return result, err
+	}
+	s.debugf("rewrite JSONL", "dir", s.paths.Dir, "tickets", len(tickets), "updated", 1)
+
+	// Many rows change, so rebuild the cache from the new file rather than
+	// patching it row by row.
+	if err := s.db.RebuildFromAll(tickets, comments, kept); err != nil {
+		return result, fmt.Errorf("rebuilding cache: %w", s.sqlError("rebuild cache", err))
+	}
+
+	return result, s.updateJSONLModTime()
 }
 
 // GetDependenciesFrom retrieves all dependencies from a specific ticket.
@@ -315,6 +861,28 @@ func (s *Store) GetCreatedFrom(ticketID string) (*ticket.Ticket, error) {
 	return nil, nil
 }
 
+// GetCreatedChildren retrieves the tickets that were created from this ticket.
+func (s *Store) GetCreatedChildren(ticketID string) ([]*ticket.Ticket, error) {
+	deps, err := s.db.GetDependenciesTo(ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []*ticket.Ticket
+	for _, d := range deps {
+		if d.Type == ticket.DependencyCreatedFrom {
+			t, err := s.db.GetTicket(d.FromTicketID)
+			if err != nil {
+				return nil, err
+			}
+			if t != nil {
+				children = append(children, t)
+			}
+		}
+	}
+	return children, nil
+}
+
 // IsBlocked checks if a ticket has any open blocking dependencies.
 func (s *Store) IsBlocked(ticketID string) (bool, error) {
 	blockers, err := s.GetBlockers(ticketID)
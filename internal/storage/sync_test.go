@@ -1,8 +1,13 @@
 package storage
 
 import (
+	"bytes"
+	"database/sql"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -41,6 +46,97 @@ func TestStore_Open(t *testing.T) {
 	defer store.Close()
 }
 
+func TestStore_ReadOnlyCache(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk, _ := ticket.New("TH", "Readable", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	store.Close()
+
+	// Put the cache somewhere it can never be created, even by root: under
+	// a regular file rather than a directory.
+	blocker := filepath.Join(t.TempDir(), "not-a-directory")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	paths.Cache = filepath.Join(blocker, "cache.db")
+
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() with unwritable cache error = %v", err)
+	}
+	defer store.Close()
+
+	if !store.ReadOnly() {
+		t.Error("ReadOnly() = false, want true for an unwritable cache")
+	}
+	tickets, err := store.List(nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].ID != tk.ID {
+		t.Errorf("List() = %v, want the ticket from tickets.jsonl", tickets)
+	}
+
+	other, _ := ticket.New("TH", "Not saved", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := store.Add(other); err != ErrReadOnly {
+		t.Errorf("Add() error = %v, want ErrReadOnly", err)
+	}
+	tk.Title = "Renamed"
+	if err := store.Update(tk); err != ErrReadOnly {
+		t.Errorf("Update() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestStore_MemoryCache(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	SetMemoryCache(true)
+	defer SetMemoryCache(false)
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk, _ := ticket.New("TH", "In memory", "", ticket.TypeTask, 1, []string{"ci"}, "", 0)
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	got, err := store.ListByLabel("ci", nil)
+	if err != nil {
+		t.Fatalf("ListByLabel() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != tk.ID {
+		t.Errorf("ListByLabel() = %v, want the added ticket", got)
+	}
+	store.Close()
+
+	// A new store rebuilds its cache from the JSONL file.
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	if got, err := store.Get(tk.ID); err != nil || got == nil {
+		t.Errorf("Get() after reopening = %v, %v, want the ticket", got, err)
+	}
+	if store.ReadOnly() {
+		t.Error("ReadOnly() = true, want false for a memory cache")
+	}
+
+	if _, err := os.Stat(paths.Cache); !os.IsNotExist(err) {
+		t.Errorf("Stat(%s) error = %v, want the cache file not to exist", paths.Cache, err)
+	}
+}
+
 func TestStore_AddAndGet(t *testing.T) {
 	paths, cleanup := setupTestProject(t)
 	defer cleanup()
@@ -51,7 +147,7 @@ func TestStore_AddAndGet(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, nil, "")
+	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, nil, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -86,7 +182,7 @@ func TestStore_Update(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk, err := ticket.New("TH", "Original title", "Description", ticket.TypeTask, 1, nil, "")
+	tk, err := ticket.New("TH", "Original title", "Description", ticket.TypeTask, 1, nil, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -96,7 +192,7 @@ func TestStore_Update(t *testing.T) {
 	}
 
 	newTitle := "Updated title"
-	if err := tk.Update(&newTitle, nil, nil, nil, nil, nil, nil, nil); err != nil {
+	if err := tk.Update(&newTitle, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("ticket.Update() error = %v", err)
 	}
 
@@ -126,7 +222,7 @@ func TestStore_List(t *testing.T) {
 
 	// Add some tickets
 	for i := 0; i < 3; i++ {
-		tk, err := ticket.New("TH", "Ticket", "", ticket.TypeTask, i, nil, "")
+		tk, err := ticket.New("TH", "Ticket", "", ticket.TypeTask, i, nil, "", 0)
 		if err != nil {
 			t.Fatalf("ticket.New() error = %v", err)
 		}
@@ -194,6 +290,231 @@ func TestStore_SyncFromJSONL(t *testing.T) {
 	}
 }
 
+func TestStore_ForceRebuild(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	tk, err := ticket.New("TH", "Rebuild me", "", ticket.TypeBug, 1, []string{"cache"}, "", 0)
+	if err != nil {
+		t.Fatalf("ticket.New() error = %v", err)
+	}
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	c, err := ticket.NewComment(tk.ID, "A comment")
+	if err != nil {
+		t.Fatalf("NewComment() error = %v", err)
+	}
+	if err := store.AddComment(c); err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+
+	// Empty the cache without touching the stored mod time, so an ordinary
+	// sync believes the cache is current.
+	for _, table := range []string{"tickets", "ticket_labels", "comments"} {
+		if _, err := store.db.(*DB).conn.Exec("DELETE FROM " + table); err != nil {
+			t.Fatalf("clearing %s: %v", table, err)
+		}
+	}
+	if err := store.SyncFromJSONL(); err != nil {
+		t.Fatalf("SyncFromJSONL() error = %v", err)
+	}
+	if got, _ := store.Get(tk.ID); got != nil {
+		t.Fatalf("Get() = %+v after clearing cache, want nil", got)
+	}
+
+	if err := store.ForceRebuild(); err != nil {
+		t.Fatalf("ForceRebuild() error = %v", err)
+	}
+
+	got, err := store.Get(tk.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil {
+		t.Fatal("Get() returned nil after ForceRebuild()")
+	}
+	if got.Title != "Rebuild me" || got.Type != ticket.TypeBug {
+		t.Errorf("Get() = %+v, want title %q and type %q", got, "Rebuild me", ticket.TypeBug)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "cache" {
+		t.Errorf("Labels = %v, want [cache]", got.Labels)
+	}
+
+	counts, err := store.CacheCounts()
+	if err != nil {
+		t.Fatalf("CacheCounts() error = %v", err)
+	}
+	if counts != (RecordCounts{Tickets: 1, Comments: 1}) {
+		t.Errorf("CacheCounts() = %+v, want 1 ticket and 1 comment", counts)
+	}
+}
+
+func TestStore_Rebuilt(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk, _ := ticket.New("TH", "Initial", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	store.Close()
+
+	// Writes through the store keep the cache current.
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	if store.Rebuilt() {
+		t.Error("Rebuilt() = true for an up-to-date cache")
+	}
+
+	if err := store.ForceRebuild(); err != nil {
+		t.Fatalf("ForceRebuild() error = %v", err)
+	}
+	if !store.Rebuilt() {
+		t.Error("Rebuilt() = false after ForceRebuild()")
+	}
+}
+
+func TestStore_Verbose(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	var buf bytes.Buffer
+	SetVerbose(&buf)
+	defer SetVerbose(nil)
+
+	now := time.Now().UTC()
+	tickets := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "First", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+	}
+	if err := WriteJSONL(paths.Tickets, tickets); err != nil {
+		t.Fatalf("WriteJSONL() error = %v", err)
+	}
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.Close()
+
+	output := buf.String()
+	if !strings.Contains(output, "rebuilding cache") {
+		t.Errorf("Verbose output should report the cache rebuild, got: %q", output)
+	}
+	if !strings.Contains(output, "loaded 1 tickets, 0 comments, 0 dependencies") {
+		t.Errorf("Verbose output should report record counts, got: %q", output)
+	}
+
+	// Reopening without changes should not rebuild.
+	buf.Reset()
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.Close()
+
+	output = buf.String()
+	if strings.Contains(output, "rebuilding cache") {
+		t.Errorf("Unchanged JSONL should not trigger a rebuild, got: %q", output)
+	}
+	if !strings.Contains(output, "cache is up to date") {
+		t.Errorf("Verbose output should report an up-to-date cache, got: %q", output)
+	}
+}
+
+func TestStore_NotVerboseByDefault(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	if store.verbose != nil {
+		t.Error("Store should not log diagnostics unless SetVerbose is called")
+	}
+}
+
+func TestStore_Debug(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	var buf bytes.Buffer
+	debugWriter = &buf
+	defer func() { debugWriter = os.Stderr }()
+
+	now := time.Now().UTC()
+	tk := &ticket.Ticket{ID: "TH-111111", Title: "First", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now}
+
+	t.Setenv(DebugEnv, "1")
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	store.Close()
+
+	output := buf.String()
+	for _, want := range []string{
+		"level=DEBUG msg=\"open store\"",
+		"msg=\"rebuild cache\" tickets=0",
+		"msg=\"append JSONL\"",
+		"ticket=TH-111111",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Debug output missing %q, got:\n%s", want, output)
+		}
+	}
+
+	// A closed cache makes the insert fail after the JSONL write.
+	buf.Reset()
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.db.Close()
+	tk2 := &ticket.Ticket{ID: "TH-222222", Title: "Second", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now}
+	if err := store.Add(tk2); err == nil {
+		t.Fatal("Add() with a closed cache expected error")
+	}
+	if output := buf.String(); !strings.Contains(output, "msg=\"SQL error\" op=\"insert ticket\"") {
+		t.Errorf("Debug output should report the SQL error, got:\n%s", output)
+	}
+
+	buf.Reset()
+	t.Setenv(DebugEnv, "")
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk3 := &ticket.Ticket{ID: "TH-333333", Title: "Third", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now}
+	if err := store.Add(tk3); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	store.Close()
+
+	if buf.Len() != 0 {
+		t.Errorf("Debug output without %s = %q, want none", DebugEnv, buf.String())
+	}
+}
+
 func TestStore_SyncOnReopen(t *testing.T) {
 	paths, cleanup := setupTestProject(t)
 	defer cleanup()
@@ -204,7 +525,7 @@ func TestStore_SyncOnReopen(t *testing.T) {
 		t.Fatalf("Open() error = %v", err)
 	}
 
-	tk, _ := ticket.New("TH", "Initial", "", ticket.TypeTask, 1, nil, "")
+	tk, _ := ticket.New("TH", "Initial", "", ticket.TypeTask, 1, nil, "", 0)
 	if err := store.Add(tk); err != nil {
 		t.Fatalf("Add() error = %v", err)
 	}
@@ -239,6 +560,59 @@ func TestStore_SyncOnReopen(t *testing.T) {
 	}
 }
 
+func TestStore_SyncDetectsChangeWithSameModTime(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	original := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "Before", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+	}
+	if err := WriteJSONL(paths.Tickets, original); err != nil {
+		t.Fatalf("WriteJSONL() error = %v", err)
+	}
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	store.Close()
+
+	info, err := os.Stat(paths.Tickets)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+
+	// Rewrite the file and restore its mod time, as happens when two edits
+	// land within the filesystem's timestamp resolution.
+	edited := []*ticket.Ticket{
+		{ID: "TH-111111", Title: "After", Status: ticket.StatusOpen, Priority: 1, Created: now, Updated: now},
+	}
+	if err := WriteJSONL(paths.Tickets, edited); err != nil {
+		t.Fatalf("WriteJSONL() error = %v", err)
+	}
+	if err := os.Chtimes(paths.Tickets, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	if !store.Rebuilt() {
+		t.Error("Rebuilt() = false, want a rebuild for changed content")
+	}
+	got, err := store.Get("TH-111111")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.Title != "After" {
+		t.Errorf("Get() = %+v, want title After", got)
+	}
+}
+
 func TestStore_AddAndGetComments(t *testing.T) {
 	paths, cleanup := setupTestProject(t)
 	defer cleanup()
@@ -249,7 +623,7 @@ func TestStore_AddAndGetComments(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, nil, "")
+	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, nil, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -317,7 +691,7 @@ func TestStore_MultipleComments(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk, _ := ticket.New("TH", "Test ticket", "", ticket.TypeTask, 1, nil, "")
+	tk, _ := ticket.New("TH", "Test ticket", "", ticket.TypeTask, 1, nil, "", 0)
 	store.Add(tk)
 
 	// Add multiple comments
@@ -348,7 +722,7 @@ func TestStore_SyncCommentsOnReopen(t *testing.T) {
 		t.Fatalf("Open() error = %v", err)
 	}
 
-	tk, _ := ticket.New("TH", "Test", "", ticket.TypeTask, 1, nil, "")
+	tk, _ := ticket.New("TH", "Test", "", ticket.TypeTask, 1, nil, "", 0)
 	store.Add(tk)
 
 	c, _ := ticket.NewComment(tk.ID, "Original comment")
@@ -387,8 +761,8 @@ func TestStore_AddAndGetDependencies(t *testing.T) {
 	defer store.Close()
 
 	// Create two tickets
-	tk1, _ := ticket.New("TH", "Blocker ticket", "", ticket.TypeTask, 1, nil, "")
-	tk2, _ := ticket.New("TH", "Blocked ticket", "", ticket.TypeTask, 2, nil, "")
+	tk1, _ := ticket.New("TH", "Blocker ticket", "", ticket.TypeTask, 1, nil, "", 0)
+	tk2, _ := ticket.New("TH", "Blocked ticket", "", ticket.TypeTask, 2, nil, "", 0)
 	store.Add(tk1)
 	store.Add(tk2)
 
@@ -438,9 +812,9 @@ func TestStore_CircularDependencyPrevention(t *testing.T) {
 	defer store.Close()
 
 	// Create three tickets for testing transitive cycle
-	tk1, _ := ticket.New("TH", "Ticket 1", "", ticket.TypeTask, 1, nil, "")
-	tk2, _ := ticket.New("TH", "Ticket 2", "", ticket.TypeTask, 2, nil, "")
-	tk3, _ := ticket.New("TH", "Ticket 3", "", ticket.TypeTask, 3, nil, "")
+	tk1, _ := ticket.New("TH", "Ticket 1", "", ticket.TypeTask, 1, nil, "", 0)
+	tk2, _ := ticket.New("TH", "Ticket 2", "", ticket.TypeTask, 2, nil, "", 0)
+	tk3, _ := ticket.New("TH", "Ticket 3", "", ticket.TypeTask, 3, nil, "", 0)
 	store.Add(tk1)
 	store.Add(tk2)
 	store.Add(tk3)
@@ -469,8 +843,8 @@ func TestStore_DuplicateDependencyPrevention(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk1, _ := ticket.New("TH", "Ticket 1", "", ticket.TypeTask, 1, nil, "")
-	tk2, _ := ticket.New("TH", "Ticket 2", "", ticket.TypeTask, 2, nil, "")
+	tk1, _ := ticket.New("TH", "Ticket 1", "", ticket.TypeTask, 1, nil, "", 0)
+	tk2, _ := ticket.New("TH", "Ticket 2", "", ticket.TypeTask, 2, nil, "", 0)
 	store.Add(tk1)
 	store.Add(tk2)
 
@@ -495,8 +869,8 @@ func TestStore_CreatedFromDependency(t *testing.T) {
 	}
 	defer store.Close()
 
-	parent, _ := ticket.New("TH", "Parent ticket", "", ticket.TypeTask, 1, nil, "")
-	child, _ := ticket.New("TH", "Child ticket", "", ticket.TypeTask, 2, nil, "")
+	parent, _ := ticket.New("TH", "Parent ticket", "", ticket.TypeTask, 1, nil, "", 0)
+	child, _ := ticket.New("TH", "Child ticket", "", ticket.TypeTask, 2, nil, "", 0)
 	store.Add(parent)
 	store.Add(child)
 
@@ -515,6 +889,44 @@ func TestStore_CreatedFromDependency(t *testing.T) {
 	}
 }
 
+func TestStore_GetCreatedChildren(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	parent, _ := ticket.New("TH", "Parent ticket", "", ticket.TypeTask, 1, nil, "", 0)
+	child1, _ := ticket.New("TH", "First child", "", ticket.TypeTask, 2, nil, "", 0)
+	child2, _ := ticket.New("TH", "Second child", "", ticket.TypeTask, 2, nil, "", 0)
+	blocked, _ := ticket.New("TH", "Blocked ticket", "", ticket.TypeTask, 2, nil, "", 0)
+	for _, tk := range []*ticket.Ticket{parent, child1, child2, blocked} {
+		store.Add(tk)
+	}
+
+	for _, child := range []*ticket.Ticket{child1, child2} {
+		dep, _ := ticket.NewDependency(child.ID, parent.ID, ticket.DependencyCreatedFrom)
+		store.AddDependency(dep)
+	}
+	dep, _ := ticket.NewDependency(blocked.ID, parent.ID, ticket.DependencyBlockedBy)
+	store.AddDependency(dep)
+
+	children, err := store.GetCreatedChildren(parent.ID)
+	if err != nil {
+		t.Fatalf("GetCreatedChildren() error = %v", err)
+	}
+	if len(children) != 2 {
+		t.Fatalf("GetCreatedChildren() returned %d, want 2", len(children))
+	}
+	got := map[string]bool{children[0].ID: true, children[1].ID: true}
+	if !got[child1.ID] || !got[child2.ID] {
+		t.Errorf("GetCreatedChildren() = %v, want %s and %s", got, child1.ID, child2.ID)
+	}
+}
+
 func TestStore_IsBlocked(t *testing.T) {
 	paths, cleanup := setupTestProject(t)
 	defer cleanup()
@@ -525,8 +937,8 @@ func TestStore_IsBlocked(t *testing.T) {
 	}
 	defer store.Close()
 
-	blocker, _ := ticket.New("TH", "Blocker", "", ticket.TypeTask, 1, nil, "")
-	blocked, _ := ticket.New("TH", "Blocked", "", ticket.TypeTask, 2, nil, "")
+	blocker, _ := ticket.New("TH", "Blocker", "", ticket.TypeTask, 1, nil, "", 0)
+	blocked, _ := ticket.New("TH", "Blocked", "", ticket.TypeTask, 2, nil, "", 0)
 	store.Add(blocker)
 	store.Add(blocked)
 
@@ -566,8 +978,8 @@ func TestStore_SyncDependenciesOnReopen(t *testing.T) {
 		t.Fatalf("Open() error = %v", err)
 	}
 
-	tk1, _ := ticket.New("TH", "Ticket 1", "", ticket.TypeTask, 1, nil, "")
-	tk2, _ := ticket.New("TH", "Ticket 2", "", ticket.TypeTask, 2, nil, "")
+	tk1, _ := ticket.New("TH", "Ticket 1", "", ticket.TypeTask, 1, nil, "", 0)
+	tk2, _ := ticket.New("TH", "Ticket 2", "", ticket.TypeTask, 2, nil, "", 0)
 	store.Add(tk1)
 	store.Add(tk2)
 
@@ -601,7 +1013,7 @@ func TestStore_UpdatePreservesCommentsAndDependencies(t *testing.T) {
 	}
 
 	// 1. Add a ticket
-	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, nil, "")
+	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, nil, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -620,7 +1032,7 @@ func TestStore_UpdatePreservesCommentsAndDependencies(t *testing.T) {
 	}
 
 	// 3. Add a dependency
-	tk2, err := ticket.New("TH", "Blocked ticket", "Description", ticket.TypeTask, 1, nil, "")
+	tk2, err := ticket.New("TH", "Blocked ticket", "Description", ticket.TypeTask, 1, nil, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -639,7 +1051,7 @@ func TestStore_UpdatePreservesCommentsAndDependencies(t *testing.T) {
 
 	// 4. Update the ticket
 	newTitle := "Updated title"
-	if err := tk.Update(&newTitle, nil, nil, nil, nil, nil, nil, nil); err != nil {
+	if err := tk.Update(&newTitle, nil, nil, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("tk.Update() error = %v", err)
 	}
 	if err := store.Update(tk); err != nil {
@@ -703,7 +1115,7 @@ func TestStore_AddWithLabels(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, []string{"bug", "urgent"}, "")
+	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, []string{"bug", "urgent"}, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -735,7 +1147,7 @@ func TestStore_UpdateLabels(t *testing.T) {
 	}
 	defer store.Close()
 
-	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, []string{"initial"}, "")
+	tk, err := ticket.New("TH", "Test ticket", "Description", ticket.TypeTask, 1, []string{"initial"}, "", 0)
 	if err != nil {
 		t.Fatalf("ticket.New() error = %v", err)
 	}
@@ -745,7 +1157,7 @@ func TestStore_UpdateLabels(t *testing.T) {
 	}
 
 	// Add a new label
-	if err := tk.Update(nil, nil, nil, nil, nil, []string{"added"}, nil, nil); err != nil {
+	if err := tk.Update(nil, nil, nil, nil, nil, []string{"added"}, nil, nil, nil); err != nil {
 		t.Fatalf("ticket.Update() error = %v", err)
 	}
 
@@ -774,9 +1186,9 @@ func TestStore_ListByLabel(t *testing.T) {
 	defer store.Close()
 
 	// Create tickets with different labels
-	tk1, _ := ticket.New("TH", "Bug ticket", "", ticket.TypeTask, 1, []string{"bug"}, "")
-	tk2, _ := ticket.New("TH", "Feature ticket", "", ticket.TypeTask, 2, []string{"feature"}, "")
-	tk3, _ := ticket.New("TH", "Bug and feature", "", ticket.TypeTask, 3, []string{"bug", "feature"}, "")
+	tk1, _ := ticket.New("TH", "Bug ticket", "", ticket.TypeTask, 1, []string{"bug"}, "", 0)
+	tk2, _ := ticket.New("TH", "Feature ticket", "", ticket.TypeTask, 2, []string{"feature"}, "", 0)
+	tk3, _ := ticket.New("TH", "Bug and feature", "", ticket.TypeTask, 3, []string{"bug", "feature"}, "", 0)
 	store.Add(tk1)
 	store.Add(tk2)
 	store.Add(tk3)
@@ -819,7 +1231,7 @@ func TestStore_LabelsPreservedOnReopen(t *testing.T) {
 		t.Fatalf("Open() error = %v", err)
 	}
 
-	tk, _ := ticket.New("TH", "Test", "", ticket.TypeTask, 1, []string{"label1", "label2"}, "")
+	tk, _ := ticket.New("TH", "Test", "", ticket.TypeTask, 1, []string{"label1", "label2"}, "", 0)
 	store.Add(tk)
 	store.Close()
 
@@ -844,3 +1256,292 @@ func TestStore_LabelsPreservedOnReopen(t *testing.T) {
 		t.Errorf("Labels not preserved after reopen. Got %d labels, want 2", len(got.Labels))
 	}
 }
+
+func TestStore_Watchers(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	tk, err := ticket.New("TH", "Watched", "", ticket.TypeTask, 1, nil, "", 0)
+	if err != nil {
+		t.Fatalf("ticket.New() error = %v", err)
+	}
+	tk.Watch("alice")
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	tk.Watch("bob")
+	if err := store.Update(tk); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	tk.Unwatch("alice")
+	tk.Watch("carol")
+	if err := store.Update(tk); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	store.Close()
+
+	// Remove the cache so the watchers must come back from the JSONL file.
+	if err := os.Remove(paths.Cache); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() after reopen error = %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get(tk.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if strings.Join(got.Watchers, ",") != "bob,carol" {
+		t.Errorf("Get().Watchers = %v, want [bob carol]", got.Watchers)
+	}
+
+	all, err := store.ListAll()
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	if len(all) != 1 || strings.Join(all[0].Watchers, ",") != "bob,carol" {
+		t.Errorf("ListAll() watchers = %v, want [bob carol]", all[0].Watchers)
+	}
+}
+
+func TestStore_SplitFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := config.InitSplit(dir, "TH"); err != nil {
+		t.Fatalf("InitSplit() error = %v", err)
+	}
+	paths := config.GetPaths(dir)
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	blocker, _ := ticket.New("TH", "Blocker", "", ticket.TypeTask, 1, nil, "", 0)
+	blocked, _ := ticket.New("TH", "Blocked", "", ticket.TypeTask, 2, nil, "", 0)
+	for _, tk := range []*ticket.Ticket{blocker, blocked} {
+		if err := store.Add(tk); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	c, _ := ticket.NewComment(blocker.ID, "Split comment")
+	if err := store.AddComment(c); err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+	d, _ := ticket.NewDependency(blocked.ID, blocker.ID, ticket.DependencyBlockedBy)
+	if err := store.AddDependency(d); err != nil {
+		t.Fatalf("AddDependency() error = %v", err)
+	}
+	store.Close()
+
+	tickets, comments, deps, err := ReadAllJSONL(config.Paths{Tickets: paths.Tickets})
+	if err != nil {
+		t.Fatalf("ReadAllJSONL() error = %v", err)
+	}
+	if len(tickets) != 2 || len(comments) != 0 || len(deps) != 0 {
+		t.Errorf("tickets.jsonl has %d tickets, %d comments, %d dependencies, want 2, 0, 0", len(tickets), len(comments), len(deps))
+	}
+
+	// Rebuild the cache from the split files.
+	if err := os.Remove(paths.Cache); err != nil {
+		t.Fatal(err)
+	}
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	gotComments, err := store.GetComments(blocker.ID)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if len(gotComments) != 1 || gotComments[0].Content != "Split comment" {
+		t.Errorf("GetComments() = %v, want the split comment", gotComments)
+	}
+	counts, err := store.CacheCounts()
+	if err != nil {
+		t.Fatalf("CacheCounts() error = %v", err)
+	}
+	if counts.Tickets != 2 || counts.Comments != 1 || counts.Dependencies != 1 {
+		t.Errorf("CacheCounts() = %+v, want 2 tickets, 1 comment, 1 dependency", counts)
+	}
+}
+
+func TestStore_Gzip(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	// Compress the project's tickets file.
+	gz := paths.Tickets + config.GzipExt
+	if err := os.Rename(paths.Tickets, gz); err != nil {
+		t.Fatal(err)
+	}
+	paths = config.GetPaths(paths.Root)
+	if paths.Tickets != gz {
+		t.Fatalf("GetPaths().Tickets = %s, want %s", paths.Tickets, gz)
+	}
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk, _ := ticket.New("TH", "Compressed", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	store.Close()
+
+	if _, err := os.Stat(filepath.Join(paths.Dir, config.TicketsFile)); !os.IsNotExist(err) {
+		t.Error("an uncompressed tickets.jsonl was created")
+	}
+
+	// Rebuild the cache from the compressed file.
+	if err := os.Remove(paths.Cache); err != nil {
+		t.Fatal(err)
+	}
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+	got, err := store.Get(tk.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got == nil || got.Title != "Compressed" {
+		t.Errorf("Get() = %v, want the compressed ticket", got)
+	}
+}
+
+func TestStore_AddComment_TooLong(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer store.Close()
+
+	tk, _ := ticket.New("TH", "Chatty", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	defer ticket.SetMaxCommentLength(0)
+	ticket.SetMaxCommentLength(5)
+	c := &ticket.Comment{ID: "TH-c11111", TicketID: tk.ID, Content: "far too long", Created: time.Now().UTC()}
+	if err := store.AddComment(c); err != ticket.ErrCommentTooLong {
+		t.Fatalf("AddComment() error = %v, want ErrCommentTooLong", err)
+	}
+
+	comments, err := store.GetComments(tk.ID)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("GetComments() = %d comments, want 0", len(comments))
+	}
+}
+
+func TestOpen_RepairsCorruptCache(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	tk, _ := ticket.New("TH", "Survivor", "", ticket.TypeTask, 1, nil, "", 0)
+	if err := store.Add(tk); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	store.Close()
+
+	if err := os.WriteFile(paths.Cache, []byte("this is not a SQLite database"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var warnings bytes.Buffer
+	oldWarnings := warningOutput
+	warningOutput = &warnings
+	defer func() { warningOutput = oldWarnings }()
+
+	store, err = Open(paths)
+	if err != nil {
+		t.Fatalf("Open() with corrupt cache error = %v", err)
+	}
+	defer store.Close()
+
+	if !store.Repaired() {
+		t.Error("Repaired() = false, want true after rebuilding a corrupt cache")
+	}
+	if !strings.Contains(warnings.String(), "rebuilding it from tickets.jsonl") {
+		t.Errorf("warnings = %q, want a rebuild warning", warnings.String())
+	}
+	got, err := store.Get(tk.ID)
+	if err != nil || got == nil || got.Title != "Survivor" {
+		t.Errorf("Get(%s) = %v, %v; want the ticket rebuilt from tickets.jsonl", tk.ID, got, err)
+	}
+}
+
+func TestOpen_MigratesOutdatedCache(t *testing.T) {
+	paths, cleanup := setupTestProject(t)
+	defer cleanup()
+
+	tk, _ := ticket.New("TH", "Estimated", "", ticket.TypeTask, 1, []string{"ui"}, "alice", 3)
+	if err := AppendJSONL(paths, tk); err != nil {
+		t.Fatalf("AppendJSONL() error = %v", err)
+	}
+	modTime, err := GetJSONLModTime(paths)
+	if err != nil {
+		t.Fatalf("GetJSONLModTime() error = %v", err)
+	}
+	checksum, err := GetJSONLChecksum(paths)
+	if err != nil {
+		t.Fatalf("GetJSONLChecksum() error = %v", err)
+	}
+
+	// Simulate a cache written by an older version: no estimate or author
+	// columns, no schema version, and sync metadata claiming it is current.
+	conn, err := sql.Open("sqlite3", paths.Cache)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	_, err = conn.Exec(`
+		CREATE TABLE tickets (id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT, type TEXT,
+			status TEXT NOT NULL DEFAULT 'open', priority INTEGER NOT NULL DEFAULT 0, created TEXT NOT NULL, updated TEXT NOT NULL);
+		CREATE TABLE metadata (key TEXT PRIMARY KEY, value TEXT);
+		INSERT INTO tickets (id, title, status, priority, created, updated) VALUES (?, 'Stale title', 'open', 1, ?, ?);
+		INSERT INTO metadata (key, value) VALUES ('jsonl_modtime', ?), ('jsonl_checksum', ?);
+	`, tk.ID, tk.Created.Format(time.RFC3339Nano), tk.Updated.Format(time.RFC3339Nano), strconv.FormatInt(modTime, 10), checksum)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("creating old schema: %v", err)
+	}
+
+	store, err := Open(paths)
+	if err != nil {
+		t.Fatalf("Open() with outdated cache error = %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get(tk.ID)
+	if err != nil || got == nil {
+		t.Fatalf("Get(%s) = %v, %v", tk.ID, got, err)
+	}
+	if got.Title != "Estimated" || got.Estimate != 3 || got.Assignee != "alice" || !slices.Equal(got.Labels, []string{"ui"}) {
+		t.Errorf("Get(%s) = %+v, want every field from tickets.jsonl", tk.ID, got)
+	}
+	if version, _ := store.db.GetMetadata(metaKeySchemaVersion); version != schemaVersion {
+		t.Errorf("schema_version = %q, want %q", version, schemaVersion)
+	}
+}
@@ -2,27 +2,60 @@
 package ticket
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Comment represents a comment on a ticket.
 type Comment struct {
-	ID       string    `json:"id"`        // Format: TH-cXXXXXX (project code + c + 6 alphanumeric chars)
-	TicketID string    `json:"ticket_id"` // The ticket this comment belongs to
-	Content  string    `json:"content"`   // Comment text
-	Created  time.Time `json:"created"`   // Timestamp
+	ID       string    `json:"id"`                 // Format: TH-cXXXXXX (project code + c + 6 alphanumeric chars)
+	TicketID string    `json:"ticket_id"`          // The ticket this comment belongs to
+	Content  string    `json:"content"`            // Comment text
+	Author   string    `json:"author,omitempty"`   // Who wrote the comment, if known
+	Created  time.Time `json:"created"`            // Timestamp
+	Acked    time.Time `json:"acked,omitzero"`     // When the comment was acknowledged, if it was
+	AckedBy  string    `json:"acked_by,omitempty"` // Who acknowledged the comment, if known
 }
 
 var (
-	ErrEmptyComment    = errors.New("comment content cannot be empty")
+	ErrEmptyComment     = errors.New("comment content cannot be empty")
+	ErrCommentTooLong   = errors.New("comment is too long")
 	ErrInvalidCommentID = errors.New("invalid comment ID format")
 )
 
+// DefaultMaxCommentLength is the maximum comment length, in characters,
+// unless the config sets max_comment_length.
+const DefaultMaxCommentLength = 10000
+
+var maxCommentLength = DefaultMaxCommentLength
+
+// SetMaxCommentLength overrides the maximum comment length.
+// A non-positive value restores the default.
+func SetMaxCommentLength(max int) {
+	if max <= 0 {
+		max = DefaultMaxCommentLength
+	}
+	maxCommentLength = max
+}
+
+// MaxCommentLength returns the maximum number of characters allowed in a comment.
+func MaxCommentLength() int {
+	return maxCommentLength
+}
+
+// ValidateCommentLength checks comment content against the configured limit.
+func ValidateCommentLength(content string) error {
+	if utf8.RuneCountInString(strings.TrimSpace(content)) > maxCommentLength {
+		return ErrCommentTooLong
+	}
+	return nil
+}
+
 // commentIDPattern matches valid comment IDs: two uppercase letters, hyphen, 'c', six alphanumeric chars.
 var commentIDPattern = regexp.MustCompile(`^[A-Z]{2}-c[a-z0-9]{6}$`)
 
@@ -34,7 +67,7 @@ func GenerateCommentID(projectCode string) (string, error) {
 
 	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 	result := make([]byte, 6)
-	if _, err := rand.Read(result); err != nil {
+	if _, err := io.ReadFull(randReader, result); err != nil {
 		return "", fmt.Errorf("generating random ID: %w", err)
 	}
 
@@ -63,6 +96,9 @@ func NewComment(ticketID, content string) (*Comment, error) {
 	if content == "" {
 		return nil, ErrEmptyComment
 	}
+	if err := ValidateCommentLength(content); err != nil {
+		return nil, err
+	}
 
 	projectCode, err := ParseProjectCode(ticketID)
 	if err != nil {
@@ -82,6 +118,17 @@ func NewComment(ticketID, content string) (*Comment, error) {
 	}, nil
 }
 
+// Ack marks the comment as acknowledged by who, which may be empty, now.
+func (c *Comment) Ack(who string) {
+	c.Acked = time.Now().UTC()
+	c.AckedBy = who
+}
+
+// IsAcked reports whether the comment has been acknowledged.
+func (c *Comment) IsAcked() bool {
+	return !c.Acked.IsZero()
+}
+
 // Validate checks if the comment has valid field values.
 func (c *Comment) Validate() error {
 	if err := ValidateCommentID(c.ID); err != nil {
@@ -145,3 +145,20 @@ func TestComment_Validate(t *testing.T) {
 		t.Error("Validate() expected error for empty content")
 	}
 }
+
+func TestNewComment_TooLong(t *testing.T) {
+	defer SetMaxCommentLength(0)
+	SetMaxCommentLength(10)
+
+	if _, err := NewComment("TH-abcdef", "  "+strings.Repeat("é", 10)+"\n"); err != nil {
+		t.Errorf("NewComment() at the limit error = %v", err)
+	}
+	if _, err := NewComment("TH-abcdef", strings.Repeat("a", 11)); err != ErrCommentTooLong {
+		t.Errorf("NewComment() error = %v, want ErrCommentTooLong", err)
+	}
+
+	SetMaxCommentLength(0)
+	if MaxCommentLength() != DefaultMaxCommentLength {
+		t.Errorf("MaxCommentLength() = %d, want the default %d", MaxCommentLength(), DefaultMaxCommentLength)
+	}
+}
@@ -2,9 +2,9 @@
 package ticket
 
 import (
-	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
 	"strings"
 	"time"
@@ -18,6 +18,9 @@ const (
 	DependencyBlockedBy DependencyType = "blocked_by"
 	// DependencyCreatedFrom indicates that a ticket was created from another ticket.
 	DependencyCreatedFrom DependencyType = "created_from"
+	// DependencyRelatedTo indicates that a ticket is related to another ticket,
+	// such as a duplicate of it.
+	DependencyRelatedTo DependencyType = "related_to"
 )
 
 // Dependency represents a relationship between two tickets.
@@ -43,7 +46,7 @@ var dependencyIDPattern = regexp.MustCompile(`^[A-Z]{2}-d[a-z0-9]{6}$`)
 // ValidateDependencyType checks if a dependency type is valid.
 func ValidateDependencyType(t DependencyType) error {
 	switch t {
-	case DependencyBlockedBy, DependencyCreatedFrom:
+	case DependencyBlockedBy, DependencyCreatedFrom, DependencyRelatedTo:
 		return nil
 	default:
 		return ErrInvalidDependencyType
@@ -58,7 +61,7 @@ func GenerateDependencyID(projectCode string) (string, error) {
 
 	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 	result := make([]byte, 6)
-	if _, err := rand.Read(result); err != nil {
+	if _, err := io.ReadFull(randReader, result); err != nil {
 		return "", fmt.Errorf("generating random ID: %w", err)
 	}
 
@@ -0,0 +1,45 @@
+package ticket
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SanitizeLine makes untrusted text safe to print on a single terminal line.
+// Control characters, including ANSI escape sequences, newlines, and tabs, are
+// replaced with visible escapes (e.g., "\x1b") so they cannot alter the
+// terminal state or break table layout. Stored data is never modified; this is
+// only applied when rendering for humans.
+func SanitizeLine(s string) string {
+	return sanitize(s, false)
+}
+
+// SanitizeText is like SanitizeLine but preserves newlines and tabs, for
+// multi-line content such as descriptions and comments.
+func SanitizeText(s string) string {
+	return sanitize(s, true)
+}
+
+func sanitize(s string, multiline bool) string {
+	if !strings.ContainsFunc(s, unicode.IsControl) {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case multiline && (r == '\n' || r == '\t'):
+			b.WriteRune(r)
+		case multiline && r == '\r':
+			// Drop carriage returns so CRLF text renders as plain newlines.
+		case r < 0x80 && unicode.IsControl(r):
+			fmt.Fprintf(&b, "\\x%02x", r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, "\\u%04x", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
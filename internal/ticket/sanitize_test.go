@@ -0,0 +1,45 @@
+package ticket
+
+import "testing"
+
+func TestSanitizeLine(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Fix login bug", "Fix login bug"},
+		{"unicode", "Café ☕", "Café ☕"},
+		{"ansi color", "\x1b[31mred\x1b[0m", `\x1b[31mred\x1b[0m`},
+		{"newline", "one\ntwo", `one\x0atwo`},
+		{"tab", "a\tb", `a\x09b`},
+		{"bell", "ding\a", `ding\x07`},
+		{"c1 control", "csi\u009b2J", `csi\u009b2J`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeLine(tt.in); got != tt.want {
+				t.Errorf("SanitizeLine(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"keeps newlines and tabs", "one\n\ttwo", "one\n\ttwo"},
+		{"drops carriage returns", "one\r\ntwo", "one\ntwo"},
+		{"escapes ansi", "\x1b]0;title\x07", `\x1b]0;title\x07`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeText(tt.in); got != tt.want {
+				t.Errorf("SanitizeText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,9 +5,12 @@ import (
 	"crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Status represents the state of a ticket.
@@ -17,6 +20,9 @@ const (
 	StatusOpen   Status = "open"
 	StatusClosed Status = "closed"
 	StatusIcebox Status = "icebox"
+	// StatusDeleted marks a soft-deleted ticket. Deleted tickets stay in the
+	// tickets file so they can be restored, but are hidden by default.
+	StatusDeleted Status = "deleted"
 )
 
 // Type represents the category of a ticket.
@@ -32,18 +38,35 @@ const (
 
 // Ticket represents a single issue in the tracker.
 type Ticket struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Type        Type      `json:"type"`
-	Status      Status    `json:"status"`
-	Priority    int       `json:"priority"`
-	Labels      []string  `json:"labels"`
-	Assignee    string    `json:"assignee"`
-	Created     time.Time `json:"created"`
-	Updated     time.Time `json:"updated"`
+	ID             string      `json:"id"`
+	Title          string      `json:"title"`
+	Description    string      `json:"description"`
+	Type           Type        `json:"type"`
+	Status         Status      `json:"status"`
+	Priority       int         `json:"priority"`
+	Labels         []string    `json:"labels"`
+	Watchers       []string    `json:"watchers,omitempty"` // People notified about changes
+	Assignee       string      `json:"assignee"`
+	Estimate       int         `json:"estimate,omitempty"` // Rough size in points; 0 means unestimated
+	CloseReason    CloseReason `json:"close_reason,omitempty"`
+	Rank           int         `json:"rank,omitempty"`            // Orders tickets of the same priority; lower first, 0 (unranked) last
+	CreatedBy      string      `json:"created_by,omitempty"`      // Who created the ticket, if known
+	UpdatedBy      string      `json:"updated_by,omitempty"`      // Who last changed the ticket, if known
+	IdempotencyKey string      `json:"idempotency_key,omitempty"` // Set by add --idempotency-key to detect retries
+	Created        time.Time   `json:"created"`
+	Updated        time.Time   `json:"updated"`
 }
 
+// CloseReason records why a ticket was closed.
+type CloseReason string
+
+const (
+	CloseReasonDone      CloseReason = "done"
+	CloseReasonWontfix   CloseReason = "wontfix"
+	CloseReasonDuplicate CloseReason = "duplicate"
+	CloseReasonObsolete  CloseReason = "obsolete"
+)
+
 var (
 	ErrInvalidID          = errors.New("invalid ticket ID format")
 	ErrEmptyTitle         = errors.New("ticket title cannot be empty")
@@ -51,8 +74,103 @@ var (
 	ErrInvalidType        = errors.New("invalid ticket type")
 	ErrInvalidProjectCode = errors.New("project code must be exactly two uppercase letters")
 	ErrInvalidLabel       = errors.New("label must be 1-30 alphanumeric characters, hyphens, or underscores")
+	ErrTitleTooLong       = errors.New("ticket title is too long")
+	ErrDescriptionTooLong = errors.New("ticket description is too long")
+	ErrInvalidCloseReason = errors.New("invalid close reason")
+	ErrInvalidPriority    = errors.New("priority is out of range")
+	ErrInvalidEstimate    = errors.New("estimate cannot be negative")
+)
+
+// Default length limits for ticket text fields, measured in characters.
+const (
+	DefaultMaxTitleLength       = 200
+	DefaultMaxDescriptionLength = 10000
+)
+
+var (
+	maxTitleLength       = DefaultMaxTitleLength
+	maxDescriptionLength = DefaultMaxDescriptionLength
 )
 
+// SetLengthLimits overrides the maximum title and description lengths.
+// A non-positive value restores the corresponding default.
+func SetLengthLimits(title, description int) {
+	if title <= 0 {
+		title = DefaultMaxTitleLength
+	}
+	if description <= 0 {
+		description = DefaultMaxDescriptionLength
+	}
+	maxTitleLength = title
+	maxDescriptionLength = description
+}
+
+// DefaultMaxPriority is the lowest priority (largest number) a ticket can
+// have unless the config sets priority_max.
+const DefaultMaxPriority = 5
+
+var maxPriority = DefaultMaxPriority
+
+// SetMaxPriority overrides the largest allowed priority value.
+// A non-positive value restores the default.
+func SetMaxPriority(max int) {
+	if max <= 0 {
+		max = DefaultMaxPriority
+	}
+	maxPriority = max
+}
+
+// MaxPriority returns the largest allowed priority value.
+func MaxPriority() int {
+	return maxPriority
+}
+
+// ValidatePriority checks that a priority is between 0 and MaxPriority.
+func ValidatePriority(priority int) error {
+	if priority < 0 || priority > maxPriority {
+		return ErrInvalidPriority
+	}
+	return nil
+}
+
+// ValidateEstimate checks that an estimate is not negative.
+func ValidateEstimate(estimate int) error {
+	if estimate < 0 {
+		return ErrInvalidEstimate
+	}
+	return nil
+}
+
+// MaxTitleLength returns the maximum number of characters allowed in a title.
+func MaxTitleLength() int {
+	return maxTitleLength
+}
+
+// MaxDescriptionLength returns the maximum number of characters allowed in a description.
+func MaxDescriptionLength() int {
+	return maxDescriptionLength
+}
+
+// validateTitleLength checks a trimmed title against the configured limit.
+func validateTitleLength(title string) error {
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return ErrTitleTooLong
+	}
+	return nil
+}
+
+// validateDescriptionLength checks a trimmed description against the configured limit.
+func validateDescriptionLength(description string) error {
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return ErrDescriptionTooLong
+	}
+	return nil
+}
+
+// randReader is the source of randomness for generated IDs. Tests can
+// replace it to get reproducible IDs.
+var randReader io.Reader = rand.Reader
+
 // idPattern matches valid ticket IDs: two uppercase letters, hyphen, six alphanumeric chars.
 var idPattern = regexp.MustCompile(`^[A-Z]{2}-[a-z0-9]{6}$`)
 
@@ -78,7 +196,7 @@ func GenerateID(projectCode string) (string, error) {
 
 	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 	result := make([]byte, 6)
-	if _, err := rand.Read(result); err != nil {
+	if _, err := io.ReadFull(randReader, result); err != nil {
 		return "", fmt.Errorf("generating random ID: %w", err)
 	}
 
@@ -108,13 +226,23 @@ func ParseProjectCode(id string) (string, error) {
 // ValidateStatus checks if a status value is valid.
 func ValidateStatus(s Status) error {
 	switch s {
-	case StatusOpen, StatusClosed, StatusIcebox:
+	case StatusOpen, StatusClosed, StatusIcebox, StatusDeleted:
 		return nil
 	default:
 		return ErrInvalidStatus
 	}
 }
 
+// ValidateCloseReason checks if a close reason is valid. An empty reason is valid.
+func ValidateCloseReason(r CloseReason) error {
+	switch r {
+	case "", CloseReasonDone, CloseReasonWontfix, CloseReasonDuplicate, CloseReasonObsolete:
+		return nil
+	default:
+		return ErrInvalidCloseReason
+	}
+}
+
 // ValidateType checks if a type value is valid.
 // An empty type is allowed for tickets where type is not specified.
 func ValidateType(t Type) error {
@@ -149,7 +277,7 @@ func ValidateLabels(labels []string) error {
 
 // New creates a new ticket with the given parameters.
 // New creates a new ticket with a generated ID.
-func New(projectCode, title, description string, issueType Type, priority int, labels []string, assignee string) (*Ticket, error) {
+func New(projectCode, title, description string, issueType Type, priority int, labels []string, assignee string, estimate int) (*Ticket, error) {
 	id, err := GenerateID(projectCode)
 	if err != nil {
 		return nil, err
@@ -159,6 +287,14 @@ func New(projectCode, title, description string, issueType Type, priority int, l
 	if title == "" {
 		return nil, ErrEmptyTitle
 	}
+	if err := validateTitleLength(title); err != nil {
+		return nil, err
+	}
+
+	description = strings.TrimSpace(description)
+	if err := validateDescriptionLength(description); err != nil {
+		return nil, err
+	}
 
 	if err := ValidateLabels(labels); err != nil {
 		return nil, err
@@ -168,16 +304,25 @@ func New(projectCode, title, description string, issueType Type, priority int, l
 		return nil, err
 	}
 
+	if err := ValidatePriority(priority); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateEstimate(estimate); err != nil {
+		return nil, err
+	}
+
 	now := time.Now().UTC()
 	return &Ticket{
 		ID:          id,
 		Title:       title,
-		Description: strings.TrimSpace(description),
+		Description: description,
 		Type:        issueType,
 		Status:      StatusOpen,
 		Priority:    priority,
 		Labels:      labels,
 		Assignee:    strings.TrimSpace(assignee),
+		Estimate:    estimate,
 		Created:     now,
 		Updated:     now,
 	}, nil
@@ -197,26 +342,98 @@ func (t *Ticket) Validate() error {
 	if err := ValidateType(t.Type); err != nil {
 		return err
 	}
+	if err := ValidateCloseReason(t.CloseReason); err != nil {
+		return err
+	}
 	return nil
 }
 
 // Close marks the ticket as closed and updates the timestamp.
 func (t *Ticket) Close() {
 	t.Status = StatusClosed
+	t.CloseReason = ""
+	t.Updated = time.Now().UTC()
+}
+
+// CloseWithReason marks the ticket as closed and records why.
+func (t *Ticket) CloseWithReason(reason CloseReason) error {
+	if err := ValidateCloseReason(reason); err != nil {
+		return err
+	}
+	t.Close()
+	t.CloseReason = reason
+	return nil
+}
+
+// SetPriority changes the ticket's priority. A ticket that changes priority
+// becomes unranked, since its rank placed it among its old peers.
+func (t *Ticket) SetPriority(priority int) {
+	if priority != t.Priority {
+		t.Priority = priority
+		t.Rank = 0
+	}
+}
+
+// Reopen marks a closed ticket as open again and clears its close reason.
+func (t *Ticket) Reopen() {
+	t.Status = StatusOpen
+	t.CloseReason = ""
+	t.Updated = time.Now().UTC()
+}
+
+// Watch adds name to the ticket's watchers. It reports whether name was
+// added, which it isn't if name is empty or already watching.
+func (t *Ticket) Watch(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" || slices.Contains(t.Watchers, name) {
+		return false
+	}
+	t.Watchers = append(t.Watchers, name)
+	return true
+}
+
+// Unwatch removes name from the ticket's watchers. It reports whether name
+// was watching.
+func (t *Ticket) Unwatch(name string) bool {
+	i := slices.Index(t.Watchers, strings.TrimSpace(name))
+	if i < 0 {
+		return false
+	}
+	t.Watchers = slices.Delete(t.Watchers, i, i+1)
+	return true
+}
+
+// Delete soft-deletes the ticket.
+func (t *Ticket) Delete() {
+	t.Status = StatusDeleted
+	t.Updated = time.Now().UTC()
+}
+
+// Restore reopens a soft-deleted ticket.
+func (t *Ticket) Restore() {
+	t.Status = StatusOpen
+	t.CloseReason = ""
 	t.Updated = time.Now().UTC()
 }
 
 // Update modifies the ticket fields and updates the timestamp.
-func (t *Ticket) Update(title, description *string, issueType *Type, priority *int, status *Status, addLabels, removeLabels []string, assignee *string) error {
+func (t *Ticket) Update(title, description *string, issueType *Type, priority *int, status *Status, addLabels, removeLabels []string, assignee *string, estimate *int) error {
 	if title != nil {
 		trimmed := strings.TrimSpace(*title)
 		if trimmed == "" {
 			return ErrEmptyTitle
 		}
+		if err := validateTitleLength(trimmed); err != nil {
+			return err
+		}
 		t.Title = trimmed
 	}
 	if description != nil {
-		t.Description = strings.TrimSpace(*description)
+		trimmed := strings.TrimSpace(*description)
+		if err := validateDescriptionLength(trimmed); err != nil {
+			return err
+		}
+		t.Description = trimmed
 	}
 	if issueType != nil {
 		if err := ValidateType(*issueType); err != nil {
@@ -225,13 +442,19 @@ func (t *Ticket) Update(title, description *string, issueType *Type, priority *i
 		t.Type = *issueType
 	}
 	if priority != nil {
-		t.Priority = *priority
+		if err := ValidatePriority(*priority); err != nil {
+			return err
+		}
+		t.SetPriority(*priority)
 	}
 	if status != nil {
 		if err := ValidateStatus(*status); err != nil {
 			return err
 		}
 		t.Status = *status
+		if t.Status != StatusClosed {
+			t.CloseReason = ""
+		}
 	}
 
 	// Handle label additions
@@ -272,6 +495,13 @@ func (t *Ticket) Update(title, description *string, issueType *Type, priority *i
 		t.Assignee = strings.TrimSpace(*assignee)
 	}
 
+	if estimate != nil {
+		if err := ValidateEstimate(*estimate); err != nil {
+			return err
+		}
+		t.Estimate = *estimate
+	}
+
 	t.Updated = time.Now().UTC()
 	return nil
 }
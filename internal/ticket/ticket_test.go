@@ -1,6 +1,7 @@
 package ticket
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 )
@@ -56,6 +57,25 @@ func TestGenerateID(t *testing.T) {
 	}
 }
 
+func TestGenerateID_SeededReader(t *testing.T) {
+	orig := randReader
+	defer func() { randReader = orig }()
+
+	randReader = bytes.NewReader([]byte{0, 1, 2, 3, 4, 5, 36, 37})
+	id, err := GenerateID("TH")
+	if err != nil {
+		t.Fatalf("GenerateID() error = %v", err)
+	}
+	if id != "TH-abcdef" {
+		t.Errorf("GenerateID() = %q, want %q", id, "TH-abcdef")
+	}
+
+	// A short reader is an error rather than a short ID.
+	if _, err := GenerateID("TH"); err == nil {
+		t.Error("GenerateID() expected error when the reader runs out")
+	}
+}
+
 func TestGenerateID_InvalidCode(t *testing.T) {
 	_, err := GenerateID("invalid")
 	if err == nil {
@@ -110,6 +130,8 @@ func TestValidateStatus(t *testing.T) {
 	}{
 		{StatusOpen, false},
 		{StatusClosed, false},
+		{StatusIcebox, false},
+		{StatusDeleted, false},
 		{"", true},
 		{"pending", true},
 		{"OPEN", true},
@@ -152,7 +174,7 @@ func TestValidateType(t *testing.T) {
 }
 
 func TestNew(t *testing.T) {
-	ticket, err := New("TH", "Test ticket", "A description", TypeTask, 1, nil, "")
+	ticket, err := New("TH", "Test ticket", "A description", TypeTask, 1, nil, "", 0)
 	if err != nil {
 		t.Fatalf("New() error = %v", err)
 	}
@@ -184,7 +206,7 @@ func TestNew(t *testing.T) {
 }
 
 func TestNew_TrimSpace(t *testing.T) {
-	ticket, err := New("TH", "  Test ticket  ", "  Description  ", TypeTask, 0, nil, "")
+	ticket, err := New("TH", "  Test ticket  ", "  Description  ", TypeTask, 0, nil, "", 0)
 	if err != nil {
 		t.Fatalf("New() error = %v", err)
 	}
@@ -198,12 +220,12 @@ func TestNew_TrimSpace(t *testing.T) {
 }
 
 func TestNew_EmptyTitle(t *testing.T) {
-	_, err := New("TH", "", "Description", TypeTask, 0, nil, "")
+	_, err := New("TH", "", "Description", TypeTask, 0, nil, "", 0)
 	if err != ErrEmptyTitle {
 		t.Errorf("New() error = %v, want ErrEmptyTitle", err)
 	}
 
-	_, err = New("TH", "   ", "Description", TypeTask, 0, nil, "")
+	_, err = New("TH", "   ", "Description", TypeTask, 0, nil, "", 0)
 	if err != ErrEmptyTitle {
 		t.Errorf("New() error = %v, want ErrEmptyTitle", err)
 	}
@@ -261,6 +283,72 @@ func TestTicket_Close(t *testing.T) {
 	}
 }
 
+func TestTicket_Reopen(t *testing.T) {
+	ticket := &Ticket{
+		ID:          "TH-abcdef",
+		Title:       "Test",
+		Status:      StatusClosed,
+		CloseReason: CloseReasonWontfix,
+	}
+
+	ticket.Reopen()
+
+	if ticket.Status != StatusOpen {
+		t.Errorf("Reopen() status = %q, want %q", ticket.Status, StatusOpen)
+	}
+	if ticket.CloseReason != "" {
+		t.Errorf("Reopen() close reason = %q, want empty", ticket.CloseReason)
+	}
+	if ticket.Updated.IsZero() {
+		t.Error("Reopen() did not update timestamp")
+	}
+}
+
+func TestTicket_CloseWithReason(t *testing.T) {
+	ticket := &Ticket{
+		ID:     "TH-abcdef",
+		Title:  "Test",
+		Status: StatusOpen,
+	}
+
+	if err := ticket.CloseWithReason("fixed"); err != ErrInvalidCloseReason {
+		t.Errorf("CloseWithReason(fixed) error = %v, want ErrInvalidCloseReason", err)
+	}
+	if ticket.Status != StatusOpen {
+		t.Errorf("invalid reason changed status to %q", ticket.Status)
+	}
+
+	if err := ticket.CloseWithReason(CloseReasonWontfix); err != nil {
+		t.Fatalf("CloseWithReason() error = %v", err)
+	}
+	if ticket.Status != StatusClosed || ticket.CloseReason != CloseReasonWontfix {
+		t.Errorf("CloseWithReason() = status %q, reason %q; want closed, wontfix", ticket.Status, ticket.CloseReason)
+	}
+
+	// Reopening clears the reason.
+	open := StatusOpen
+	if err := ticket.Update(nil, nil, nil, nil, &open, nil, nil, nil, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if ticket.CloseReason != "" {
+		t.Errorf("CloseReason = %q after reopening, want empty", ticket.CloseReason)
+	}
+}
+
+func TestTicket_SetPriority(t *testing.T) {
+	ticket := &Ticket{ID: "TH-abcdef", Title: "Test", Priority: 1, Rank: 3}
+
+	ticket.SetPriority(1)
+	if ticket.Rank != 3 {
+		t.Errorf("SetPriority() to the same priority rank = %d, want 3", ticket.Rank)
+	}
+
+	ticket.SetPriority(2)
+	if ticket.Priority != 2 || ticket.Rank != 0 {
+		t.Errorf("SetPriority(2) = priority %d, rank %d; want 2, 0", ticket.Priority, ticket.Rank)
+	}
+}
+
 func TestTicket_Update(t *testing.T) {
 	ticket := &Ticket{
 		ID:          "TH-abcdef",
@@ -275,7 +363,7 @@ func TestTicket_Update(t *testing.T) {
 	newPriority := 2
 	newStatus := StatusClosed
 
-	err := ticket.Update(&newTitle, &newDesc, nil, &newPriority, &newStatus, nil, nil, nil)
+	err := ticket.Update(&newTitle, &newDesc, nil, &newPriority, &newStatus, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -304,7 +392,7 @@ func TestTicket_Update_Partial(t *testing.T) {
 	}
 
 	newTitle := "Updated"
-	err := ticket.Update(&newTitle, nil, nil, nil, nil, nil, nil, nil)
+	err := ticket.Update(&newTitle, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -324,7 +412,7 @@ func TestTicket_Update_EmptyTitle(t *testing.T) {
 	}
 
 	emptyTitle := ""
-	err := ticket.Update(&emptyTitle, nil, nil, nil, nil, nil, nil, nil)
+	err := ticket.Update(&emptyTitle, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != ErrEmptyTitle {
 		t.Errorf("Update() error = %v, want ErrEmptyTitle", err)
 	}
@@ -338,7 +426,7 @@ func TestTicket_Update_InvalidStatus(t *testing.T) {
 	}
 
 	invalidStatus := Status("invalid")
-	err := ticket.Update(nil, nil, nil, nil, &invalidStatus, nil, nil, nil)
+	err := ticket.Update(nil, nil, nil, nil, &invalidStatus, nil, nil, nil, nil)
 	if err != ErrInvalidStatus {
 		t.Errorf("Update() error = %v, want ErrInvalidStatus", err)
 	}
@@ -359,7 +447,7 @@ func TestNew_Types(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ticket, err := New("TH", "Title", "", tt.ticketType, 1, nil, "")
+			ticket, err := New("TH", "Title", "", tt.ticketType, 1, nil, "", 0)
 			if err != nil {
 				t.Fatalf("New() error = %v", err)
 			}
@@ -371,16 +459,16 @@ func TestNew_Types(t *testing.T) {
 }
 
 func TestNew_InvalidType(t *testing.T) {
-	_, err := New("TH", "Title", "", Type("custom"), 1, nil, "")
+	_, err := New("TH", "Title", "", Type("custom"), 1, nil, "", 0)
 	if err != ErrInvalidType {
 		t.Errorf("New() error = %v, want ErrInvalidType", err)
 	}
 }
 
 func TestUpdate_Type(t *testing.T) {
-	tk, _ := New("TH", "Title", "", TypeTask, 1, nil, "")
+	tk, _ := New("TH", "Title", "", TypeTask, 1, nil, "", 0)
 	newType := TypeBug
-	err := tk.Update(nil, nil, &newType, nil, nil, nil, nil, nil)
+	err := tk.Update(nil, nil, &newType, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -390,9 +478,9 @@ func TestUpdate_Type(t *testing.T) {
 }
 
 func TestUpdate_InvalidType(t *testing.T) {
-	tk, _ := New("TH", "Title", "", TypeTask, 1, nil, "")
+	tk, _ := New("TH", "Title", "", TypeTask, 1, nil, "", 0)
 	invalidType := Type("custom")
-	err := tk.Update(nil, nil, &invalidType, nil, nil, nil, nil, nil)
+	err := tk.Update(nil, nil, &invalidType, nil, nil, nil, nil, nil, nil)
 	if err != ErrInvalidType {
 		t.Errorf("Update() error = %v, want ErrInvalidType", err)
 	}
@@ -427,7 +515,7 @@ func TestValidateLabel(t *testing.T) {
 }
 
 func TestNew_WithLabels(t *testing.T) {
-	ticket, err := New("TH", "Test ticket", "Description", TypeTask, 1, []string{"bug", "urgent"}, "")
+	ticket, err := New("TH", "Test ticket", "Description", TypeTask, 1, []string{"bug", "urgent"}, "", 0)
 	if err != nil {
 		t.Fatalf("New() error = %v", err)
 	}
@@ -441,7 +529,7 @@ func TestNew_WithLabels(t *testing.T) {
 }
 
 func TestNew_InvalidLabel(t *testing.T) {
-	_, err := New("TH", "Test ticket", "Description", TypeTask, 1, []string{"valid", "has space"}, "")
+	_, err := New("TH", "Test ticket", "Description", TypeTask, 1, []string{"valid", "has space"}, "", 0)
 	if err != ErrInvalidLabel {
 		t.Errorf("New() error = %v, want ErrInvalidLabel", err)
 	}
@@ -455,7 +543,7 @@ func TestTicket_Update_AddLabels(t *testing.T) {
 		Labels: []string{"existing"},
 	}
 
-	err := ticket.Update(nil, nil, nil, nil, nil, []string{"new-label"}, nil, nil)
+	err := ticket.Update(nil, nil, nil, nil, nil, []string{"new-label"}, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -473,7 +561,7 @@ func TestTicket_Update_RemoveLabels(t *testing.T) {
 		Labels: []string{"keep", "remove"},
 	}
 
-	err := ticket.Update(nil, nil, nil, nil, nil, nil, []string{"remove"}, nil)
+	err := ticket.Update(nil, nil, nil, nil, nil, nil, []string{"remove"}, nil, nil)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -494,7 +582,7 @@ func TestTicket_Update_AddDuplicateLabel(t *testing.T) {
 		Labels: []string{"existing"},
 	}
 
-	err := ticket.Update(nil, nil, nil, nil, nil, []string{"existing"}, nil, nil)
+	err := ticket.Update(nil, nil, nil, nil, nil, []string{"existing"}, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -512,8 +600,149 @@ func TestTicket_Update_InvalidAddLabel(t *testing.T) {
 		Status: StatusOpen,
 	}
 
-	err := ticket.Update(nil, nil, nil, nil, nil, []string{"has space"}, nil, nil)
+	err := ticket.Update(nil, nil, nil, nil, nil, []string{"has space"}, nil, nil, nil)
 	if err != ErrInvalidLabel {
 		t.Errorf("Update() error = %v, want ErrInvalidLabel", err)
 	}
 }
+
+func TestNew_TitleTooLong(t *testing.T) {
+	title := strings.Repeat("a", DefaultMaxTitleLength+1)
+	_, err := New("TH", title, "", TypeTask, 1, nil, "", 0)
+	if err != ErrTitleTooLong {
+		t.Errorf("New() error = %v, want ErrTitleTooLong", err)
+	}
+
+	// Exactly at the limit is fine, and multi-byte characters count once.
+	title = strings.Repeat("é", DefaultMaxTitleLength)
+	if _, err := New("TH", title, "", TypeTask, 1, nil, "", 0); err != nil {
+		t.Errorf("New() error = %v for title at the limit", err)
+	}
+}
+
+func TestNew_DescriptionTooLong(t *testing.T) {
+	desc := strings.Repeat("a", DefaultMaxDescriptionLength+1)
+	_, err := New("TH", "Title", desc, TypeTask, 1, nil, "", 0)
+	if err != ErrDescriptionTooLong {
+		t.Errorf("New() error = %v, want ErrDescriptionTooLong", err)
+	}
+}
+
+func TestTicket_Update_TooLong(t *testing.T) {
+	ticket := &Ticket{
+		ID:    "TH-abcdef",
+		Title: "Original",
+	}
+
+	longTitle := strings.Repeat("a", DefaultMaxTitleLength+1)
+	if err := ticket.Update(&longTitle, nil, nil, nil, nil, nil, nil, nil, nil); err != ErrTitleTooLong {
+		t.Errorf("Update() error = %v, want ErrTitleTooLong", err)
+	}
+	if ticket.Title != "Original" {
+		t.Errorf("Update() Title = %q, want unchanged", ticket.Title)
+	}
+
+	longDesc := strings.Repeat("a", DefaultMaxDescriptionLength+1)
+	if err := ticket.Update(nil, &longDesc, nil, nil, nil, nil, nil, nil, nil); err != ErrDescriptionTooLong {
+		t.Errorf("Update() error = %v, want ErrDescriptionTooLong", err)
+	}
+}
+
+func TestSetMaxPriority(t *testing.T) {
+	defer SetMaxPriority(0)
+
+	if err := ValidatePriority(DefaultMaxPriority); err != nil {
+		t.Errorf("ValidatePriority(%d) error = %v", DefaultMaxPriority, err)
+	}
+	if err := ValidatePriority(-1); err != ErrInvalidPriority {
+		t.Errorf("ValidatePriority(-1) error = %v, want ErrInvalidPriority", err)
+	}
+
+	SetMaxPriority(3)
+	if _, err := New("TH", "Test", "", TypeTask, 3, nil, "", 0); err != nil {
+		t.Errorf("New() at max priority error = %v", err)
+	}
+	if _, err := New("TH", "Test", "", TypeTask, 4, nil, "", 0); err != ErrInvalidPriority {
+		t.Errorf("New() above max priority error = %v, want ErrInvalidPriority", err)
+	}
+
+	tk := &Ticket{ID: "TH-abcdef", Title: "Test", Priority: 1}
+	p := 4
+	if err := tk.Update(nil, nil, nil, &p, nil, nil, nil, nil, nil); err != ErrInvalidPriority {
+		t.Errorf("Update() error = %v, want ErrInvalidPriority", err)
+	}
+	if tk.Priority != 1 {
+		t.Errorf("Update() changed priority to %d", tk.Priority)
+	}
+
+	SetMaxPriority(0)
+	if MaxPriority() != DefaultMaxPriority {
+		t.Errorf("MaxPriority() = %d, want default %d", MaxPriority(), DefaultMaxPriority)
+	}
+}
+
+func TestSetLengthLimits(t *testing.T) {
+	defer SetLengthLimits(0, 0)
+
+	SetLengthLimits(10, 20)
+	if MaxTitleLength() != 10 || MaxDescriptionLength() != 20 {
+		t.Errorf("limits = %d/%d, want 10/20", MaxTitleLength(), MaxDescriptionLength())
+	}
+	if _, err := New("TH", "This title is too long", "", TypeTask, 1, nil, "", 0); err != ErrTitleTooLong {
+		t.Errorf("New() error = %v, want ErrTitleTooLong", err)
+	}
+
+	SetLengthLimits(0, -1)
+	if MaxTitleLength() != DefaultMaxTitleLength || MaxDescriptionLength() != DefaultMaxDescriptionLength {
+		t.Errorf("limits = %d/%d, want defaults", MaxTitleLength(), MaxDescriptionLength())
+	}
+}
+
+func TestTicket_Estimate(t *testing.T) {
+	tk, err := New("TH", "Test", "", TypeTask, 2, nil, "", 3)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if tk.Estimate != 3 {
+		t.Errorf("Estimate = %d, want 3", tk.Estimate)
+	}
+	if _, err := New("TH", "Test", "", TypeTask, 2, nil, "", -1); err != ErrInvalidEstimate {
+		t.Errorf("New() with negative estimate error = %v, want ErrInvalidEstimate", err)
+	}
+
+	e := 5
+	if err := tk.Update(nil, nil, nil, nil, nil, nil, nil, nil, &e); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if tk.Estimate != 5 {
+		t.Errorf("Estimate after Update() = %d, want 5", tk.Estimate)
+	}
+	e = -2
+	if err := tk.Update(nil, nil, nil, nil, nil, nil, nil, nil, &e); err != ErrInvalidEstimate {
+		t.Errorf("Update() with negative estimate error = %v, want ErrInvalidEstimate", err)
+	}
+}
+
+func TestTicket_Watch(t *testing.T) {
+	tk := &Ticket{ID: "TH-abcdef", Title: "Test"}
+
+	if !tk.Watch("alice") || !tk.Watch(" bob ") {
+		t.Fatal("Watch() = false, want true for new watchers")
+	}
+	if tk.Watch("alice") || tk.Watch("") {
+		t.Error("Watch() = true, want false for an existing or empty watcher")
+	}
+	if strings.Join(tk.Watchers, ",") != "alice,bob" {
+		t.Errorf("Watchers = %v, want [alice bob]", tk.Watchers)
+	}
+
+	if !tk.Unwatch("alice") {
+		t.Error("Unwatch(alice) = false, want true")
+	}
+	if tk.Unwatch("carol") {
+		t.Error("Unwatch(carol) = true, want false for someone not watching")
+	}
+	if strings.Join(tk.Watchers, ",") != "bob" {
+		t.Errorf("Watchers = %v, want [bob]", tk.Watchers)
+	}
+}
@@ -0,0 +1,66 @@
+package ticket
+
+import (
+	"fmt"
+	"time"
+)
+
+// Named time formats accepted by FormatTime. Any other non-empty format is
+// used as a Go time layout, such as "Jan 2 15:04".
+const (
+	TimeFormatRFC3339  = "rfc3339"
+	TimeFormatDate     = "date"
+	TimeFormatRelative = "relative"
+)
+
+// FormatTime renders t for humans in the given format. An empty format uses
+// fallback, the layout the caller shows by default.
+func FormatTime(t time.Time, format, fallback string) string {
+	switch format {
+	case "":
+		return t.Format(fallback)
+	case TimeFormatRFC3339:
+		return t.Format(time.RFC3339)
+	case TimeFormatDate:
+		return t.Format(time.DateOnly)
+	case TimeFormatRelative:
+		return relativeTime(t, time.Now())
+	default:
+		return t.Format(format)
+	}
+}
+
+// relativeTime describes t relative to now, such as "3 hours ago" or
+// "in 2 days", using the largest whole unit.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+
+	var n int
+	var unit string
+	switch {
+	case d < time.Hour:
+		n, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int(d/(365*24*time.Hour)), "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
@@ -0,0 +1,45 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	tm := time.Date(2026, 1, 25, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "2026-01-25 10:30:00"},
+		{TimeFormatRFC3339, "2026-01-25T10:30:00Z"},
+		{TimeFormatDate, "2026-01-25"},
+		{"Jan 2 15:04", "Jan 25 10:30"},
+	}
+	for _, tt := range tests {
+		if got := FormatTime(tm, tt.format, time.DateTime); got != tt.want {
+			t.Errorf("FormatTime(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 1, 25, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{3 * time.Hour, "3 hours ago"},
+		{49 * time.Hour, "2 days ago"},
+		{65 * 24 * time.Hour, "2 months ago"},
+		{800 * 24 * time.Hour, "2 years ago"},
+		{-2 * time.Hour, "in 2 hours"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("relativeTime(%v ago) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
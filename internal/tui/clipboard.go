@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clipboardOutput is where copyToClipboard writes its escape sequence: the
+// terminal the TUI is drawn on. It is a variable so tests can capture it.
+var clipboardOutput io.Writer = os.Stdout
+
+// osc52 returns the OSC 52 escape sequence that asks the terminal to put text
+// on the system clipboard. This works over SSH and without a clipboard
+// utility, in terminals that support it. Inside tmux, the sequence is wrapped
+// so that tmux passes it through to the outer terminal.
+func osc52(text string, tmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	if tmux {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// copyToClipboard returns a command that copies text to the clipboard and
+// reports it in the status line.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := io.WriteString(clipboardOutput, osc52(text, os.Getenv("TMUX") != "")); err != nil {
+			return StatusMsg{Message: fmt.Sprintf("Copy failed: %v", err), IsError: true}
+		}
+		return StatusMsg{Message: fmt.Sprintf("Copied %s to the clipboard", text)}
+	}
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOSC52(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		tmux bool
+		want string
+	}{
+		{"plain", "TH-abc123", false, "\x1b]52;c;VEgtYWJjMTIz\a"},
+		{"tmux", "TH-abc123", true, "\x1bPtmux;\x1b\x1b]52;c;VEgtYWJjMTIz\a\x1b\\"},
+		{"empty", "", false, "\x1b]52;c;\a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := osc52(tt.text, tt.tmux); got != tt.want {
+				t.Errorf("osc52(%q, %v) = %q, want %q", tt.text, tt.tmux, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	t.Setenv("TMUX", "")
+	var out strings.Builder
+	old := clipboardOutput
+	clipboardOutput = &out
+	t.Cleanup(func() { clipboardOutput = old })
+
+	msg := copyToClipboard("TH-abc123")()
+	if got, want := out.String(), osc52("TH-abc123", false); got != want {
+		t.Errorf("wrote %q, want %q", got, want)
+	}
+	if status, ok := msg.(StatusMsg); !ok || status.IsError || !strings.Contains(status.Message, "TH-abc123") {
+		t.Errorf("copyToClipboard() message = %#v, want a status naming the ID", msg)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
@@ -28,6 +29,8 @@ type DetailModel struct {
 	loading   bool
 	err       error
 
+	timeFormat string // Format for timestamps (see ticket.FormatTime)
+
 	// Comment input mode
 	commenting   bool
 	commentInput textarea.Model
@@ -184,6 +187,10 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 				m.commentInput.Focus()
 				return m, nil
 			}
+		case key.Matches(msg, m.keys.CopyID):
+			if m.ticket != nil {
+				return m, copyToClipboard(m.ticket.ID)
+			}
 		case key.Matches(msg, m.keys.PriorityUp):
 			if m.ticket != nil {
 				if m.ticket.Priority > 0 {
@@ -192,7 +199,9 @@ func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 			}
 		case key.Matches(msg, m.keys.PriorityDown):
 			if m.ticket != nil {
-				return m, m.updatePriority(m.ticket.Priority + 1)
+				if m.ticket.Priority < ticket.MaxPriority() {
+					return m, m.updatePriority(m.ticket.Priority + 1)
+				}
 			}
 		case key.Matches(msg, m.keys.SetBug):
 			if m.ticket != nil {
@@ -227,6 +236,7 @@ func (m DetailModel) closeTicket() tea.Cmd {
 			return ErrorMsg{Err: err}
 		}
 		t.Status = ticket.StatusClosed
+		t.UpdatedBy = config.ResolveIdentity()
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -240,6 +250,7 @@ func (m DetailModel) saveComment(content string) tea.Cmd {
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
+		comment.Author = config.ResolveIdentity()
 		if err := m.store.AddComment(comment); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -253,7 +264,8 @@ func (m DetailModel) updatePriority(newPriority int) tea.Cmd {
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
-		t.Priority = newPriority
+		t.SetPriority(newPriority)
+		t.UpdatedBy = config.ResolveIdentity()
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -268,6 +280,7 @@ func (m DetailModel) updateType(newType ticket.Type) tea.Cmd {
 			return ErrorMsg{Err: err}
 		}
 		t.Type = newType
+		t.UpdatedBy = config.ResolveIdentity()
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -292,7 +305,7 @@ func (m DetailModel) View() string {
 	var lines []string
 
 	lines = append(lines, m.renderField("ID", t.ID))
-	lines = append(lines, m.renderField("Title", highlightMatches(t.Title, m.searchQuery)))
+	lines = append(lines, m.renderField("Title", highlightMatches(ticket.SanitizeLine(t.Title), m.searchQuery)))
 
 	typ := string(t.Type)
 	if typ == "" {
@@ -300,9 +313,12 @@ func (m DetailModel) View() string {
 	}
 	lines = append(lines, m.renderField("Type", typ))
 	lines = append(lines, m.renderField("Status", string(t.Status)))
+	if t.Status == ticket.StatusClosed && t.CloseReason != "" {
+		lines = append(lines, m.renderField("Reason", string(t.CloseReason)))
+	}
 	lines = append(lines, m.renderField("Priority", fmt.Sprintf("%d", t.Priority)))
 
-	assignee := t.Assignee
+	assignee := ticket.SanitizeLine(t.Assignee)
 	if assignee == "" {
 		assignee = "(unassigned)"
 	}
@@ -314,8 +330,8 @@ func (m DetailModel) View() string {
 	}
 	lines = append(lines, m.renderField("Labels", labels))
 
-	lines = append(lines, m.renderField("Created", t.Created.Format(time.RFC3339)))
-	lines = append(lines, m.renderField("Updated", t.Updated.Format(time.RFC3339)))
+	lines = append(lines, m.renderField("Created", ticket.FormatTime(t.Created, m.timeFormat, time.RFC3339)))
+	lines = append(lines, m.renderField("Updated", ticket.FormatTime(t.Updated, m.timeFormat, time.RFC3339)))
 
 	// Blocked by
 	if len(m.blockedBy) > 0 {
@@ -326,7 +342,7 @@ func (m DetailModel) View() string {
 			if blocker.Status == ticket.StatusClosed {
 				status = " [closed]"
 			}
-			lines = append(lines, fmt.Sprintf("  - %s: %s%s", blocker.ID, blocker.Title, status))
+			lines = append(lines, fmt.Sprintf("  - %s: %s%s", blocker.ID, ticket.SanitizeLine(blocker.Title), status))
 		}
 	}
 
@@ -335,7 +351,7 @@ func (m DetailModel) View() string {
 		lines = append(lines, "")
 		lines = append(lines, subtitleStyle.Render("Blocking:"))
 		for _, blocked := range m.blocking {
-			lines = append(lines, fmt.Sprintf("  - %s: %s", blocked.ID, blocked.Title))
+			lines = append(lines, fmt.Sprintf("  - %s: %s", blocked.ID, ticket.SanitizeLine(blocked.Title)))
 		}
 	}
 
@@ -344,7 +360,7 @@ func (m DetailModel) View() string {
 		lines = append(lines, "")
 		lines = append(lines, subtitleStyle.Render("Description:"))
 		// Wrap description lines
-		for _, line := range strings.Split(t.Description, "\n") {
+		for _, line := range strings.Split(ticket.SanitizeText(t.Description), "\n") {
 			lines = append(lines, "  "+highlightMatches(line, m.searchQuery))
 		}
 	}
@@ -354,8 +370,19 @@ func (m DetailModel) View() string {
 		lines = append(lines, "")
 		lines = append(lines, subtitleStyle.Render("Comments:"))
 		for _, c := range m.comments {
-			timestamp := c.Created.Format("2006-01-02 15:04")
-			lines = append(lines, fmt.Sprintf("  [%s] %s", timestamp, highlightMatches(c.Content, m.searchQuery)))
+			timestamp := ticket.FormatTime(c.Created, m.timeFormat, "2006-01-02 15:04")
+			author := ""
+			if c.Author != "" {
+				author = ticket.SanitizeLine(c.Author) + ": "
+			}
+			lines = append(lines, fmt.Sprintf("  [%s] %s%s", timestamp, author, highlightMatches(ticket.SanitizeText(c.Content), m.searchQuery)))
+			if c.IsAcked() {
+				ack := "acknowledged"
+				if c.AckedBy != "" {
+					ack += " by " + ticket.SanitizeLine(c.AckedBy)
+				}
+				lines = append(lines, helpStyle.Render(fmt.Sprintf("    (%s %s)", ack, ticket.FormatTime(c.Acked, m.timeFormat, "2006-01-02 15:04"))))
+			}
 		}
 	}
 
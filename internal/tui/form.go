@@ -11,6 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
@@ -80,7 +81,7 @@ func NewFormModel(store *storage.Store, projectCode string, t *ticket.Ticket) Fo
 	m.title = textinput.New()
 	m.title.Placeholder = "Ticket title"
 	m.title.PlaceholderStyle = placeholderStyle
-	m.title.CharLimit = 200
+	m.title.CharLimit = ticket.MaxTitleLength()
 	m.title.Width = 50
 
 	m.description = textarea.New()
@@ -88,6 +89,7 @@ func NewFormModel(store *storage.Store, projectCode string, t *ticket.Ticket) Fo
 	m.description.SetWidth(50)
 	m.description.SetHeight(5)
 	m.description.ShowLineNumbers = false
+	m.description.CharLimit = ticket.MaxDescriptionLength()
 
 	m.ticketType = textinput.New()
 	m.ticketType.Placeholder = "bug, feature, task, epic, cleanup"
@@ -96,13 +98,13 @@ func NewFormModel(store *storage.Store, projectCode string, t *ticket.Ticket) Fo
 	m.ticketType.Width = 30
 
 	m.priority = textinput.New()
-	m.priority.Placeholder = "0-5 (0=highest)"
+	m.priority.Placeholder = fmt.Sprintf("0-%d (0=highest)", ticket.MaxPriority())
 	m.priority.PlaceholderStyle = placeholderStyle
-	m.priority.CharLimit = 1
+	m.priority.CharLimit = len(strconv.Itoa(ticket.MaxPriority()))
 	m.priority.Width = 10
 
 	m.status = textinput.New()
-	m.status.Placeholder = "open, closed, icebox"
+	m.status.Placeholder = "open, closed, icebox, deleted"
 	m.status.PlaceholderStyle = placeholderStyle
 	m.status.CharLimit = 20
 	m.status.Width = 30
@@ -285,8 +287,8 @@ func (m FormModel) validate() map[formField]string {
 	pri := strings.TrimSpace(m.priority.Value())
 	if pri != "" {
 		p, err := strconv.Atoi(pri)
-		if err != nil || p < 0 || p > 5 {
-			errors[fieldPriority] = "Priority must be 0-5"
+		if err != nil || ticket.ValidatePriority(p) != nil {
+			errors[fieldPriority] = fmt.Sprintf("Priority must be 0-%d", ticket.MaxPriority())
 		}
 	}
 
@@ -353,10 +355,11 @@ func (m FormModel) save() tea.Cmd {
 
 		if m.isNew {
 			// Create new ticket
-			t, err := ticket.New(m.projectCode, title, description, issueType, priority, labels, assignee)
+			t, err := ticket.New(m.projectCode, title, description, issueType, priority, labels, assignee, 0)
 			if err != nil {
 				return ErrorMsg{Err: err}
 			}
+			t.CreatedBy = config.ResolveIdentity()
 
 			if err := m.store.Add(t); err != nil {
 				return ErrorMsg{Err: err}
@@ -379,9 +382,10 @@ func (m FormModel) save() tea.Cmd {
 		t.Description = description
 		t.Type = issueType
 		t.Status = issueStatus
-		t.Priority = priority
+		t.SetPriority(priority)
 		t.Assignee = assignee
 		t.Labels = labels
+		t.UpdatedBy = config.ResolveIdentity()
 
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
@@ -0,0 +1,20 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+func TestNewFormModel_PriorityLimit(t *testing.T) {
+	old := ticket.MaxPriority()
+	t.Cleanup(func() { ticket.SetMaxPriority(old) })
+
+	for _, tt := range []struct{ max, want int }{{4, 1}, {9, 1}, {10, 2}, {100, 3}} {
+		ticket.SetMaxPriority(tt.max)
+		m := NewFormModel(nil, "TH", nil)
+		if got := m.priority.CharLimit; got != tt.want {
+			t.Errorf("with max priority %d, CharLimit = %d, want %d", tt.max, got, tt.want)
+		}
+	}
+}
@@ -21,6 +21,7 @@ type KeyMap struct {
 	Edit         key.Binding
 	Close        key.Binding
 	Comment      key.Binding
+	CopyID       key.Binding
 	Search       key.Binding
 	Refresh      key.Binding
 	PriorityUp   key.Binding
@@ -97,6 +98,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("m"),
 			key.WithHelp("m", "comment"),
 		),
+		CopyID: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy ID"),
+		),
 		Search: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "search"),
@@ -175,6 +180,7 @@ func ListHelp() string {
 		helpKeyStyle.Render("n") + helpStyle.Render(" new  ") +
 		helpKeyStyle.Render("e") + helpStyle.Render(" edit  ") +
 		helpKeyStyle.Render("c") + helpStyle.Render(" close  ") +
+		helpKeyStyle.Render("y") + helpStyle.Render(" copy ID  ") +
 		helpKeyStyle.Render("+/-") + helpStyle.Render(" prio  ") +
 		helpKeyStyle.Render("b/f/t/E/C") + helpStyle.Render(" type  ") +
 		helpKeyStyle.Render("o/x/i/a") + helpStyle.Render(" filter  ") +
@@ -188,6 +194,7 @@ func DetailHelp() string {
 		helpKeyStyle.Render("e") + helpStyle.Render(" edit  ") +
 		helpKeyStyle.Render("c") + helpStyle.Render(" close  ") +
 		helpKeyStyle.Render("m") + helpStyle.Render(" comment  ") +
+		helpKeyStyle.Render("y") + helpStyle.Render(" copy ID  ") +
 		helpKeyStyle.Render("+/-") + helpStyle.Render(" prio  ") +
 		helpKeyStyle.Render("b/f/t/E/C") + helpStyle.Render(" type  ") +
 		helpKeyStyle.Render("j/k") + helpStyle.Render(" scroll  ") +
@@ -8,6 +8,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/abarth/thicket/internal/config"
 	"github.com/abarth/thicket/internal/storage"
 	"github.com/abarth/thicket/internal/ticket"
 )
@@ -35,6 +36,7 @@ type ListModel struct {
 	isSearching    bool
 	searchInput    textinput.Model
 	pendingCloseID string
+	noTruncate     bool // Show full titles even if they don't fit
 }
 
 // NewListModel creates a new list model.
@@ -267,6 +269,10 @@ func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 					return m, nil
 				}
 			}
+		case key.Matches(msg, m.keys.CopyID):
+			if len(m.tickets) > 0 && m.cursor < len(m.tickets) {
+				return m, copyToClipboard(m.tickets[m.cursor].ID)
+			}
 		case key.Matches(msg, m.keys.Refresh):
 			m.loading = true
 			return m, m.loadTickets()
@@ -305,7 +311,9 @@ func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 		case key.Matches(msg, m.keys.PriorityDown):
 			if len(m.tickets) > 0 && m.cursor < len(m.tickets) {
 				t := m.tickets[m.cursor]
-				return m, m.updatePriority(t.ID, t.Priority+1)
+				if t.Priority < ticket.MaxPriority() {
+					return m, m.updatePriority(t.ID, t.Priority+1)
+				}
 			}
 		case key.Matches(msg, m.keys.SetBug):
 			if len(m.tickets) > 0 && m.cursor < len(m.tickets) {
@@ -345,6 +353,7 @@ func (m ListModel) closeTicket(id string) tea.Cmd {
 			return ErrorMsg{Err: err}
 		}
 		t.Status = ticket.StatusClosed
+		t.UpdatedBy = config.ResolveIdentity()
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -358,7 +367,8 @@ func (m ListModel) updatePriority(id string, newPriority int) tea.Cmd {
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
-		t.Priority = newPriority
+		t.SetPriority(newPriority)
+		t.UpdatedBy = config.ResolveIdentity()
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -373,6 +383,7 @@ func (m ListModel) updateType(id string, newType ticket.Type) tea.Cmd {
 			return ErrorMsg{Err: err}
 		}
 		t.Type = newType
+		t.UpdatedBy = config.ResolveIdentity()
 		if err := m.store.Update(t); err != nil {
 			return ErrorMsg{Err: err}
 		}
@@ -494,13 +505,15 @@ func (m ListModel) View() string {
 }
 
 func (m ListModel) renderRow(cursor, id, pri, typ, status, title string, selected bool) string {
+	title = ticket.SanitizeLine(title)
+
 	// Truncate fields
 	titleWidth := m.width - 34
 	if titleWidth < 10 {
 		titleWidth = 10
 	}
 
-	if len(title) > titleWidth {
+	if len(title) > titleWidth && !m.noTruncate {
 		title = title[:titleWidth-3] + "..."
 	}
 	if typ == "" {
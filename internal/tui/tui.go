@@ -48,25 +48,35 @@ type Model struct {
 	showHelp bool
 
 	// File watching
-	ticketsPath    string
+	dataFiles      []string
 	watcherChan    chan FileChangedMsg
 	watcherCleanup func()
 }
 
-// New creates a new TUI model.
-func New(store *storage.Store, cfg *config.Config, ticketsPath string) Model {
+// Options adjusts how the TUI displays tickets.
+type Options struct {
+	NoTruncate bool // Show full titles in the list instead of cutting them to fit
+}
+
+// New creates a new TUI model that reloads when any of dataFiles changes.
+func New(store *storage.Store, cfg *config.Config, dataFiles []string, opts Options) Model {
 	// Set up file watcher with 100ms debounce
-	watchChan, cleanup := WatchFile(ticketsPath, 100*time.Millisecond)()
+	watchChan, cleanup := WatchFiles(dataFiles, 100*time.Millisecond)()
+
+	detail := NewDetailModel(store)
+	detail.timeFormat = cfg.TimeFormat
+	list := NewListModel(store)
+	list.noTruncate = opts.NoTruncate
 
 	return Model{
 		view:           viewList,
-		list:           NewListModel(store),
-		detail:         NewDetailModel(store),
+		list:           list,
+		detail:         detail,
 		form:           NewFormModel(store, cfg.ProjectCode, nil),
 		store:          store,
 		config:         cfg,
 		keys:           DefaultKeyMap(),
-		ticketsPath:    ticketsPath,
+		dataFiles:      dataFiles,
 		watcherChan:    watchChan,
 		watcherCleanup: cleanup,
 	}
@@ -285,8 +295,8 @@ func (m Model) renderHelpBar() string {
 }
 
 // Run starts the TUI application.
-func Run(store *storage.Store, cfg *config.Config, ticketsPath string) error {
-	model := New(store, cfg, ticketsPath)
+func Run(store *storage.Store, cfg *config.Config, dataFiles []string, opts Options) error {
+	model := New(store, cfg, dataFiles, opts)
 	p := tea.NewProgram(model, tea.WithAltScreen())
 	_, err := p.Run()
 	// Clean up the file watcher
@@ -14,6 +14,12 @@ type FileChangedMsg struct{}
 // The debounce parameter controls how long to wait after a change before
 // sending the message (to avoid rapid repeated updates).
 func WatchFile(path string, debounce time.Duration) func() (chan FileChangedMsg, func()) {
+	return WatchFiles([]string{path}, debounce)
+}
+
+// WatchFiles is like WatchFile but watches several files, sending a single
+// FileChangedMsg for changes to any of them within the debounce period.
+func WatchFiles(paths []string, debounce time.Duration) func() (chan FileChangedMsg, func()) {
 	return func() (chan FileChangedMsg, func()) {
 		ch := make(chan FileChangedMsg)
 
@@ -23,10 +29,12 @@ func WatchFile(path string, debounce time.Duration) func() (chan FileChangedMsg,
 			return ch, func() {}
 		}
 
-		if err := watcher.Add(path); err != nil {
-			watcher.Close()
-			close(ch)
-			return ch, func() {}
+		for _, path := range paths {
+			if err := watcher.Add(path); err != nil {
+				watcher.Close()
+				close(ch)
+				return ch, func() {}
+			}
 		}
 
 		done := make(chan struct{})
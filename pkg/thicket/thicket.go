@@ -0,0 +1,279 @@
+// Package thicket lets Go programs read and change a Thicket project without
+// shelling out to the CLI.
+//
+// Open a project by its root directory, the one containing .thicket:
+//
+//	p, err := thicket.Open("/path/to/repo")
+//	if err != nil {
+//		return err
+//	}
+//	defer p.Close()
+//
+//	t, err := p.Create(thicket.CreateOptions{Title: "Fix the parser", Priority: thicket.Priority(1)})
+//
+// Changes are written to the project's JSONL files just as the CLI writes
+// them, so the two can be used side by side. Commands configured as hooks in
+// config.json are not run.
+//
+// The length and priority limits in the project's config.json are applied
+// process-wide when a project is opened, so programs that open several
+// projects with different limits should not use them concurrently.
+package thicket
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+	"github.com/abarth/thicket/internal/storage"
+	"github.com/abarth/thicket/internal/ticket"
+)
+
+// Ticket is a single issue in the tracker.
+type Ticket = ticket.Ticket
+
+// Comment is a note attached to a ticket.
+type Comment = ticket.Comment
+
+// Dependency is a relationship between two tickets.
+type Dependency = ticket.Dependency
+
+// Status is the state of a ticket.
+type Status = ticket.Status
+
+// Type is the category of a ticket.
+type Type = ticket.Type
+
+// DependencyType is the kind of relationship a Dependency records.
+type DependencyType = ticket.DependencyType
+
+// CloseReason records why a ticket was closed.
+type CloseReason = ticket.CloseReason
+
+const (
+	StatusOpen    = ticket.StatusOpen
+	StatusClosed  = ticket.StatusClosed
+	StatusIcebox  = ticket.StatusIcebox
+	StatusDeleted = ticket.StatusDeleted
+
+	TypeBug     = ticket.TypeBug
+	TypeFeature = ticket.TypeFeature
+	TypeTask    = ticket.TypeTask
+	TypeEpic    = ticket.TypeEpic
+	TypeCleanup = ticket.TypeCleanup
+
+	DependencyBlockedBy   = ticket.DependencyBlockedBy
+	DependencyCreatedFrom = ticket.DependencyCreatedFrom
+	DependencyRelatedTo   = ticket.DependencyRelatedTo
+
+	CloseReasonDone      = ticket.CloseReasonDone
+	CloseReasonWontfix   = ticket.CloseReasonWontfix
+	CloseReasonDuplicate = ticket.CloseReasonDuplicate
+	CloseReasonObsolete  = ticket.CloseReasonObsolete
+)
+
+var (
+	// ErrNotInitialized is returned by Open when the directory has no
+	// Thicket project.
+	ErrNotInitialized = config.ErrNotInitialized
+
+	// ErrNotFound is returned when no ticket has the given ID.
+	ErrNotFound = errors.New("ticket not found")
+)
+
+// Project is an open Thicket project. It must be closed when no longer
+// needed.
+type Project struct {
+	store *storage.Store
+	cfg   *config.Config
+}
+
+// Open opens the Thicket project whose .thicket directory is directly under
+// root. Unlike the CLI, it neither searches parent directories nor honors
+// THICKET_DIR.
+func Open(root string) (*Project, error) {
+	paths := config.ProjectPaths(root)
+	cfg, err := config.LoadPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	ticket.SetLengthLimits(cfg.MaxTitleLength, cfg.MaxDescriptionLength)
+	ticket.SetMaxCommentLength(cfg.MaxCommentLength)
+	ticket.SetMaxPriority(cfg.PriorityMax)
+
+	store, err := storage.Open(paths)
+	if err != nil {
+		return nil, err
+	}
+	return &Project{store: store, cfg: cfg}, nil
+}
+
+// Close releases the project's cache.
+func (p *Project) Close() error {
+	return p.store.Close()
+}
+
+// ProjectCode returns the two-letter code that prefixes the project's
+// ticket IDs.
+func (p *Project) ProjectCode() string {
+	return p.cfg.ProjectCode
+}
+
+// DefaultPriority is the priority of tickets created without one, as with the
+// CLI.
+const DefaultPriority = 2
+
+// Priority returns a pointer to priority, for CreateOptions.Priority.
+func Priority(priority int) *int {
+	return &priority
+}
+
+// CreateOptions describes a new ticket. Only Title is required.
+type CreateOptions struct {
+	Title       string
+	Description string
+	Type        Type
+	Priority    *int // Nil means DefaultPriority; 0 is the highest priority
+	Labels      []string
+	Assignee    string
+	Estimate    int // Rough size in points; 0 means unestimated
+}
+
+// Create adds a new open ticket to the project and returns it.
+func (p *Project) Create(opts CreateOptions) (*Ticket, error) {
+	priority := DefaultPriority
+	if opts.Priority != nil {
+		priority = *opts.Priority
+	}
+	t, err := ticket.New(p.cfg.ProjectCode, opts.Title, opts.Description, opts.Type, priority, opts.Labels, opts.Assignee, opts.Estimate)
+	if err != nil {
+		return nil, err
+	}
+	t.CreatedBy = config.ResolveIdentity()
+	if err := p.store.Add(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// resolve expands a full or partial ticket ID, as accepted by the CLI, into
+// a full ID.
+func (p *Project) resolve(id string) (string, error) {
+	if len(id) >= 3 && id[2] == '-' {
+		id = strings.ToUpper(id[:2]) + "-" + strings.ToLower(id[3:])
+	}
+	resolved, err := p.store.ResolveID(id)
+	if errors.Is(err, storage.ErrNoMatchingTicket) {
+		return "", ErrNotFound
+	}
+	return resolved, err
+}
+
+// Get returns the ticket with the given full or partial ID, or ErrNotFound.
+func (p *Project) Get(id string) (*Ticket, error) {
+	id, err := p.resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	t, err := p.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, ErrNotFound
+	}
+	return t, nil
+}
+
+// List returns the tickets with the given status, ordered by priority. An
+// empty status lists every ticket that isn't deleted.
+func (p *Project) List(status Status) ([]*Ticket, error) {
+	if status == "" {
+		return p.store.List(nil)
+	}
+	if err := ticket.ValidateStatus(status); err != nil {
+		return nil, err
+	}
+	return p.store.List(&status)
+}
+
+// Update saves changes made to a ticket returned by Get, Create, or List,
+// recording when and by whom it was updated.
+func (p *Project) Update(t *Ticket) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	t.Updated = time.Now().UTC()
+	t.UpdatedBy = config.ResolveIdentity()
+	return p.store.Update(t)
+}
+
+// CloseTicket closes the ticket with the given ID for reason, which may be
+// empty. Closing a closed ticket does nothing.
+func (p *Project) CloseTicket(id string, reason CloseReason) (*Ticket, error) {
+	t, err := p.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status == ticket.StatusClosed {
+		return t, nil
+	}
+	if err := t.CloseWithReason(reason); err != nil {
+		return nil, err
+	}
+	if err := p.Update(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Comment adds a comment to the ticket with the given ID and returns it.
+func (p *Project) Comment(id, content string) (*Comment, error) {
+	t, err := p.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := ticket.NewComment(t.ID, content)
+	if err != nil {
+		return nil, err
+	}
+	c.Author = config.ResolveIdentity()
+	if err := p.store.AddComment(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Comments returns the comments on the ticket with the given ID, oldest
+// first.
+func (p *Project) Comments(id string) ([]*Comment, error) {
+	t, err := p.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return p.store.GetComments(t.ID)
+}
+
+// Link records that the ticket from has a dependency of type depType on the
+// ticket to. For example, Link(a, b, DependencyBlockedBy) marks a as blocked
+// by b. Links that already exist or would create a blocking cycle are
+// rejected.
+func (p *Project) Link(from, to string, depType DependencyType) (*Dependency, error) {
+	fromTicket, err := p.Get(from)
+	if err != nil {
+		return nil, err
+	}
+	toTicket, err := p.Get(to)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ticket.NewDependency(fromTicket.ID, toTicket.ID, depType)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.store.AddDependency(d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
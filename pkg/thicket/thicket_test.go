@@ -0,0 +1,178 @@
+package thicket
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abarth/thicket/internal/config"
+)
+
+// openTestProject initializes a project in a temporary directory and opens it.
+func openTestProject(t *testing.T) *Project {
+	t.Helper()
+	t.Setenv(config.IdentityEnv, "tester")
+
+	dir := t.TempDir()
+	if err := config.Init(dir, "TH"); err != nil {
+		t.Fatalf("config.Init() error = %v", err)
+	}
+	p, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func TestProject(t *testing.T) {
+	p := openTestProject(t)
+
+	blocker, err := p.Create(CreateOptions{Title: "Design the schema", Priority: Priority(1), Type: TypeTask})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	blocked, err := p.Create(CreateOptions{Title: "Write the migration", Priority: Priority(2), Labels: []string{"db"}})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if blocked.CreatedBy != "tester" {
+		t.Errorf("CreatedBy = %q, want 'tester'", blocked.CreatedBy)
+	}
+
+	got, err := p.Get(blocked.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Title != "Write the migration" || got.Labels[0] != "db" {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	// Partial and lowercase IDs resolve the way they do in the CLI.
+	if got, err := p.Get(blocked.ID[3:]); err != nil || got.ID != blocked.ID {
+		t.Errorf("Get(partial) = %v, %v", got, err)
+	}
+
+	if _, err := p.Link(blocked.ID, blocker.ID, DependencyBlockedBy); err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	if _, err := p.Link(blocker.ID, blocked.ID, DependencyBlockedBy); err == nil {
+		t.Error("Link() expected error for a blocking cycle")
+	}
+
+	c, err := p.Comment(blocked.ID, "Waiting on the schema")
+	if err != nil {
+		t.Fatalf("Comment() error = %v", err)
+	}
+	if c.Author != "tester" {
+		t.Errorf("Author = %q, want 'tester'", c.Author)
+	}
+	comments, err := p.Comments(blocked.ID)
+	if err != nil {
+		t.Fatalf("Comments() error = %v", err)
+	}
+	if len(comments) != 1 || comments[0].Content != "Waiting on the schema" {
+		t.Errorf("Comments() = %+v", comments)
+	}
+
+	if _, err := p.CloseTicket(blocker.ID, CloseReasonDone); err != nil {
+		t.Fatalf("CloseTicket() error = %v", err)
+	}
+
+	open, err := p.List(StatusOpen)
+	if err != nil {
+		t.Fatalf("List(open) error = %v", err)
+	}
+	if len(open) != 1 || open[0].ID != blocked.ID {
+		t.Errorf("List(open) = %v, want only %s", open, blocked.ID)
+	}
+	all, err := p.List("")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("List() returned %d tickets, want 2", len(all))
+	}
+
+	lastWeek := time.Now().UTC().AddDate(0, 0, -7)
+	got.Priority = 0
+	got.Updated = lastWeek
+	if err := p.Update(got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	updated, _ := p.Get(blocked.ID)
+	if updated.Priority != 0 {
+		t.Errorf("Priority = %d after Update(), want 0", updated.Priority)
+	}
+	if !updated.Updated.After(lastWeek) {
+		t.Errorf("Updated = %v after Update(), want it set to now", updated.Updated)
+	}
+}
+
+func TestProject_CreatePriority(t *testing.T) {
+	p := openTestProject(t)
+
+	unset, err := p.Create(CreateOptions{Title: "No priority given"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if unset.Priority != DefaultPriority {
+		t.Errorf("Priority = %d without one given, want %d", unset.Priority, DefaultPriority)
+	}
+
+	critical, err := p.Create(CreateOptions{Title: "Critical", Priority: Priority(0)})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if critical.Priority != 0 {
+		t.Errorf("Priority = %d, want 0", critical.Priority)
+	}
+}
+
+func TestProject_Reopen(t *testing.T) {
+	t.Setenv(config.IdentityEnv, "tester")
+	dir := t.TempDir()
+	if err := config.Init(dir, "TH"); err != nil {
+		t.Fatalf("config.Init() error = %v", err)
+	}
+
+	p, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	created, err := p.Create(CreateOptions{Title: "Persisted"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	p.Close()
+
+	// A fresh project handle reads the ticket back from disk.
+	p, err = Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer p.Close()
+	if got, err := p.Get(created.ID); err != nil || got.Title != "Persisted" {
+		t.Errorf("Get() = %v, %v", got, err)
+	}
+}
+
+func TestProject_Errors(t *testing.T) {
+	if _, err := Open(t.TempDir()); !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("Open(empty dir) error = %v, want ErrNotInitialized", err)
+	}
+
+	p := openTestProject(t)
+	if _, err := p.Get("TH-zzzzzz"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := p.Create(CreateOptions{Title: "  "}); err == nil {
+		t.Error("Create() expected error for empty title")
+	}
+	if _, err := p.Comment("TH-zzzzzz", "Hello"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Comment(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := p.List("bogus"); err == nil {
+		t.Error("List(bogus) expected error")
+	}
+}